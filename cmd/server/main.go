@@ -2,92 +2,228 @@ package main
 
 import (
 	"context"
+	"crypto/rand"
+	"database/sql"
+	"errors"
+	"flag"
+	"fmt"
 	"log"
 	"net"
 	"net/http"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
 
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/awslabs/aws-lambda-go-api-proxy/httpadapter"
-	"google.golang.org/grpc"
 
-	"github.com/rprajapati0067/quiz-app-tools/logger"
 	"github.com/rprajapati0067/quiz-game-backend/initilization"
-	authrpc "github.com/rprajapati0067/quiz-game-backend/rpc/auth"
-	questionrpc "github.com/rprajapati0067/quiz-game-backend/rpc/question"
-	userrpc "github.com/rprajapati0067/quiz-game-backend/rpc/user"
 
-	"github.com/rprajapati0067/quiz-game-backend/internal/handlers"
+	"github.com/rprajapati0067/quiz-game-backend/internal/app"
+	"github.com/rprajapati0067/quiz-game-backend/internal/config"
+	"github.com/rprajapati0067/quiz-game-backend/internal/lifecycle"
+	"github.com/rprajapati0067/quiz-game-backend/internal/logging"
 	"github.com/rprajapati0067/quiz-game-backend/internal/repository"
-	"github.com/rprajapati0067/quiz-game-backend/internal/service"
+	"github.com/rprajapati0067/quiz-game-backend/internal/token"
 )
 
 var httpAdapter *httpadapter.HandlerAdapter
 
-func initServices() (service.AuthService, service.UserService, service.QuestionService) {
-	userRepo := repository.NewMemoryUserRepository()
-	questionRepo := repository.NewMemoryQuestionRepository()
-
-	authSvc := service.NewAuthService(userRepo)
-	userSvc := service.NewUserService(userRepo)
-	questionSvc := service.NewQuestionService(questionRepo)
+// newRepositories opens the configured storage backend. The SQL backend
+// refuses to start on an outdated schema unless auto-migration is on.
+func newRepositories(ctx context.Context, cfg config.StorageConfig) (repository.Repositories, error) {
+	switch cfg.Backend {
+	case "memory":
+		logging.Info("Using in-memory storage")
+		return repository.NewMemoryRepositories(), nil
+	case "dynamodb":
+		awsCfg, err := awsconfig.LoadDefaultConfig(ctx)
+		if err != nil {
+			return repository.Repositories{}, err
+		}
+		client := dynamodb.NewFromConfig(awsCfg, func(o *dynamodb.Options) {
+			if cfg.DynamoEndpoint != "" {
+				o.BaseEndpoint = aws.String(cfg.DynamoEndpoint)
+			}
+		})
 
-	return authSvc, userSvc, questionSvc
+		tables := repository.DefaultDynamoTables(cfg.DynamoTablePrefix)
+		if cfg.DynamoCreateTables {
+			if err := repository.CreateDynamoTables(ctx, client, tables); err != nil {
+				return repository.Repositories{}, err
+			}
+		}
+		logging.Info("Using DynamoDB storage")
+		return repository.NewDynamoRepositories(client, tables), nil
+	case "sql":
+		db, err := openSQL(cfg)
+		if err != nil {
+			return repository.Repositories{}, err
+		}
+		pending, err := repository.PendingMigrations(ctx, db)
+		if err != nil {
+			return repository.Repositories{}, err
+		}
+		if len(pending) > 0 {
+			if !cfg.SQLAutoMigrate {
+				return repository.Repositories{}, fmt.Errorf("database schema is %d migration(s) behind, run the migrate command", len(pending))
+			}
+			if _, err := repository.Migrate(ctx, db); err != nil {
+				return repository.Repositories{}, err
+			}
+		}
+		logging.Info("Using SQL storage")
+		return repository.NewSQLRepositories(db), nil
+	default:
+		return repository.Repositories{}, fmt.Errorf("unknown storage backend %q", cfg.Backend)
+	}
 }
 
-func setupHTTPMux() *http.ServeMux {
-	authSvc, userSvc, questionSvc := initServices()
+// openSQL connects to the configured SQL database. SQLite defaults to
+// quiz.db in the working directory.
+func openSQL(cfg config.StorageConfig) (*sql.DB, error) {
+	dsn := cfg.SQLDSN
+	if dsn == "" && cfg.SQLDriver == "sqlite" {
+		dsn = "quiz.db"
+	}
+	return repository.OpenSQL(cfg.SQLDriver, dsn)
+}
 
-	httpHandlers := handlers.NewHTTPHandlers(authSvc, userSvc, questionSvc)
-	mux := http.NewServeMux()
-	httpHandlers.SetupRoutes(mux)
+// runMigrate applies pending schema migrations to the SQL database. It
+// refuses other backends rather than migrating a default SQLite file that
+// the server would never use.
+func runMigrate(cfg *config.Config) {
+	if cfg.Storage.Backend != "sql" {
+		log.Fatalf("migrate needs STORAGE_BACKEND=sql, not %q", cfg.Storage.Backend)
+	}
+	db, err := openSQL(cfg.Storage)
+	if err != nil {
+		log.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
 
-	return mux
+	applied, err := repository.Migrate(context.Background(), db)
+	for _, m := range applied {
+		logging.Info(fmt.Sprintf("Applied migration %04d_%s", m.Version, m.Name))
+	}
+	if err != nil {
+		log.Fatalf("Migration failed: %v", err)
+	}
+	if len(applied) == 0 {
+		logging.Info("Database schema is up to date")
+	}
 }
 
-func setupLocalServer() {
-	logger.Info("Starting in LOCAL mode")
+// newTokenManager signs with the PEM private key file (RS256 or EdDSA) or
+// with the shared secret (HS256). The verify keys are previous public keys
+// as kid=path pairs so tokens survive a key rotation. Without any key a
+// random secret is used; Validate only allows that for local runs.
+func newTokenManager(cfg config.JWTConfig) (*token.Manager, error) {
+	kid := cfg.KeyID
 
-	authSvc, userSvc, questionSvc := initServices()
+	var signing token.Key
+	switch {
+	case cfg.PrivateKeyFile != "":
+		data, err := os.ReadFile(cfg.PrivateKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		if signing, err = token.ParsePrivateKeyPEM(kid, data); err != nil {
+			return nil, err
+		}
+	case cfg.Secret != "":
+		signing = token.NewHMACKey(kid, []byte(cfg.Secret))
+	default:
+		logging.Info("No JWT key configured, using an ephemeral secret")
+		secret := make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, err
+		}
+		signing = token.NewHMACKey(kid, secret)
+	}
 
-	// Setup HTTP REST API server
-	httpMux := setupHTTPMux()
-	go func() {
-		logger.Info("HTTP REST API server running on :8080")
-		logger.Info("API endpoints available at http://localhost:8080/api/v1/")
-		if err := http.ListenAndServe(":8080", httpMux); err != nil {
-			log.Fatalf("Failed to start HTTP server: %v", err)
+	var verifyOnly []token.Key
+	for _, entry := range cfg.VerifyKeys {
+		id, path, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid verify key entry %q", entry)
 		}
-	}()
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		k, err := token.ParsePublicKeyPEM(id, data)
+		if err != nil {
+			return nil, err
+		}
+		verifyOnly = append(verifyOnly, k)
+	}
 
-	// Setup gRPC server
-	authHandler := handlers.NewAuthHandler(authSvc)
-	userHandler := handlers.NewUserHandler(userSvc)
-	questionHandler := handlers.NewQuestionHandler(questionSvc)
+	return token.NewManager(token.Config{
+		Issuer:    cfg.Issuer,
+		AccessTTL: cfg.AccessTTL,
+	}, signing, verifyOnly...)
+}
+
+// runLocalServer serves the REST API, gRPC and, if configured, the metrics
+// until ctx is done, then drains them and closes the repositories.
+func runLocalServer(ctx context.Context, a *app.App) error {
+	logging.Info("Starting in LOCAL mode")
+	cfg := a.Config
 
-	grpcServer := grpc.NewServer()
+	lc := lifecycle.New(cfg.ShutdownTimeout, cfg.DrainDelay)
+	lc.OnStop("storage", a.Repos.Close)
 
-	authrpc.RegisterAuthServiceServer(grpcServer, authHandler)
-	userrpc.RegisterUserServiceServer(grpcServer, userHandler)
-	questionrpc.RegisterQuestionServiceServer(grpcServer, questionHandler)
+	// Setup HTTP REST API server
+	httpListener, err := net.Listen("tcp", cfg.HTTPAddr)
+	if err != nil {
+		return err
+	}
+	lc.ServeHTTP("http", &http.Server{
+		Handler:           a.HTTPHandler(lc.Ready),
+		ReadHeaderTimeout: 10 * time.Second,
+	}, httpListener)
+	logging.Info("HTTP REST API server running on " + cfg.HTTPAddr)
 
-	listener, err := net.Listen("tcp", ":8082")
+	// Setup gRPC server
+	grpcServer := a.GRPCServer(lc.Ready)
+	grpcListener, err := net.Listen("tcp", cfg.GRPCAddr)
 	if err != nil {
-		log.Fatalf("Failed to listen: %v", err)
+		httpListener.Close()
+		return err
 	}
+	lc.ServeGRPC("grpc", grpcServer, grpcListener)
+	logging.Info("gRPC server running on " + cfg.GRPCAddr)
 
-	logger.Info("gRPC server running on :8082")
-	logger.Info("Health check available at http://localhost:8080/health")
-	if err := grpcServer.Serve(listener); err != nil {
-		log.Fatalf("Failed to serve gRPC: %v", err)
+	if cfg.MetricsAddr != "" {
+		metricsListener, err := net.Listen("tcp", cfg.MetricsAddr)
+		if err != nil {
+			httpListener.Close()
+			grpcListener.Close()
+			return err
+		}
+		lc.ServeHTTP("metrics", &http.Server{
+			Handler:           a.MetricsHandler(),
+			ReadHeaderTimeout: 10 * time.Second,
+		}, metricsListener)
+		logging.Info("Metrics server running on " + cfg.MetricsAddr)
 	}
+
+	return lc.Run(ctx)
 }
 
-func setupLambdaServer() {
-	logger.Info("Starting in LAMBDA mode")
+func setupLambdaServer(a *app.App) {
+	logging.Info("Starting in LAMBDA mode")
 
-	mux := setupHTTPMux()
+	// Lambda stops the process itself and waits for the current invocation
+	// first, so the function is ready for as long as it runs.
+	mux := a.HTTPHandler(func() bool { return true })
 	httpAdapter = httpadapter.New(mux)
 
 	lambda.Start(func(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
@@ -95,14 +231,83 @@ func setupLambdaServer() {
 	})
 }
 
+// usage is printed for -h and for an unknown command.
+const usage = `Usage:
+  server [flags]               run the server
+  server migrate [flags]       apply pending SQL migrations
+  server config print [flags]  print the effective configuration, secrets redacted
+
+Settings come from defaults, then the JSON file named by -config or
+CONFIG_FILE, then environment variables, then flags.
+
+Flags:`
+
 func main() {
 	// Initialize logger
 	initilization.Init()
 
-	// Check if running in Lambda environment
-	if os.Getenv("AWS_LAMBDA_FUNCTION_NAME") != "" {
-		setupLambdaServer()
-	} else {
-		setupLocalServer()
+	command, args := "serve", os.Args[1:]
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		command, args = args[0], args[1:]
+		if command == "config" {
+			if len(args) == 0 || args[0] != "print" {
+				log.Fatalf("Unknown command, expected \"config print\"\n%s", usage)
+			}
+			args = args[1:]
+		}
+	}
+
+	cfg, err := config.Load(args)
+	if errors.Is(err, flag.ErrHelp) {
+		fmt.Fprintln(os.Stderr, usage)
+		config.PrintFlags(os.Stderr)
+		return
+	}
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+	level, err := logging.ParseLevel(cfg.LogLevel)
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+	logging.SetLevel(level)
+
+	switch command {
+	case "serve":
+	case "migrate":
+		runMigrate(cfg)
+		return
+	case "config":
+		if err := cfg.Print(os.Stdout); err != nil {
+			log.Fatalf("Failed to print configuration: %v", err)
+		}
+		return
+	default:
+		log.Fatalf("Unknown command %q\n%s", command, usage)
+	}
+	logging.Info("Configuration: " + cfg.String())
+
+	tokens, err := newTokenManager(cfg.JWT)
+	if err != nil {
+		log.Fatalf("Failed to load JWT keys: %v", err)
+	}
+
+	repos, err := newRepositories(context.Background(), cfg.Storage)
+	if err != nil {
+		log.Fatalf("Failed to set up storage: %v", err)
+	}
+
+	// One App serves every transport so they share state.
+	a := app.New(cfg, repos, tokens)
+	if cfg.Lambda() {
+		setupLambdaServer(a)
+		return
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if err := runLocalServer(ctx, a); err != nil {
+		repos.Close()
+		log.Fatalf("Server failed: %v", err)
 	}
 }
@@ -0,0 +1,92 @@
+package access
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/rprajapati0067/quiz-game-backend/internal/apperr"
+	"github.com/rprajapati0067/quiz-game-backend/internal/models"
+	"github.com/rprajapati0067/quiz-game-backend/internal/repository"
+	"github.com/rprajapati0067/quiz-game-backend/internal/token"
+)
+
+var (
+	ErrUnauthenticated = apperr.New(apperr.Unauthenticated, "UNAUTHENTICATED", "missing or invalid bearer token")
+	ErrBlocked         = apperr.New(apperr.PermissionDenied, "USER_BLOCKED", "user is blocked")
+	ErrForbidden       = apperr.New(apperr.PermissionDenied, "PERMISSION_DENIED", "permission denied")
+)
+
+// TokenAuthenticator validates an access token, including whether its
+// session is still alive. A token the caller should not be let in with
+// fails with token.ErrInvalidToken or an Unauthenticated apperr.Error;
+// any other error is a failure to check it. service.AuthService satisfies
+// it.
+type TokenAuthenticator interface {
+	Authenticate(ctx context.Context, accessToken string) (*token.Claims, error)
+}
+
+// Authenticator turns an Authorization header into a Principal. It backs
+// both the HTTP middleware and the gRPC interceptors so the two transports
+// apply identical rules.
+type Authenticator struct {
+	tokens TokenAuthenticator
+	users  repository.UserRepository
+}
+
+func NewAuthenticator(tokens TokenAuthenticator, users repository.UserRepository) *Authenticator {
+	return &Authenticator{tokens: tokens, users: users}
+}
+
+// Authorize authenticates the caller and checks it holds perm. An empty
+// perm only requires authentication; Public skips both.
+func (a *Authenticator) Authorize(ctx context.Context, authorization string, perm Permission) (*Principal, error) {
+	if perm == Public {
+		return nil, nil
+	}
+	p, err := a.Authenticate(ctx, authorization)
+	if err != nil {
+		return nil, err
+	}
+	if perm != "" && !p.Can(perm) {
+		return nil, ErrForbidden
+	}
+	return p, nil
+}
+
+func (a *Authenticator) Authenticate(ctx context.Context, authorization string) (*Principal, error) {
+	raw, ok := strings.CutPrefix(authorization, "Bearer ")
+	if !ok || raw == "" {
+		return nil, ErrUnauthenticated
+	}
+	claims, err := a.tokens.Authenticate(ctx, raw)
+	if errors.Is(err, token.ErrInvalidToken) || apperr.KindOf(err) == apperr.Unauthenticated {
+		return nil, ErrUnauthenticated
+	}
+	if err != nil {
+		// A failure to look up the session is ours, not the caller's.
+		return nil, err
+	}
+
+	// Blocking takes effect immediately rather than when the token expires.
+	u, err := a.users.GetByID(ctx, claims.UserID())
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, ErrUnauthenticated
+	}
+	if err != nil {
+		return nil, err
+	}
+	if u.Blocked {
+		return nil, ErrBlocked
+	}
+
+	roles := u.Roles
+	if len(roles) == 0 {
+		roles = []models.Role{models.RolePlayer}
+	}
+	return &Principal{
+		UserID:    u.ID,
+		SessionID: claims.SessionID,
+		Roles:     roles,
+	}, nil
+}
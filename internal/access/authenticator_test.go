@@ -0,0 +1,87 @@
+package access
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/rprajapati0067/quiz-game-backend/internal/apperr"
+	"github.com/rprajapati0067/quiz-game-backend/internal/models"
+	"github.com/rprajapati0067/quiz-game-backend/internal/repository"
+	"github.com/rprajapati0067/quiz-game-backend/internal/token"
+)
+
+// failingTokens rejects every token with err.
+type failingTokens struct{ err error }
+
+func (f failingTokens) Authenticate(ctx context.Context, accessToken string) (*token.Claims, error) {
+	return nil, f.err
+}
+
+func TestAuthenticateMapsTokenErrors(t *testing.T) {
+	errRevoked := apperr.New(apperr.Unauthenticated, "SESSION_REVOKED", "session revoked")
+	errStore := errors.New("sessions table unavailable")
+	for _, tc := range []struct {
+		name    string
+		err     error
+		wantErr error
+	}{
+		{name: "invalid token", err: fmt.Errorf("%w: token is expired", token.ErrInvalidToken), wantErr: ErrUnauthenticated},
+		{name: "revoked session", err: errRevoked, wantErr: ErrUnauthenticated},
+		{name: "session lookup failure", err: errStore, wantErr: errStore},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			a := NewAuthenticator(failingTokens{tc.err}, repository.NewMemoryUserRepository())
+			_, err := a.Authenticate(context.Background(), "Bearer t0k")
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("got %v, want %v", err, tc.wantErr)
+			}
+			if tc.wantErr == errStore && apperr.KindOf(err) != apperr.Internal {
+				t.Fatalf("got kind %v for a lookup failure, want Internal", apperr.KindOf(err))
+			}
+		})
+	}
+}
+
+func TestAuthenticateChecksUser(t *testing.T) {
+	ctx := context.Background()
+	users := repository.NewMemoryUserRepository()
+	for _, u := range []*models.User{
+		{ID: "plain", Phone: "+15550100001"},
+		{ID: "blocked", Phone: "+15550100002", Blocked: true},
+	} {
+		if err := users.CreateUser(ctx, u); err != nil {
+			t.Fatalf("create %s: %v", u.ID, err)
+		}
+	}
+	tokens := fakeTokens{"plain": "plain", "blocked": "blocked", "deleted": "deleted"}
+	a := NewAuthenticator(tokens, users)
+
+	for _, tc := range []struct {
+		authorization string
+		wantErr       error
+	}{
+		{authorization: ""},
+		{authorization: "Basic cGxhaW4="},
+		{authorization: "Bearer "},
+		{authorization: "Bearer blocked", wantErr: ErrBlocked},
+		{authorization: "Bearer deleted"},
+	} {
+		want := tc.wantErr
+		if want == nil {
+			want = ErrUnauthenticated
+		}
+		if _, err := a.Authenticate(ctx, tc.authorization); !errors.Is(err, want) {
+			t.Errorf("%q: got %v, want %v", tc.authorization, err, want)
+		}
+	}
+
+	p, err := a.Authenticate(ctx, "Bearer plain")
+	if err != nil {
+		t.Fatalf("authenticate: %v", err)
+	}
+	if p.UserID != "plain" || p.SessionID != "s-plain" || len(p.Roles) != 1 || p.Roles[0] != models.RolePlayer {
+		t.Fatalf("got principal %+v, want a player in session s-plain", p)
+	}
+}
@@ -0,0 +1,34 @@
+package access
+
+import "github.com/rprajapati0067/quiz-game-backend/internal/models"
+
+type Permission string
+
+const (
+	// Public marks routes and RPCs that need no bearer token at all.
+	Public Permission = "public"
+
+	PermPlay           Permission = "quiz:play"
+	PermManageQuestion Permission = "questions:manage"
+	PermManageRoles    Permission = "roles:manage"
+	PermManageAwards   Permission = "awards:manage"
+	PermManageClaims   Permission = "claims:manage"
+)
+
+var rolePermissions = map[models.Role][]Permission{
+	models.RolePlayer:  {PermPlay},
+	models.RoleEditor:  {PermPlay, PermManageQuestion},
+	models.RoleSupport: {PermPlay, PermManageClaims},
+	models.RoleAdmin:   {PermPlay, PermManageQuestion, PermManageRoles, PermManageAwards, PermManageClaims},
+}
+
+func (p *Principal) Can(perm Permission) bool {
+	for _, r := range p.Roles {
+		for _, granted := range rolePermissions[r] {
+			if granted == perm {
+				return true
+			}
+		}
+	}
+	return false
+}
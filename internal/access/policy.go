@@ -0,0 +1,48 @@
+package access
+
+import (
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	authrpc "github.com/rprajapati0067/quiz-game-backend/rpc/auth"
+	questionrpc "github.com/rprajapati0067/quiz-game-backend/rpc/question"
+	rewardrpc "github.com/rprajapati0067/quiz-game-backend/rpc/reward"
+	userrpc "github.com/rprajapati0067/quiz-game-backend/rpc/user"
+)
+
+// methodPermissions declares what each RPC requires; the REST gateway
+// serves the same RPCs, so it covers both transports. Anything not listed
+// only needs an authenticated caller.
+var methodPermissions = map[string]Permission{
+	authrpc.AuthService_Signup_FullMethodName:      Public,
+	authrpc.AuthService_Login_FullMethodName:       Public,
+	authrpc.AuthService_VerifyPhone_FullMethodName: Public,
+	authrpc.AuthService_Refresh_FullMethodName:     Public,
+	authrpc.AuthService_Logout_FullMethodName:      Public,
+
+	questionrpc.QuestionService_ListQuestions_FullMethodName:  PermPlay,
+	questionrpc.QuestionService_SubmitAnswer_FullMethodName:   PermPlay,
+	questionrpc.QuestionService_CreateQuestion_FullMethodName: PermManageQuestion,
+
+	rewardrpc.RewardService_ListAwards_FullMethodName:   PermPlay,
+	rewardrpc.RewardService_ClaimAward_FullMethodName:   PermPlay,
+	rewardrpc.RewardService_ListMyClaims_FullMethodName: PermPlay,
+
+	rewardrpc.RewardService_CreateAward_FullMethodName:    PermManageAwards,
+	rewardrpc.RewardService_UpdateAward_FullMethodName:    PermManageAwards,
+	rewardrpc.RewardService_RestockAward_FullMethodName:   PermManageAwards,
+	rewardrpc.RewardService_RetireAward_FullMethodName:    PermManageAwards,
+	rewardrpc.RewardService_UploadVouchers_FullMethodName: PermManageAwards,
+
+	rewardrpc.RewardService_ListClaims_FullMethodName:   PermManageClaims,
+	rewardrpc.RewardService_ApproveClaim_FullMethodName: PermManageClaims,
+	rewardrpc.RewardService_FulfillClaim_FullMethodName: PermManageClaims,
+	rewardrpc.RewardService_RejectClaim_FullMethodName:  PermManageClaims,
+	rewardrpc.RewardService_RefundClaim_FullMethodName:  PermManageClaims,
+
+	userrpc.UserService_GrantRole_FullMethodName:  PermManageRoles,
+	userrpc.UserService_RevokeRole_FullMethodName: PermManageRoles,
+
+	healthpb.Health_Check_FullMethodName: Public,
+	healthpb.Health_List_FullMethodName:  Public,
+	healthpb.Health_Watch_FullMethodName: Public,
+}
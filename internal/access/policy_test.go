@@ -0,0 +1,185 @@
+package access
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	authrpc "github.com/rprajapati0067/quiz-game-backend/rpc/auth"
+	questionrpc "github.com/rprajapati0067/quiz-game-backend/rpc/question"
+	rewardrpc "github.com/rprajapati0067/quiz-game-backend/rpc/reward"
+	userrpc "github.com/rprajapati0067/quiz-game-backend/rpc/user"
+
+	"github.com/rprajapati0067/quiz-game-backend/internal/models"
+	"github.com/rprajapati0067/quiz-game-backend/internal/repository"
+	"github.com/rprajapati0067/quiz-game-backend/internal/token"
+)
+
+// fakeTokens accepts a token named after one of the users.
+type fakeTokens map[string]string
+
+func (f fakeTokens) Authenticate(ctx context.Context, accessToken string) (*token.Claims, error) {
+	userID, ok := f[accessToken]
+	if !ok {
+		return nil, token.ErrInvalidToken
+	}
+	return &token.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: userID}, SessionID: "s-" + userID}, nil
+}
+
+// newPolicyAuthenticator returns an Authenticator whose bearer tokens are
+// the role names, each for a user holding only that role.
+func newPolicyAuthenticator(t *testing.T) *Authenticator {
+	t.Helper()
+	users := repository.NewMemoryUserRepository()
+	tokens := fakeTokens{}
+	for i, r := range []models.Role{models.RolePlayer, models.RoleEditor, models.RoleSupport, models.RoleAdmin} {
+		u := &models.User{ID: "u-" + string(r), Phone: "+1555010000" + string(rune('0'+i)), Roles: []models.Role{r}}
+		if err := users.CreateUser(context.Background(), u); err != nil {
+			t.Fatalf("create %s: %v", r, err)
+		}
+		tokens[string(r)] = u.ID
+	}
+	return NewAuthenticator(tokens, users)
+}
+
+func TestMethodPermissions(t *testing.T) {
+	for method, want := range map[string]Permission{
+		authrpc.AuthService_Signup_FullMethodName:        Public,
+		authrpc.AuthService_Login_FullMethodName:         Public,
+		authrpc.AuthService_VerifyPhone_FullMethodName:   Public,
+		authrpc.AuthService_Refresh_FullMethodName:       Public,
+		authrpc.AuthService_Logout_FullMethodName:        Public,
+		authrpc.AuthService_ListSessions_FullMethodName:  "",
+		authrpc.AuthService_RevokeSession_FullMethodName: "",
+
+		questionrpc.QuestionService_ListQuestions_FullMethodName:  PermPlay,
+		questionrpc.QuestionService_SubmitAnswer_FullMethodName:   PermPlay,
+		questionrpc.QuestionService_CreateQuestion_FullMethodName: PermManageQuestion,
+
+		rewardrpc.RewardService_ListAwards_FullMethodName:     PermPlay,
+		rewardrpc.RewardService_ClaimAward_FullMethodName:     PermPlay,
+		rewardrpc.RewardService_ListMyClaims_FullMethodName:   PermPlay,
+		rewardrpc.RewardService_CreateAward_FullMethodName:    PermManageAwards,
+		rewardrpc.RewardService_UpdateAward_FullMethodName:    PermManageAwards,
+		rewardrpc.RewardService_RestockAward_FullMethodName:   PermManageAwards,
+		rewardrpc.RewardService_RetireAward_FullMethodName:    PermManageAwards,
+		rewardrpc.RewardService_UploadVouchers_FullMethodName: PermManageAwards,
+		rewardrpc.RewardService_ListClaims_FullMethodName:     PermManageClaims,
+		rewardrpc.RewardService_ApproveClaim_FullMethodName:   PermManageClaims,
+		rewardrpc.RewardService_FulfillClaim_FullMethodName:   PermManageClaims,
+		rewardrpc.RewardService_RejectClaim_FullMethodName:    PermManageClaims,
+		rewardrpc.RewardService_RefundClaim_FullMethodName:    PermManageClaims,
+
+		userrpc.UserService_Me_FullMethodName:            "",
+		userrpc.UserService_PointsHistory_FullMethodName: "",
+		userrpc.UserService_GrantRole_FullMethodName:     PermManageRoles,
+		userrpc.UserService_RevokeRole_FullMethodName:    PermManageRoles,
+
+		healthpb.Health_Check_FullMethodName: Public,
+		healthpb.Health_List_FullMethodName:  Public,
+		healthpb.Health_Watch_FullMethodName: Public,
+	} {
+		if got := methodPermissions[method]; got != want {
+			t.Errorf("%s requires %q, want %q", method, got, want)
+		}
+	}
+}
+
+// TestEveryMethodHasIntendedPermission catches RPCs added to a service
+// without a decision in TestMethodPermissions.
+func TestEveryMethodHasIntendedPermission(t *testing.T) {
+	authenticatedOnly := map[string]bool{
+		authrpc.AuthService_ListSessions_FullMethodName:  true,
+		authrpc.AuthService_RevokeSession_FullMethodName: true,
+		userrpc.UserService_Me_FullMethodName:            true,
+		userrpc.UserService_PointsHistory_FullMethodName: true,
+	}
+	for _, sd := range []grpc.ServiceDesc{
+		authrpc.AuthService_ServiceDesc,
+		questionrpc.QuestionService_ServiceDesc,
+		rewardrpc.RewardService_ServiceDesc,
+		userrpc.UserService_ServiceDesc,
+	} {
+		for _, m := range sd.Methods {
+			method := "/" + sd.ServiceName + "/" + m.MethodName
+			if _, listed := methodPermissions[method]; !listed && !authenticatedOnly[method] {
+				t.Errorf("%s has no permission in policy.go", method)
+			}
+		}
+	}
+}
+
+func TestRolePermissions(t *testing.T) {
+	a := newPolicyAuthenticator(t)
+	ctx := context.Background()
+	for _, tc := range []struct {
+		method string
+		// allowed lists the bearer tokens that may call method; "" is a
+		// caller without one.
+		allowed []string
+	}{
+		{authrpc.AuthService_Login_FullMethodName, []string{"", "forged", "player", "editor", "support", "admin"}},
+		{userrpc.UserService_Me_FullMethodName, []string{"player", "editor", "support", "admin"}},
+		{"/quiz.unlisted.Service/Method", []string{"player", "editor", "support", "admin"}},
+		{questionrpc.QuestionService_ListQuestions_FullMethodName, []string{"player", "editor", "support", "admin"}},
+		{questionrpc.QuestionService_CreateQuestion_FullMethodName, []string{"editor", "admin"}},
+		{rewardrpc.RewardService_ClaimAward_FullMethodName, []string{"player", "editor", "support", "admin"}},
+		{rewardrpc.RewardService_CreateAward_FullMethodName, []string{"admin"}},
+		{rewardrpc.RewardService_RefundClaim_FullMethodName, []string{"support", "admin"}},
+		{userrpc.UserService_GrantRole_FullMethodName, []string{"admin"}},
+	} {
+		allowed := map[string]bool{}
+		for _, caller := range tc.allowed {
+			allowed[caller] = true
+		}
+		for _, caller := range []string{"", "player", "editor", "support", "admin", "forged"} {
+			authorization := ""
+			if caller != "" {
+				authorization = "Bearer " + caller
+			}
+			_, err := a.Authorize(ctx, authorization, methodPermissions[tc.method])
+			switch {
+			case allowed[caller] && err != nil:
+				t.Errorf("%s as %q: %v", tc.method, caller, err)
+			case allowed[caller]:
+			case caller == "" || caller == "forged":
+				if !errors.Is(err, ErrUnauthenticated) {
+					t.Errorf("%s as %q: got %v, want ErrUnauthenticated", tc.method, caller, err)
+				}
+			case !errors.Is(err, ErrForbidden):
+				t.Errorf("%s as %q: got %v, want ErrForbidden", tc.method, caller, err)
+			}
+		}
+	}
+}
+
+func TestInterceptorAuthenticatesUnlistedMethods(t *testing.T) {
+	intercept := newPolicyAuthenticator(t).UnaryInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/quiz.unlisted.Service/Method"}
+	var got *Principal
+	handler := func(ctx context.Context, req any) (any, error) {
+		got, _ = PrincipalFromContext(ctx)
+		return "ok", nil
+	}
+
+	if _, err := intercept(context.Background(), nil, info, handler); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("without a token: got %v, want Unauthenticated", err)
+	}
+	if got != nil {
+		t.Fatal("handler ran without a token")
+	}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer player"))
+	if _, err := intercept(ctx, nil, info, handler); err != nil {
+		t.Fatalf("with a token: %v", err)
+	}
+	if got == nil || got.UserID != "u-player" {
+		t.Fatalf("handler saw principal %+v, want u-player", got)
+	}
+}
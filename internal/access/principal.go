@@ -0,0 +1,27 @@
+package access
+
+import (
+	"context"
+
+	"github.com/rprajapati0067/quiz-game-backend/internal/models"
+)
+
+// Principal is the authenticated caller of a request. Roles are read from
+// the user record on every request, so grants and revocations apply
+// without waiting for the access token to expire.
+type Principal struct {
+	UserID    string
+	SessionID string
+	Roles     []models.Role
+}
+
+type principalKey struct{}
+
+func WithPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(*Principal)
+	return p, ok
+}
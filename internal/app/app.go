@@ -0,0 +1,151 @@
+// Package app wires the services together once per process. Every
+// transport (the gRPC server, the REST gateway, the Lambda adapter) serves
+// the same App, so they share repositories, caches and in-memory state: a
+// user who signs up over REST can log in over gRPC.
+package app
+
+import (
+	"net/http"
+
+	"google.golang.org/grpc"
+
+	authrpc "github.com/rprajapati0067/quiz-game-backend/rpc/auth"
+	questionrpc "github.com/rprajapati0067/quiz-game-backend/rpc/question"
+	rewardrpc "github.com/rprajapati0067/quiz-game-backend/rpc/reward"
+	userrpc "github.com/rprajapati0067/quiz-game-backend/rpc/user"
+
+	"github.com/rprajapati0067/quiz-game-backend/internal/access"
+	"github.com/rprajapati0067/quiz-game-backend/internal/apperr"
+	"github.com/rprajapati0067/quiz-game-backend/internal/config"
+	"github.com/rprajapati0067/quiz-game-backend/internal/gateway"
+	"github.com/rprajapati0067/quiz-game-backend/internal/handlers"
+	"github.com/rprajapati0067/quiz-game-backend/internal/health"
+	"github.com/rprajapati0067/quiz-game-backend/internal/metrics"
+	"github.com/rprajapati0067/quiz-game-backend/internal/repository"
+	"github.com/rprajapati0067/quiz-game-backend/internal/service"
+	"github.com/rprajapati0067/quiz-game-backend/internal/token"
+)
+
+type App struct {
+	Config *config.Config
+	Repos  repository.Repositories
+	Tokens *token.Manager
+
+	Auth      service.AuthService
+	Users     service.UserService
+	Questions service.QuestionService
+	Rewards   service.RewardService
+
+	Authenticator *access.Authenticator
+
+	// Health holds the dependency checks behind the readiness probes.
+	Health *health.Registry
+}
+
+// New builds the services on top of repos and tokens. Call it once and hand
+// the result to every transport.
+func New(cfg *config.Config, repos repository.Repositories, tokens *token.Manager) *App {
+	sender := newOTPSender(cfg.OTP)
+	checks := health.NewRegistry()
+	checks.Register("storage", repos)
+	checks.Register("token_keys", tokens)
+	if c, ok := sender.(health.Checker); ok {
+		checks.Register("otp_sender", c)
+	}
+
+	otpSvc := service.NewOTPService(repos.OTPs, sender, service.OTPConfig{
+		Length:         cfg.OTP.Length,
+		TTL:            cfg.OTP.TTL,
+		MaxAttempts:    int32(cfg.OTP.MaxAttempts),
+		Window:         cfg.OTP.Window,
+		ResendCooldown: cfg.OTP.ResendCooldown,
+	})
+	authSvc := service.NewAuthService(repos.Users, repos.Sessions, otpSvc, tokens, cfg.AdminPhones)
+
+	return &App{
+		Config: cfg,
+		Repos:  repos,
+		Tokens: tokens,
+
+		Auth:  authSvc,
+		Users: service.NewUserService(repos.Users, repos.Ledger),
+		Questions: service.NewQuestionService(repos.Questions, repos.Answers, repos.Ledger, service.QuestionConfig{
+			ShuffleOptions: cfg.Features.ShuffleOptions,
+		}),
+		Rewards: service.NewRewardService(repos.Awards, repos.Ledger, repos.Vouchers, service.RewardConfig{
+			LowVoucherThreshold: cfg.Rewards.LowVoucherThreshold,
+		}),
+
+		Authenticator: access.NewAuthenticator(authSvc, repos.Users),
+		Health:        checks,
+	}
+}
+
+// newOTPSender writes codes to the outbox file when one is configured and
+// to the log otherwise. Neither is suitable for production delivery.
+func newOTPSender(cfg config.OTPConfig) service.OTPSender {
+	if cfg.OutboxFile != "" {
+		return service.NewFileOTPSender(cfg.OutboxFile)
+	}
+	return service.NewLogOTPSender()
+}
+
+// UnaryInterceptors is the interceptor chain shared by the gRPC server and
+// the REST gateway.
+func (a *App) UnaryInterceptors() []grpc.UnaryServerInterceptor {
+	return []grpc.UnaryServerInterceptor{apperr.UnaryServerInterceptor(), a.Authenticator.UnaryInterceptor()}
+}
+
+// RegisterServices registers the RPC handlers on s, which is either a gRPC
+// server or the REST gateway.
+func (a *App) RegisterServices(s grpc.ServiceRegistrar) {
+	authrpc.RegisterAuthServiceServer(s, handlers.NewAuthHandler(a.Auth))
+	userrpc.RegisterUserServiceServer(s, handlers.NewUserHandler(a.Users))
+	questionrpc.RegisterQuestionServiceServer(s, handlers.NewQuestionHandler(a.Questions))
+	rewardrpc.RegisterRewardServiceServer(s, handlers.NewRewardHandler(a.Rewards))
+}
+
+// GRPCServer returns a gRPC server with the services and grpc.health.v1
+// registered. Health reports NOT_SERVING once ready returns false. Every RPC
+// is recorded in the gRPC server metrics.
+func (a *App) GRPCServer(ready func() bool, opts ...grpc.ServerOption) *grpc.Server {
+	unary := append([]grpc.UnaryServerInterceptor{metrics.UnaryServerInterceptor()}, a.UnaryInterceptors()...)
+	opts = append(opts,
+		grpc.ChainUnaryInterceptor(unary...),
+		grpc.ChainStreamInterceptor(metrics.StreamServerInterceptor(), apperr.StreamServerInterceptor(), a.Authenticator.StreamInterceptor()),
+	)
+	s := grpc.NewServer(opts...)
+	a.RegisterServices(s)
+	a.Health.RegisterGRPC(s, ready)
+	return s
+}
+
+// HTTPHandler serves the REST API and the health probes. The probes are
+// /livez, with /health and /healthz kept as aliases for existing settings,
+// and /readyz, the only one that runs the checks and that also fails once
+// ready returns false. Every request is recorded in the HTTP metrics, which
+// MetricsHandler serves on a listener of their own.
+func (a *App) HTTPHandler(ready func() bool) http.Handler {
+	// The REST API is derived from the google.api.http annotations in
+	// proto/*.proto.
+	gw := gateway.New(a.UnaryInterceptors()...)
+	a.RegisterServices(gw)
+
+	mux := http.NewServeMux()
+	livez := health.LiveHandler()
+	mux.Handle("/livez", livez)
+	mux.Handle("/health", livez)
+	mux.Handle("/healthz", livez)
+	mux.Handle("/readyz", a.Health.ReadyHandler(ready))
+	mux.Handle("/", gw)
+	return metrics.Middleware(mux)
+}
+
+// MetricsHandler serves the Prometheus metrics at /metrics. It is
+// unauthenticated, so it is meant for a private listener rather than the
+// API's.
+func (a *App) MetricsHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics.Handler())
+	return mux
+}
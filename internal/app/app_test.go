@@ -0,0 +1,221 @@
+package app
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	authrpc "github.com/rprajapati0067/quiz-game-backend/rpc/auth"
+	questionrpc "github.com/rprajapati0067/quiz-game-backend/rpc/question"
+	userrpc "github.com/rprajapati0067/quiz-game-backend/rpc/user"
+
+	"github.com/rprajapati0067/quiz-game-backend/internal/access"
+	"github.com/rprajapati0067/quiz-game-backend/internal/config"
+	"github.com/rprajapati0067/quiz-game-backend/internal/models"
+	"github.com/rprajapati0067/quiz-game-backend/internal/repository"
+	"github.com/rprajapati0067/quiz-game-backend/internal/token"
+)
+
+const testPhone = "+15550100"
+
+// testApp serves one App over both transports: the REST API from an
+// httptest server and gRPC from a loopback listener.
+type testApp struct {
+	app    *App
+	outbox string
+	rest   *httptest.Server
+	conn   *grpc.ClientConn
+}
+
+func newTestApp(t *testing.T) *testApp {
+	t.Helper()
+	cfg := config.Default()
+	cfg.OTP.OutboxFile = filepath.Join(t.TempDir(), "outbox")
+	tokens, err := token.NewManager(token.Config{
+		Issuer:    cfg.JWT.Issuer,
+		AccessTTL: cfg.JWT.AccessTTL,
+	}, token.NewHMACKey(cfg.JWT.KeyID, []byte("app-test-secret")))
+	if err != nil {
+		t.Fatalf("token manager: %v", err)
+	}
+	a := New(cfg, repository.NewMemoryRepositories(), tokens)
+	ready := func() bool { return true }
+
+	rest := httptest.NewServer(a.HTTPHandler(ready))
+	t.Cleanup(rest.Close)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	srv := a.GRPCServer(ready)
+	go srv.Serve(ln)
+	t.Cleanup(srv.Stop)
+	conn, err := grpc.NewClient(ln.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return &testApp{app: a, outbox: cfg.OTP.OutboxFile, rest: rest, conn: conn}
+}
+
+// call sends a REST request and decodes the response into res, failing
+// unless it answers 200.
+func (ta *testApp) call(t *testing.T, method, path, bearer string, req, res proto.Message) {
+	t.Helper()
+	var body io.Reader
+	if req != nil {
+		raw, err := protojson.Marshal(req)
+		if err != nil {
+			t.Fatalf("encode %s: %v", path, err)
+		}
+		body = bytes.NewReader(raw)
+	}
+	httpReq, err := http.NewRequest(method, ta.rest.URL+path, body)
+	if err != nil {
+		t.Fatalf("request %s: %v", path, err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	if bearer != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+bearer)
+	}
+	httpRes, err := http.DefaultClient.Do(httpReq)
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, path, err)
+	}
+	defer httpRes.Body.Close()
+	raw, err := io.ReadAll(httpRes.Body)
+	if err != nil {
+		t.Fatalf("read %s: %v", path, err)
+	}
+	if httpRes.StatusCode != http.StatusOK {
+		t.Fatalf("%s %s: status %d: %s", method, path, httpRes.StatusCode, raw)
+	}
+	if err := protojson.Unmarshal(raw, res); err != nil {
+		t.Fatalf("decode %s: %v", path, err)
+	}
+}
+
+// lastCode returns the most recent code written to the outbox for phone.
+func (ta *testApp) lastCode(t *testing.T, phone string) string {
+	t.Helper()
+	f, err := os.Open(ta.outbox)
+	if err != nil {
+		t.Fatalf("open outbox: %v", err)
+	}
+	defer f.Close()
+	var code string
+	lines := bufio.NewScanner(f)
+	for lines.Scan() {
+		fields := strings.Split(lines.Text(), "\t")
+		if len(fields) == 3 && fields[1] == phone {
+			code = fields[2]
+		}
+	}
+	if code == "" {
+		t.Fatalf("no code sent to %s", phone)
+	}
+	return code
+}
+
+func TestRESTSessionWorksOverGRPC(t *testing.T) {
+	ta := newTestApp(t)
+
+	signup := &authrpc.SignupResponse{}
+	ta.call(t, http.MethodPost, "/api/v1/auth/signup", "", &authrpc.SignupRequest{Name: "Ada", Phone: testPhone}, signup)
+	login := &authrpc.LoginResponse{}
+	ta.call(t, http.MethodPost, "/api/v1/auth/login", "", &authrpc.LoginRequest{Phone: testPhone, Otp: ta.lastCode(t, testPhone)}, login)
+	if login.Token == "" {
+		t.Fatal("login returned no token")
+	}
+
+	editor := access.WithPrincipal(context.Background(), &access.Principal{UserID: "editor", Roles: []models.Role{models.RoleEditor}})
+	q, err := ta.app.Questions.Create(editor, "2 + 2?", []string{"3", "4"}, 1, 1)
+	if err != nil {
+		t.Fatalf("create question: %v", err)
+	}
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+login.Token)
+	users := userrpc.NewUserServiceClient(ta.conn)
+	me, err := users.Me(ctx, &userrpc.MeRequest{})
+	if err != nil {
+		t.Fatalf("grpc me: %v", err)
+	}
+	if me.UserId != signup.UserId || !me.Verified || me.Points != 0 {
+		t.Fatalf("grpc me %+v, want verified user %s with no points", me, signup.UserId)
+	}
+
+	answer, err := questionrpc.NewQuestionServiceClient(ta.conn).SubmitAnswer(ctx, &questionrpc.SubmitAnswerRequest{
+		QuestionId:     q.ID,
+		SelectedIndex:  1,
+		IdempotencyKey: "first",
+	})
+	if err != nil {
+		t.Fatalf("grpc submit: %v", err)
+	}
+	if !answer.Correct || answer.UpdatedPoints <= 0 {
+		t.Fatalf("grpc submit %+v, want a correct answer earning points", answer)
+	}
+	points := answer.UpdatedPoints
+
+	me, err = users.Me(ctx, &userrpc.MeRequest{})
+	if err != nil {
+		t.Fatalf("grpc me: %v", err)
+	}
+	if me.Points != points {
+		t.Fatalf("grpc me has %d points, want %d", me.Points, points)
+	}
+
+	// The REST API sees the same balance and the same idempotency keys.
+	restMe := &userrpc.MeResponse{}
+	ta.call(t, http.MethodGet, "/api/v1/user/me", login.Token, nil, restMe)
+	if restMe.Points != points {
+		t.Fatalf("rest me has %d points, want %d", restMe.Points, points)
+	}
+	replay := &questionrpc.SubmitAnswerResponse{}
+	ta.call(t, http.MethodPost, "/api/v1/questions/submit", login.Token, &questionrpc.SubmitAnswerRequest{
+		QuestionId:     q.ID,
+		SelectedIndex:  1,
+		IdempotencyKey: "first",
+	}, replay)
+	if !replay.Replayed || replay.UpdatedPoints != points {
+		t.Fatalf("rest submit %+v, want a replay of the gRPC answer", replay)
+	}
+	ta.call(t, http.MethodGet, "/api/v1/user/me", login.Token, nil, restMe)
+	if restMe.Points != points {
+		t.Fatalf("rest me has %d points after the replay, want %d", restMe.Points, points)
+	}
+}
+
+func TestMetricsAreOnlyOnTheirOwnHandler(t *testing.T) {
+	ta := newTestApp(t)
+	res, err := http.Get(ta.rest.URL + "/metrics")
+	if err != nil {
+		t.Fatalf("get API /metrics: %v", err)
+	}
+	res.Body.Close()
+	if res.StatusCode == http.StatusOK {
+		t.Fatal("the API handler serves /metrics")
+	}
+
+	w := httptest.NewRecorder()
+	ta.app.MetricsHandler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "http_requests_total") {
+		t.Fatalf("metrics handler: status %d: %.200s", w.Code, w.Body)
+	}
+}
@@ -0,0 +1,105 @@
+// Package apperr is the error model shared by the services and the
+// transports. Services declare their failures as *Error values carrying a
+// Kind, which decides the gRPC code and HTTP status, and a stable Code that
+// clients can branch on whatever transport they use. GRPCStatus and
+// WriteHTTP are the only places errors are translated for the wire.
+package apperr
+
+import (
+	"errors"
+	"strings"
+)
+
+// Kind classifies an error by what the caller can do about it.
+type Kind int
+
+const (
+	// Internal is any failure the caller cannot fix. Its message is not
+	// shown to clients.
+	Internal Kind = iota
+	NotFound
+	AlreadyExists
+	InvalidArgument
+	Unauthenticated
+	PermissionDenied
+	// FailedPrecondition means the request is valid but the current state
+	// of the system does not allow it, like claiming with too few points.
+	FailedPrecondition
+	// Aborted means the request lost a race with another writer and may
+	// succeed if retried.
+	Aborted
+	ResourceExhausted
+)
+
+// Error is a failure with a Kind and a stable machine-readable Code such as
+// "USER_NOT_FOUND". Services wrap it with fmt.Errorf("%w: ...") to add
+// detail; the wrapped error keeps its Kind and Code. Violations lists the
+// offending request fields of an InvalidArgument error.
+type Error struct {
+	Kind       Kind
+	Code       string
+	Message    string
+	Violations []FieldViolation
+}
+
+// FieldViolation describes one invalid request field. Field is the wire
+// name, with an index for list elements, like "options[2]".
+type FieldViolation struct {
+	Field       string `json:"field"`
+	Description string `json:"description"`
+}
+
+// New returns an Error. Declare the result as a package-level variable so
+// callers can match it with errors.Is.
+func New(kind Kind, code, message string) *Error {
+	return &Error{Kind: kind, Code: code, Message: message}
+}
+
+func (e *Error) Error() string {
+	if len(e.Violations) == 0 {
+		return e.Message
+	}
+	var b strings.Builder
+	b.WriteString(e.Message)
+	for i, v := range e.Violations {
+		if i == 0 {
+			b.WriteString(": ")
+		} else {
+			b.WriteString("; ")
+		}
+		b.WriteString(v.Field + " " + v.Description)
+	}
+	return b.String()
+}
+
+// Is reports whether target is an *Error with the same Code, so the copies
+// made by WithViolations still match the declared error.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+// WithViolations returns a copy of e listing the given invalid fields.
+func (e *Error) WithViolations(violations ...FieldViolation) *Error {
+	c := *e
+	c.Violations = violations
+	return &c
+}
+
+// From returns the first *Error in err's chain.
+func From(err error) (*Error, bool) {
+	var e *Error
+	if errors.As(err, &e) {
+		return e, true
+	}
+	return nil, false
+}
+
+// KindOf returns the Kind of the first *Error in err's chain, or Internal
+// if there is none.
+func KindOf(err error) Kind {
+	if e, ok := From(err); ok {
+		return e.Kind
+	}
+	return Internal
+}
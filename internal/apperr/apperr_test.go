@@ -0,0 +1,209 @@
+package apperr
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	errNotFound = New(NotFound, "AWARD_NOT_FOUND", "award not found")
+	errInvalid  = New(InvalidArgument, "INVALID_ARGUMENT", "invalid request")
+	errSecret   = errors.New("dial tcp 10.0.0.7:5432: connection refused")
+)
+
+func TestGRPCStatusDetails(t *testing.T) {
+	err := fmt.Errorf("%w: bad fields", errInvalid.WithViolations(
+		FieldViolation{Field: "phone", Description: "is required"},
+		FieldViolation{Field: "options[2]", Description: "is empty"},
+	))
+	st := GRPCStatus(err)
+	if st.Code() != codes.InvalidArgument || st.Message() != err.Error() {
+		t.Fatalf("got %v %q, want InvalidArgument %q", st.Code(), st.Message(), err.Error())
+	}
+
+	var info *errdetails.ErrorInfo
+	var br *errdetails.BadRequest
+	for _, d := range st.Details() {
+		switch d := d.(type) {
+		case *errdetails.ErrorInfo:
+			info = d
+		case *errdetails.BadRequest:
+			br = d
+		}
+	}
+	if info == nil || info.Reason != "INVALID_ARGUMENT" || info.Domain != Domain {
+		t.Fatalf("error info %v, want reason INVALID_ARGUMENT in %s", info, Domain)
+	}
+	if br == nil || len(br.FieldViolations) != 2 ||
+		br.FieldViolations[0].Field != "phone" || br.FieldViolations[1].Description != "is empty" {
+		t.Fatalf("bad request %v, want both violations", br)
+	}
+
+	st = GRPCStatus(errNotFound)
+	for _, d := range st.Details() {
+		if _, ok := d.(*errdetails.BadRequest); ok {
+			t.Fatal("error without violations got a BadRequest detail")
+		}
+	}
+	if st.Code() != codes.NotFound || CodeOf(st.Err()) != "AWARD_NOT_FOUND" {
+		t.Fatalf("got %v with code %q, want NotFound AWARD_NOT_FOUND", st.Code(), CodeOf(st.Err()))
+	}
+}
+
+func TestGRPCStatusPassesStatusesThrough(t *testing.T) {
+	st := status.New(codes.Unavailable, "try later")
+	if got := GRPCStatus(st.Err()); got.Code() != codes.Unavailable || got.Message() != "try later" {
+		t.Fatalf("got %v %q, want the original status", got.Code(), got.Message())
+	}
+}
+
+func TestInternalErrorsAreHidden(t *testing.T) {
+	for name, err := range map[string]error{
+		"plain":          fmt.Errorf("load user: %w", errSecret),
+		"internal kind":  fmt.Errorf("%w: %v", New(Internal, "STORE_DOWN", "store down"), errSecret),
+		"status of kind": GRPCStatus(errSecret).Err(),
+	} {
+		t.Run(name, func(t *testing.T) {
+			st := GRPCStatus(err)
+			if st.Code() != codes.Internal || st.Message() != "internal error" || len(st.Details()) != 0 {
+				t.Fatalf("gRPC: got %v %q %v, want a bare internal error", st.Code(), st.Message(), st.Details())
+			}
+
+			w := httptest.NewRecorder()
+			WriteHTTP(w, err)
+			p := decodeProblem(t, w)
+			if w.Code != http.StatusInternalServerError || p.Code != "INTERNAL" || p.Detail != "internal error" {
+				t.Fatalf("HTTP: got %d %+v, want a bare internal error", w.Code, p)
+			}
+		})
+	}
+}
+
+func decodeProblem(t *testing.T, w *httptest.ResponseRecorder) Problem {
+	t.Helper()
+	if got := w.Header().Get("Content-Type"); got != "application/problem+json" {
+		t.Fatalf("content type %q, want application/problem+json", got)
+	}
+	var p Problem
+	if err := json.Unmarshal(w.Body.Bytes(), &p); err != nil {
+		t.Fatalf("decode problem: %v", err)
+	}
+	if p.Status != w.Code || p.Title != http.StatusText(w.Code) {
+		t.Fatalf("problem %+v does not match status %d", p, w.Code)
+	}
+	return p
+}
+
+func TestWriteHTTP(t *testing.T) {
+	violations := []FieldViolation{{Field: "phone", Description: "is required"}}
+	for _, tc := range []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+		wantDetail string
+		wantViol   []FieldViolation
+	}{
+		{
+			name:       "not found",
+			err:        errNotFound,
+			wantStatus: http.StatusNotFound,
+			wantCode:   "AWARD_NOT_FOUND",
+			wantDetail: "award not found",
+		},
+		{
+			name:       "wrapped",
+			err:        fmt.Errorf("%w: a1", errNotFound),
+			wantStatus: http.StatusNotFound,
+			wantCode:   "AWARD_NOT_FOUND",
+			wantDetail: "award not found: a1",
+		},
+		{
+			name:       "violations",
+			err:        errInvalid.WithViolations(violations...),
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "INVALID_ARGUMENT",
+			wantDetail: "invalid request: phone is required",
+			wantViol:   violations,
+		},
+		{
+			name:       "violations through a status",
+			err:        GRPCStatus(errInvalid.WithViolations(violations...)).Err(),
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "INVALID_ARGUMENT",
+			wantDetail: "invalid request: phone is required",
+			wantViol:   violations,
+		},
+		{
+			name:       "conflict",
+			err:        New(FailedPrecondition, "INSUFFICIENT_POINTS", "not enough points"),
+			wantStatus: http.StatusConflict,
+			wantCode:   "INSUFFICIENT_POINTS",
+			wantDetail: "not enough points",
+		},
+		{
+			name:       "rate limited",
+			err:        New(ResourceExhausted, "OTP_RESEND_TOO_SOON", "wait"),
+			wantStatus: http.StatusTooManyRequests,
+			wantCode:   "OTP_RESEND_TOO_SOON",
+			wantDetail: "wait",
+		},
+		{
+			name:       "foreign status",
+			err:        status.Error(codes.PermissionDenied, "no"),
+			wantStatus: http.StatusForbidden,
+			wantCode:   "",
+			wantDetail: "no",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			WriteHTTP(w, tc.err)
+			p := decodeProblem(t, w)
+			if w.Code != tc.wantStatus || p.Code != tc.wantCode || p.Detail != tc.wantDetail {
+				t.Fatalf("got %d %+v, want %d %s %q", w.Code, p, tc.wantStatus, tc.wantCode, tc.wantDetail)
+			}
+			if !reflect.DeepEqual(p.Violations, tc.wantViol) {
+				t.Fatalf("violations %v, want %v", p.Violations, tc.wantViol)
+			}
+		})
+	}
+
+	w := httptest.NewRecorder()
+	WriteHTTP(w, New(Unauthenticated, "UNAUTHENTICATED", "sign in"))
+	if w.Code != http.StatusUnauthorized || w.Header().Get("WWW-Authenticate") != "Bearer" {
+		t.Fatalf("got %d with WWW-Authenticate %q, want 401 with Bearer", w.Code, w.Header().Get("WWW-Authenticate"))
+	}
+}
+
+func TestFromStatusRoundTrip(t *testing.T) {
+	for kind := range grpcCodes {
+		if kind == Internal {
+			continue
+		}
+		orig := New(kind, "SOME_CODE", "something happened").
+			WithViolations(FieldViolation{Field: "f", Description: "is bad"})
+		got := fromStatus(GRPCStatus(orig))
+		if got.Kind != kind || got.Code != orig.Code || got.Message != orig.Error() ||
+			!reflect.DeepEqual(got.Violations, orig.Violations) {
+			t.Fatalf("kind %v: round trip gave %+v, want %+v", kind, got, orig)
+		}
+		if !errors.Is(got, orig) {
+			t.Fatalf("kind %v: round trip does not match the original with errors.Is", kind)
+		}
+	}
+
+	got := fromStatus(status.New(codes.Unimplemented, "nope"))
+	if got.Kind != Internal || got.Code != "" {
+		t.Fatalf("unknown code gave %+v, want an Internal error without a code", got)
+	}
+}
@@ -0,0 +1,119 @@
+package apperr
+
+import (
+	"context"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+
+	"github.com/rprajapati0067/quiz-game-backend/internal/logging"
+)
+
+// Domain is the ErrorInfo domain of every status built by GRPCStatus.
+const Domain = "quiz"
+
+var grpcCodes = map[Kind]codes.Code{
+	Internal:           codes.Internal,
+	NotFound:           codes.NotFound,
+	AlreadyExists:      codes.AlreadyExists,
+	InvalidArgument:    codes.InvalidArgument,
+	Unauthenticated:    codes.Unauthenticated,
+	PermissionDenied:   codes.PermissionDenied,
+	FailedPrecondition: codes.FailedPrecondition,
+	Aborted:            codes.Aborted,
+	ResourceExhausted:  codes.ResourceExhausted,
+}
+
+// GRPCStatus translates err into a gRPC status whose ErrorInfo detail
+// carries the error's Code as its reason, plus a BadRequest detail listing
+// any field violations. Errors that already are statuses
+// pass through unchanged; anything without a Kind is logged and reported as
+// a bare Internal status.
+func GRPCStatus(err error) *status.Status {
+	if st, ok := status.FromError(err); ok {
+		return st
+	}
+	e, ok := From(err)
+	if !ok || e.Kind == Internal {
+		logging.Error(err.Error())
+		return status.New(codes.Internal, "internal error")
+	}
+
+	details := []protoadapt.MessageV1{&errdetails.ErrorInfo{Reason: e.Code, Domain: Domain}}
+	if len(e.Violations) > 0 {
+		br := &errdetails.BadRequest{}
+		for _, v := range e.Violations {
+			br.FieldViolations = append(br.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       v.Field,
+				Description: v.Description,
+			})
+		}
+		details = append(details, br)
+	}
+	st := status.New(grpcCodes[e.Kind], err.Error())
+	if detailed, detailErr := st.WithDetails(details...); detailErr == nil {
+		st = detailed
+	}
+	return st
+}
+
+// fromStatus recovers the Error a status was built from by GRPCStatus.
+// Statuses from elsewhere keep their code's Kind with an empty Code.
+func fromStatus(st *status.Status) *Error {
+	e := &Error{Kind: Internal, Message: st.Message()}
+	for kind, code := range grpcCodes {
+		if code == st.Code() {
+			e.Kind = kind
+		}
+	}
+	for _, d := range st.Details() {
+		switch d := d.(type) {
+		case *errdetails.ErrorInfo:
+			e.Code = d.Reason
+		case *errdetails.BadRequest:
+			for _, v := range d.FieldViolations {
+				e.Violations = append(e.Violations, FieldViolation{Field: v.Field, Description: v.Description})
+			}
+		}
+	}
+	return e
+}
+
+// CodeOf returns the Code of err, which may be an *Error chain or a status
+// built by GRPCStatus, or "" if it has none.
+func CodeOf(err error) string {
+	if e, ok := From(err); ok {
+		return e.Code
+	}
+	if st, ok := status.FromError(err); ok {
+		return fromStatus(st).Code
+	}
+	return ""
+}
+
+// UnaryServerInterceptor translates the errors returned by handlers with
+// GRPCStatus. Install it first so it also sees errors from later
+// interceptors.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		res, err := handler(ctx, req)
+		if err != nil {
+			return nil, GRPCStatus(err).Err()
+		}
+		return res, nil
+	}
+}
+
+// StreamServerInterceptor is the streaming counterpart of
+// UnaryServerInterceptor.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := handler(srv, ss); err != nil {
+			return GRPCStatus(err).Err()
+		}
+		return nil
+	}
+}
@@ -0,0 +1,63 @@
+package apperr
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"google.golang.org/grpc/status"
+
+	"github.com/rprajapati0067/quiz-game-backend/internal/logging"
+)
+
+var httpStatuses = map[Kind]int{
+	Internal:           http.StatusInternalServerError,
+	NotFound:           http.StatusNotFound,
+	AlreadyExists:      http.StatusConflict,
+	InvalidArgument:    http.StatusBadRequest,
+	Unauthenticated:    http.StatusUnauthorized,
+	PermissionDenied:   http.StatusForbidden,
+	FailedPrecondition: http.StatusConflict,
+	Aborted:            http.StatusConflict,
+	ResourceExhausted:  http.StatusTooManyRequests,
+}
+
+// Problem is the JSON body of an HTTP error response, following RFC 9457
+// problem details with the error's Code added.
+type Problem struct {
+	Title      string           `json:"title"`
+	Status     int              `json:"status"`
+	Detail     string           `json:"detail"`
+	Code       string           `json:"code"`
+	Violations []FieldViolation `json:"violations,omitempty"`
+}
+
+// WriteHTTP writes err as an application/problem+json response. Like
+// GRPCStatus, it hides and logs errors without a Kind. err may also be a
+// gRPC status built by GRPCStatus, as returned through the interceptors,
+// which is translated back into the error it came from.
+func WriteHTTP(w http.ResponseWriter, err error) {
+	p := Problem{Code: "INTERNAL", Detail: "internal error", Status: http.StatusInternalServerError}
+	e, ok := From(err)
+	detail, logged := err.Error(), false
+	if st, isStatus := status.FromError(err); !ok && isStatus {
+		// Statuses are logged when GRPCStatus builds them.
+		e, ok = fromStatus(st), true
+		detail, logged = st.Message(), true
+	}
+	if ok && e.Kind != Internal {
+		p.Code = e.Code
+		p.Detail = detail
+		p.Status = httpStatuses[e.Kind]
+		p.Violations = e.Violations
+	} else if !logged {
+		logging.Error(err.Error())
+	}
+	p.Title = http.StatusText(p.Status)
+
+	if p.Status == http.StatusUnauthorized {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(p.Status)
+	json.NewEncoder(w).Encode(p)
+}
@@ -0,0 +1,210 @@
+// Package config holds the server settings. Load starts from Default and
+// applies, in order, a JSON config file, environment variables and
+// command-line flags, so a flag beats the environment and the environment
+// beats the file. Every setting has one entry in the table in settings.go
+// naming its file key, variable and flag.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/rprajapati0067/quiz-game-backend/internal/logging"
+	"github.com/rprajapati0067/quiz-game-backend/internal/validate"
+)
+
+type Config struct {
+	// Mode is "local" (HTTP and gRPC listeners), "lambda" (API Gateway
+	// events) or "auto", which picks lambda inside AWS Lambda.
+	Mode     string
+	HTTPAddr string
+	GRPCAddr string
+	// MetricsAddr is where Prometheus metrics are served, apart from the
+	// API so they are never public by accident. Empty, the default, serves
+	// none.
+	MetricsAddr string
+	// ShutdownTimeout bounds how long in-flight requests may take to
+	// finish once the server is asked to stop.
+	ShutdownTimeout time.Duration
+	// DrainDelay is how long the server reports not ready before it stops
+	// accepting requests, so load balancers can take it out of rotation.
+	DrainDelay time.Duration
+	// LogLevel is "info", which logs everything, or "error".
+	LogLevel string
+
+	Storage  StorageConfig
+	JWT      JWTConfig
+	OTP      OTPConfig
+	Rewards  RewardsConfig
+	Features FeatureConfig
+
+	// AdminPhones lists the phones whose signups are granted the admin
+	// role.
+	AdminPhones []string
+}
+
+type StorageConfig struct {
+	// Backend is "memory", "dynamodb" or "sql".
+	Backend string
+
+	DynamoTablePrefix  string
+	DynamoEndpoint     string
+	DynamoCreateTables bool
+
+	// SQLDriver is "sqlite" or "postgres". SQLDSN is a SQLite file name,
+	// quiz.db when empty, or a PostgreSQL URL, which may carry a password.
+	SQLDriver      string
+	SQLDSN         string
+	SQLAutoMigrate bool
+}
+
+type JWTConfig struct {
+	// PrivateKeyFile (RS256 or EdDSA) takes precedence over Secret (HS256).
+	// One of them is required in lambda mode; local runs without either
+	// sign with an ephemeral secret.
+	Secret         string
+	PrivateKeyFile string
+	KeyID          string
+	// VerifyKeys are previous public keys still accepted, as kid=path.pem.
+	VerifyKeys []string
+	Issuer     string
+	AccessTTL  time.Duration
+}
+
+type OTPConfig struct {
+	Length      int
+	TTL         time.Duration
+	MaxAttempts int
+	// Window is how long attempts add up across codes reissued to a phone.
+	Window time.Duration
+	// ResendCooldown is the least time between two codes for a phone.
+	ResendCooldown time.Duration
+	// OutboxFile receives codes instead of the log when set.
+	OutboxFile string
+}
+
+type RewardsConfig struct {
+	LowVoucherThreshold int
+}
+
+type FeatureConfig struct {
+	ShuffleOptions bool
+}
+
+var (
+	modes      = []string{"auto", "local", "lambda"}
+	backends   = []string{"memory", "dynamodb", "sql"}
+	sqlDrivers = []string{"sqlite", "postgres"}
+)
+
+const (
+	minOTPLength = 4
+	maxOTPLength = 10
+)
+
+// Default returns the settings used when nothing is configured: memory
+// storage, HTTP on :8080 and gRPC on :8082.
+func Default() *Config {
+	return &Config{
+		Mode:     "auto",
+		HTTPAddr: ":8080",
+		GRPCAddr: ":8082",
+
+		ShutdownTimeout: 20 * time.Second,
+		LogLevel:        "info",
+		Storage: StorageConfig{
+			Backend:           "memory",
+			DynamoTablePrefix: "quiz_",
+			SQLDriver:         "sqlite",
+		},
+		JWT: JWTConfig{
+			KeyID:     "default",
+			Issuer:    "quiz-backend",
+			AccessTTL: 15 * time.Minute,
+		},
+		OTP: OTPConfig{
+			Length:         6,
+			TTL:            5 * time.Minute,
+			MaxAttempts:    5,
+			Window:         15 * time.Minute,
+			ResendCooldown: 30 * time.Second,
+		},
+		Rewards: RewardsConfig{LowVoucherThreshold: 10},
+	}
+}
+
+// Lambda reports whether the server runs on API Gateway events, resolving
+// the "auto" mode by checking for the Lambda runtime.
+func (c *Config) Lambda() bool {
+	if c.Mode == "auto" {
+		return os.Getenv("AWS_LAMBDA_FUNCTION_NAME") != ""
+	}
+	return c.Mode == "lambda"
+}
+
+// Validate reports every invalid setting at once, naming each by its file
+// key.
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, key, format string, args ...interface{}) {
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s: %s", key, fmt.Sprintf(format, args...)))
+		}
+	}
+	oneOf := func(key, value string, allowed []string) {
+		for _, a := range allowed {
+			if value == a {
+				return
+			}
+		}
+		check(false, key, "%q is not one of %s", value, strings.Join(allowed, ", "))
+	}
+
+	oneOf("mode", c.Mode, modes)
+	address := func(key, addr string) {
+		_, _, err := net.SplitHostPort(addr)
+		check(err == nil, key, "%q is not a host:port address", addr)
+	}
+	address("http_addr", c.HTTPAddr)
+	address("grpc_addr", c.GRPCAddr)
+	if c.MetricsAddr != "" {
+		address("metrics_addr", c.MetricsAddr)
+		check(!c.Lambda(), "metrics_addr", "must be empty in lambda mode, which has no listeners")
+	}
+	check(c.ShutdownTimeout > 0, "shutdown_timeout", "must be positive")
+	check(c.DrainDelay >= 0, "drain_delay", "must not be negative")
+	oneOf("log_level", c.LogLevel, logging.Levels)
+
+	oneOf("storage.backend", c.Storage.Backend, backends)
+	oneOf("storage.sql_driver", c.Storage.SQLDriver, sqlDrivers)
+	check(c.Storage.SQLDSN != "" || c.Storage.SQLDriver != "postgres", "storage.sql_dsn", "is required for postgres")
+
+	// Every Lambda instance would sign with its own ephemeral secret and
+	// reject the others' tokens.
+	check(!c.Lambda() || c.JWT.Secret != "" || c.JWT.PrivateKeyFile != "", "jwt.secret", "or jwt.private_key_file is required in lambda mode")
+	check(c.JWT.KeyID != "", "jwt.key_id", "is required")
+	check(c.JWT.Issuer != "", "jwt.issuer", "is required")
+	check(c.JWT.AccessTTL > 0, "jwt.access_ttl", "must be positive")
+	for _, entry := range c.JWT.VerifyKeys {
+		id, path, ok := strings.Cut(entry, "=")
+		check(ok && id != "" && path != "", "jwt.verify_keys", "%q is not kid=path", entry)
+	}
+
+	check(c.OTP.Length >= minOTPLength && c.OTP.Length <= maxOTPLength, "otp.length", "must be between %d and %d", minOTPLength, maxOTPLength)
+	check(c.OTP.TTL > 0, "otp.ttl", "must be positive")
+	check(c.OTP.MaxAttempts > 0, "otp.max_attempts", "must be positive")
+	check(c.OTP.Window >= c.OTP.TTL, "otp.window", "must be at least otp.ttl")
+	check(c.OTP.ResendCooldown >= 0, "otp.resend_cooldown", "must not be negative")
+	check(c.Rewards.LowVoucherThreshold >= 0, "rewards.low_voucher_threshold", "must not be negative")
+
+	for _, phone := range c.AdminPhones {
+		_, ok := validate.Phone(phone)
+		check(ok, "admin_phones", "%q is not a valid phone number", phone)
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,79 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateRequiresJWTKeyInLambdaMode(t *testing.T) {
+	t.Setenv("AWS_LAMBDA_FUNCTION_NAME", "")
+	for _, tc := range []struct {
+		name    string
+		mode    string
+		secret  string
+		keyFile string
+		lambda  string
+		wantErr bool
+	}{
+		{name: "lambda without key", mode: "lambda", wantErr: true},
+		{name: "auto inside lambda without key", mode: "auto", lambda: "quiz", wantErr: true},
+		{name: "lambda with secret", mode: "lambda", secret: "s3cret"},
+		{name: "lambda with key file", mode: "lambda", keyFile: "key.pem"},
+		{name: "local without key", mode: "local"},
+		{name: "auto outside lambda without key", mode: "auto"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("AWS_LAMBDA_FUNCTION_NAME", tc.lambda)
+			c := Default()
+			c.Mode = tc.mode
+			c.JWT.Secret = tc.secret
+			c.JWT.PrivateKeyFile = tc.keyFile
+			err := c.Validate()
+			if got := err != nil && strings.Contains(err.Error(), "jwt.secret"); got != tc.wantErr {
+				t.Fatalf("Validate() = %v, want a jwt.secret error: %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadLogLevel(t *testing.T) {
+	c, err := Load([]string{"-log-level", "error"})
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if c.LogLevel != "error" {
+		t.Fatalf("log level %q, want error", c.LogLevel)
+	}
+	if _, err := Load([]string{"-log-level", "debug"}); err == nil || !strings.Contains(err.Error(), "log_level") {
+		t.Fatalf("load with unknown level: got %v, want a log_level error", err)
+	}
+}
+
+func TestValidateMetricsAddr(t *testing.T) {
+	t.Setenv("AWS_LAMBDA_FUNCTION_NAME", "")
+	for _, tc := range []struct {
+		name    string
+		mode    string
+		addr    string
+		wantErr bool
+	}{
+		{name: "off by default", mode: "local"},
+		{name: "local listener", mode: "local", addr: ":9090"},
+		{name: "not an address", mode: "local", addr: "9090", wantErr: true},
+		{name: "lambda", mode: "lambda", addr: ":9090", wantErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c := Default()
+			c.Mode = tc.mode
+			c.JWT.Secret = "s3cret"
+			c.MetricsAddr = tc.addr
+			err := c.Validate()
+			if got := err != nil && strings.Contains(err.Error(), "metrics_addr"); got != tc.wantErr {
+				t.Fatalf("Validate() = %v, want a metrics_addr error: %v", err, tc.wantErr)
+			}
+		})
+	}
+	if Default().MetricsAddr != "" {
+		t.Fatal("metrics are served by default")
+	}
+}
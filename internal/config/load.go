@@ -0,0 +1,211 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Load builds the configuration from Default, the JSON file named by the
+// -config flag or CONFIG_FILE, the environment and the flags in args, then
+// validates it. Empty environment variables count as unset.
+// For -h it prints nothing and returns flag.ErrHelp; see PrintFlags.
+func Load(args []string) (*Config, error) {
+	flags := map[string]string{}
+	fs, path := newFlagSet(flags)
+	fs.SetOutput(io.Discard)
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if fs.NArg() > 0 {
+		return nil, fmt.Errorf("unexpected argument %q", fs.Arg(0))
+	}
+
+	c := Default()
+	if *path != "" {
+		if err := c.loadFile(*path); err != nil {
+			return nil, err
+		}
+	}
+	var errs []error
+	for _, s := range settings {
+		if v := os.Getenv(s.env); v != "" {
+			if err := s.field(c).Set(v); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %v", s.env, err))
+			}
+		}
+	}
+	for _, s := range settings {
+		if v, ok := flags[s.key]; ok {
+			if err := s.field(c).Set(v); err != nil {
+				errs = append(errs, fmt.Errorf("-%s: %v", s.flagName(), err))
+			}
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	if err := c.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration:\n%w", err)
+	}
+	return c, nil
+}
+
+// PrintFlags describes the flags accepted by Load.
+func PrintFlags(w io.Writer) {
+	fs, _ := newFlagSet(map[string]string{})
+	fs.SetOutput(w)
+	fs.PrintDefaults()
+}
+
+// newFlagSet registers a flag per setting that records its raw value in
+// flags, to be applied after the file and the environment.
+func newFlagSet(flags map[string]string) (*flag.FlagSet, *string) {
+	fs := flag.NewFlagSet("server", flag.ContinueOnError)
+	path := fs.String("config", os.Getenv("CONFIG_FILE"), "JSON config file (CONFIG_FILE)")
+	for _, s := range settings {
+		record := func(v string) error { flags[s.key] = v; return nil }
+		usage := fmt.Sprintf("%s (%s)", s.usage, s.env)
+		if _, ok := s.field(Default()).(*boolValue); ok {
+			fs.BoolFunc(s.flagName(), usage, record)
+		} else {
+			fs.Func(s.flagName(), usage, record)
+		}
+	}
+	return fs, path
+}
+
+// loadFile applies a JSON file whose objects mirror the setting keys, e.g.
+// {"storage": {"backend": "sql"}}. Lists may be arrays or comma separated
+// strings. Unknown keys are an error so typos do not go unnoticed.
+func (c *Config) loadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var doc map[string]interface{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&doc); err != nil {
+		return fmt.Errorf("config file %s: %v", path, err)
+	}
+
+	values := map[string]string{}
+	if err := flatten("", doc, values); err != nil {
+		return fmt.Errorf("config file %s: %v", path, err)
+	}
+	var errs []error
+	for _, s := range settings {
+		if v, ok := values[s.key]; ok {
+			if err := s.field(c).Set(v); err != nil {
+				errs = append(errs, fmt.Errorf("config file %s: %s: %v", path, s.key, err))
+			}
+			delete(values, s.key)
+		}
+	}
+	for _, key := range sortedKeys(values) {
+		errs = append(errs, fmt.Errorf("config file %s: unknown setting %s", path, key))
+	}
+	return errors.Join(errs...)
+}
+
+func flatten(prefix string, doc map[string]interface{}, out map[string]string) error {
+	for k, v := range doc {
+		key := prefix + k
+		switch v := v.(type) {
+		case map[string]interface{}:
+			if err := flatten(key+".", v, out); err != nil {
+				return err
+			}
+		case []interface{}:
+			items := make([]string, len(v))
+			for i, item := range v {
+				s, ok := item.(string)
+				if !ok {
+					return fmt.Errorf("%s: list items must be strings", key)
+				}
+				items[i] = s
+			}
+			out[key] = strings.Join(items, ",")
+		case string:
+			out[key] = v
+		case json.Number:
+			out[key] = v.String()
+		case bool:
+			out[key] = strconv.FormatBool(v)
+		case nil:
+		default:
+			return fmt.Errorf("%s: unsupported value", key)
+		}
+	}
+	return nil
+}
+
+// Print writes the configuration as an indented JSON config file with
+// secrets redacted.
+func (c *Config) Print(w io.Writer) error {
+	data, err := json.MarshalIndent(c.redacted(), "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "%s\n", data)
+	return err
+}
+
+// String is the configuration as one line of JSON with secrets redacted,
+// so it is safe to log.
+func (c *Config) String() string {
+	data, err := json.Marshal(c.redacted())
+	if err != nil {
+		return err.Error()
+	}
+	return string(data)
+}
+
+// redacted nests the settings by key, keeping booleans, numbers and lists
+// typed so the output reads back as a config file.
+func (c *Config) redacted() map[string]interface{} {
+	doc := map[string]interface{}{}
+	for _, s := range settings {
+		var out interface{}
+		switch v := s.field(c).(type) {
+		case *boolValue:
+			out = bool(*v)
+		case *intValue:
+			out = int(*v)
+		case *listValue:
+			out = append([]string{}, *v...)
+		default:
+			out = s.printable(v.String())
+		}
+
+		parent := doc
+		parts := strings.Split(s.key, ".")
+		for _, p := range parts[:len(parts)-1] {
+			child, ok := parent[p].(map[string]interface{})
+			if !ok {
+				child = map[string]interface{}{}
+				parent[p] = child
+			}
+			parent = child
+		}
+		parent[parts[len(parts)-1]] = out
+	}
+	return doc
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
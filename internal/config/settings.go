@@ -0,0 +1,155 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// setting ties one Config field to its file key, environment variable and
+// flag. Flags are named after the key with "." and "_" replaced by "-".
+type setting struct {
+	key   string
+	env   string
+	usage string
+	// redact, when set, hides secrets before the value is printed.
+	redact func(v string) string
+	field  func(c *Config) value
+}
+
+// value is a Config field that can be set from and printed as a string.
+type value interface {
+	Set(s string) error
+	String() string
+}
+
+var settings = []setting{
+	{key: "mode", env: "SERVER_MODE", usage: "auto, local or lambda", field: func(c *Config) value { return (*stringValue)(&c.Mode) }},
+	{key: "http_addr", env: "HTTP_ADDR", usage: "REST API listen address", field: func(c *Config) value { return (*stringValue)(&c.HTTPAddr) }},
+	{key: "grpc_addr", env: "GRPC_ADDR", usage: "gRPC listen address", field: func(c *Config) value { return (*stringValue)(&c.GRPCAddr) }},
+	{key: "metrics_addr", env: "METRICS_ADDR", usage: "Prometheus metrics listen address, empty for none", field: func(c *Config) value { return (*stringValue)(&c.MetricsAddr) }},
+	{key: "shutdown_timeout", env: "SHUTDOWN_TIMEOUT", usage: "time in-flight requests get to finish on shutdown", field: func(c *Config) value { return (*durationValue)(&c.ShutdownTimeout) }},
+	{key: "drain_delay", env: "DRAIN_DELAY", usage: "time reported not ready before shutdown starts", field: func(c *Config) value { return (*durationValue)(&c.DrainDelay) }},
+	{key: "log_level", env: "LOG_LEVEL", usage: "info or error", field: func(c *Config) value { return (*stringValue)(&c.LogLevel) }},
+
+	{key: "storage.backend", env: "STORAGE_BACKEND", usage: "memory, dynamodb or sql", field: func(c *Config) value { return (*stringValue)(&c.Storage.Backend) }},
+	{key: "storage.dynamodb_table_prefix", env: "DYNAMODB_TABLE_PREFIX", usage: "prefix of every DynamoDB table name", field: func(c *Config) value { return (*stringValue)(&c.Storage.DynamoTablePrefix) }},
+	{key: "storage.dynamodb_endpoint", env: "DYNAMODB_ENDPOINT", usage: "custom DynamoDB endpoint, e.g. DynamoDB Local", field: func(c *Config) value { return (*stringValue)(&c.Storage.DynamoEndpoint) }},
+	{key: "storage.dynamodb_create_tables", env: "DYNAMODB_CREATE_TABLES", usage: "create missing DynamoDB tables on start", field: func(c *Config) value { return (*boolValue)(&c.Storage.DynamoCreateTables) }},
+	{key: "storage.sql_driver", env: "SQL_DRIVER", usage: "sqlite or postgres", field: func(c *Config) value { return (*stringValue)(&c.Storage.SQLDriver) }},
+	{key: "storage.sql_dsn", env: "SQL_DSN", usage: "SQLite file or PostgreSQL URL", redact: redactDSN, field: func(c *Config) value { return (*stringValue)(&c.Storage.SQLDSN) }},
+	{key: "storage.sql_auto_migrate", env: "SQL_AUTO_MIGRATE", usage: "apply pending migrations on start", field: func(c *Config) value { return (*boolValue)(&c.Storage.SQLAutoMigrate) }},
+
+	{key: "jwt.secret", env: "JWT_SECRET", usage: "HS256 signing secret", redact: redactSecret, field: func(c *Config) value { return (*stringValue)(&c.JWT.Secret) }},
+	{key: "jwt.private_key_file", env: "JWT_PRIVATE_KEY_FILE", usage: "PEM RSA or Ed25519 signing key", field: func(c *Config) value { return (*stringValue)(&c.JWT.PrivateKeyFile) }},
+	{key: "jwt.key_id", env: "JWT_KEY_ID", usage: "kid header of issued tokens", field: func(c *Config) value { return (*stringValue)(&c.JWT.KeyID) }},
+	{key: "jwt.verify_keys", env: "JWT_VERIFY_KEYS", usage: "previous public keys as kid=path.pem,...", field: func(c *Config) value { return (*listValue)(&c.JWT.VerifyKeys) }},
+	{key: "jwt.issuer", env: "JWT_ISSUER", usage: "iss claim of issued tokens", field: func(c *Config) value { return (*stringValue)(&c.JWT.Issuer) }},
+	{key: "jwt.access_ttl", env: "JWT_ACCESS_TTL", usage: "access token lifetime", field: func(c *Config) value { return (*durationValue)(&c.JWT.AccessTTL) }},
+
+	{key: "otp.length", env: "OTP_LENGTH", usage: "digits per code", field: func(c *Config) value { return (*intValue)(&c.OTP.Length) }},
+	{key: "otp.ttl", env: "OTP_TTL", usage: "code lifetime", field: func(c *Config) value { return (*durationValue)(&c.OTP.TTL) }},
+	{key: "otp.max_attempts", env: "OTP_MAX_ATTEMPTS", usage: "verification attempts per phone within otp.window", field: func(c *Config) value { return (*intValue)(&c.OTP.MaxAttempts) }},
+	{key: "otp.window", env: "OTP_WINDOW", usage: "time attempts add up across reissued codes", field: func(c *Config) value { return (*durationValue)(&c.OTP.Window) }},
+	{key: "otp.resend_cooldown", env: "OTP_RESEND_COOLDOWN", usage: "least time between two codes for a phone", field: func(c *Config) value { return (*durationValue)(&c.OTP.ResendCooldown) }},
+	{key: "otp.outbox_file", env: "OTP_OUTBOX_FILE", usage: "append codes to this file instead of logging them", field: func(c *Config) value { return (*stringValue)(&c.OTP.OutboxFile) }},
+
+	{key: "rewards.low_voucher_threshold", env: "LOW_VOUCHER_THRESHOLD", usage: "voucher codes left before an alert is logged", field: func(c *Config) value { return (*intValue)(&c.Rewards.LowVoucherThreshold) }},
+	{key: "features.shuffle_options", env: "SHUFFLE_OPTIONS", usage: "show question options in a per-player order", field: func(c *Config) value { return (*boolValue)(&c.Features.ShuffleOptions) }},
+	{key: "admin_phones", env: "ADMIN_PHONES", usage: "phones whose signups become admins", field: func(c *Config) value { return (*listValue)(&c.AdminPhones) }},
+}
+
+func (s setting) flagName() string {
+	return strings.NewReplacer(".", "-", "_", "-").Replace(s.key)
+}
+
+// printable returns v as it may appear in output and logs.
+func (s setting) printable(v string) string {
+	if s.redact == nil || v == "" {
+		return v
+	}
+	return s.redact(v)
+}
+
+func redactSecret(string) string {
+	return redacted
+}
+
+// redactDSN hides only the password of a database URL or key=value
+// connection string, so the output still says where the server connects.
+func redactDSN(dsn string) string {
+	if u, err := url.Parse(dsn); err == nil && u.Scheme != "" {
+		return u.Redacted()
+	}
+	fields := strings.Fields(dsn)
+	for i, f := range fields {
+		if k, _, ok := strings.Cut(f, "="); ok && strings.EqualFold(k, "password") {
+			fields[i] = k + "=" + redacted
+		}
+	}
+	return strings.Join(fields, " ")
+}
+
+const redacted = "REDACTED"
+
+type stringValue string
+
+func (v *stringValue) Set(s string) error { *v = stringValue(s); return nil }
+func (v *stringValue) String() string     { return string(*v) }
+
+type boolValue bool
+
+func (v *boolValue) Set(s string) error {
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		return fmt.Errorf("%q is not a boolean", s)
+	}
+	*v = boolValue(b)
+	return nil
+}
+
+func (v *boolValue) String() string { return strconv.FormatBool(bool(*v)) }
+
+type intValue int
+
+func (v *intValue) Set(s string) error {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return fmt.Errorf("%q is not an integer", s)
+	}
+	*v = intValue(n)
+	return nil
+}
+
+func (v *intValue) String() string { return strconv.Itoa(int(*v)) }
+
+type durationValue time.Duration
+
+func (v *durationValue) Set(s string) error {
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return fmt.Errorf("%q is not a duration such as 90s or 15m", s)
+	}
+	*v = durationValue(d)
+	return nil
+}
+
+func (v *durationValue) String() string { return time.Duration(*v).String() }
+
+// listValue is a comma separated list; blank entries are dropped.
+type listValue []string
+
+func (v *listValue) Set(s string) error {
+	var list []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	*v = list
+	return nil
+}
+
+func (v *listValue) String() string { return strings.Join(*v, ",") }
@@ -0,0 +1,202 @@
+// Package gateway serves gRPC services as a REST/JSON API routed by the
+// google.api.http annotations in proto/*.proto. A Gateway is a
+// grpc.ServiceRegistrar, so services are registered with the same generated
+// Register*Server functions as on the gRPC server, and every call goes
+// through the same unary interceptors. REST and gRPC therefore share one
+// definition, one set of handlers and one access policy.
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	"github.com/rprajapati0067/quiz-game-backend/internal/apperr"
+)
+
+// forwardedHeaders are passed to handlers and interceptors as incoming
+// gRPC metadata.
+var forwardedHeaders = []string{"Authorization", "User-Agent"}
+
+// Responses use the proto field names, matching request bodies, and
+// include fields left at their zero value so clients see every field.
+var marshalOptions = protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}
+
+type Gateway struct {
+	mux         *http.ServeMux
+	interceptor grpc.UnaryServerInterceptor
+}
+
+// New returns a Gateway that runs calls through interceptors in order, like
+// grpc.ChainUnaryInterceptor.
+func New(interceptors ...grpc.UnaryServerInterceptor) *Gateway {
+	return &Gateway{mux: http.NewServeMux(), interceptor: chain(interceptors)}
+}
+
+func (g *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	g.mux.ServeHTTP(w, r)
+}
+
+// RegisterService implements grpc.ServiceRegistrar. Each unary method is
+// served at the routes of its google.api.http rule and its
+// additional_bindings; methods without a rule are served at POST
+// /<service>/<method>, the gRPC path, with the request as the body.
+// Streaming methods are not served. Like grpc.Server, it panics on a
+// service it cannot serve.
+func (g *Gateway) RegisterService(sd *grpc.ServiceDesc, impl interface{}) {
+	d, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(sd.ServiceName))
+	if err != nil {
+		panic(fmt.Sprintf("gateway: service %s: %v", sd.ServiceName, err))
+	}
+	svc, ok := d.(protoreflect.ServiceDescriptor)
+	if !ok {
+		panic(fmt.Sprintf("gateway: %s is not a service", sd.ServiceName))
+	}
+
+	for i := range sd.Methods {
+		desc := &sd.Methods[i]
+		md := svc.Methods().ByName(protoreflect.Name(desc.MethodName))
+		if md == nil {
+			panic(fmt.Sprintf("gateway: %s has no method %s", sd.ServiceName, desc.MethodName))
+		}
+		for _, b := range bindings(sd.ServiceName, md) {
+			if err := b.check(md.Input()); err != nil {
+				panic(fmt.Sprintf("gateway: %s/%s: %v", sd.ServiceName, desc.MethodName, err))
+			}
+			g.mux.Handle(b.method+" "+b.path, g.handler(b, desc, impl))
+		}
+	}
+}
+
+func (g *Gateway) handler(b binding, desc *grpc.MethodDesc, impl interface{}) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		md := metadata.MD{}
+		for _, h := range forwardedHeaders {
+			if values := r.Header.Values(h); len(values) > 0 {
+				md.Set(strings.ToLower(h), values...)
+			}
+		}
+		ctx := metadata.NewIncomingContext(r.Context(), md)
+
+		decode := func(req interface{}) error {
+			return b.decode(w, r, req.(proto.Message))
+		}
+		res, err := desc.Handler(impl, ctx, decode, g.interceptor)
+		if err != nil {
+			apperr.WriteHTTP(w, err)
+			return
+		}
+		body, err := marshalOptions.Marshal(res.(proto.Message))
+		if err != nil {
+			apperr.WriteHTTP(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(body)
+	})
+}
+
+// chain combines interceptors into one, or nil if there are none so the
+// generated handlers call the service directly.
+func chain(interceptors []grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	if len(interceptors) == 0 {
+		return nil
+	}
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		next := handler
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, inner := interceptors[i], next
+			next = func(ctx context.Context, req interface{}) (interface{}, error) {
+				return interceptor(ctx, req, info, inner)
+			}
+		}
+		return next(ctx, req)
+	}
+}
+
+// binding is one HTTP route of a method.
+type binding struct {
+	method string
+	path   string
+	// body is "*" when the whole request is the body, the name of the one
+	// field read from the body, or empty when there is no body.
+	body string
+	// vars are the request fields bound by {field} path segments.
+	vars         []string
+	responseBody string
+}
+
+func bindings(service string, md protoreflect.MethodDescriptor) []binding {
+	rule, _ := proto.GetExtension(md.Options(), annotations.E_Http).(*annotations.HttpRule)
+	if rule == nil || rule.GetPattern() == nil {
+		return []binding{{method: http.MethodPost, path: "/" + service + "/" + string(md.Name()), body: "*"}}
+	}
+	bs := []binding{ruleBinding(rule)}
+	for _, extra := range rule.GetAdditionalBindings() {
+		bs = append(bs, ruleBinding(extra))
+	}
+	return bs
+}
+
+func ruleBinding(rule *annotations.HttpRule) binding {
+	b := binding{body: rule.GetBody()}
+	switch p := rule.GetPattern().(type) {
+	case *annotations.HttpRule_Get:
+		b.method, b.path = http.MethodGet, p.Get
+	case *annotations.HttpRule_Put:
+		b.method, b.path = http.MethodPut, p.Put
+	case *annotations.HttpRule_Post:
+		b.method, b.path = http.MethodPost, p.Post
+	case *annotations.HttpRule_Delete:
+		b.method, b.path = http.MethodDelete, p.Delete
+	case *annotations.HttpRule_Patch:
+		b.method, b.path = http.MethodPatch, p.Patch
+	case *annotations.HttpRule_Custom:
+		b.method, b.path = p.Custom.GetKind(), p.Custom.GetPath()
+	}
+	b.responseBody = rule.GetResponseBody()
+	for _, seg := range strings.Split(b.path, "/") {
+		if strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}") {
+			b.vars = append(b.vars, seg[1:len(seg)-1])
+		}
+	}
+	return b
+}
+
+// check reports rules this gateway cannot serve: response_body, path
+// templates beyond whole-segment {field} variables, and body or path fields
+// the request does not have.
+func (b binding) check(in protoreflect.MessageDescriptor) error {
+	if b.responseBody != "" {
+		return fmt.Errorf("response_body is not supported")
+	}
+	for _, seg := range strings.Split(b.path, "/") {
+		if strings.ContainsAny(seg, "{}") && !(strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}")) {
+			return fmt.Errorf("path segment %q must be a whole {field} variable", seg)
+		}
+	}
+	for _, v := range b.vars {
+		fd := in.Fields().ByName(protoreflect.Name(v))
+		if fd == nil || fd.IsList() || fd.IsMap() || fd.Message() != nil {
+			return fmt.Errorf("path variable {%s} must name a scalar field", v)
+		}
+	}
+	switch b.body {
+	case "", "*":
+	default:
+		fd := in.Fields().ByName(protoreflect.Name(b.body))
+		if fd == nil || fd.IsList() || fd.IsMap() || (fd.Kind() != protoreflect.BytesKind && fd.Message() == nil) {
+			return fmt.Errorf("body %q must name a message or bytes field", b.body)
+		}
+	}
+	return nil
+}
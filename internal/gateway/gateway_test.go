@@ -0,0 +1,197 @@
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	rewardrpc "github.com/rprajapati0067/quiz-game-backend/rpc/reward"
+
+	"github.com/rprajapati0067/quiz-game-backend/internal/apperr"
+)
+
+// fakeRewards answers ClaimAward with err, or with the award it was asked
+// for, and UploadVouchers by counting the CSV lines.
+type fakeRewards struct {
+	rewardrpc.UnimplementedRewardServiceServer
+	err           error
+	authorization []string
+}
+
+func (f *fakeRewards) ClaimAward(ctx context.Context, req *rewardrpc.ClaimAwardRequest) (*rewardrpc.ClaimAwardResponse, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	f.authorization = md.Get("authorization")
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &rewardrpc.ClaimAwardResponse{Success: true, Claim: &rewardrpc.Claim{AwardId: req.AwardId}}, nil
+}
+
+func (f *fakeRewards) UploadVouchers(ctx context.Context, req *rewardrpc.UploadVouchersRequest) (*rewardrpc.UploadVouchersResponse, error) {
+	lines := bytes.Count(req.Csv, []byte("\n"))
+	return &rewardrpc.UploadVouchersResponse{Award: &rewardrpc.Award{Id: req.AwardId}, Added: int32(lines)}, nil
+}
+
+func serve(t *testing.T, f *fakeRewards, interceptors ...grpc.UnaryServerInterceptor) *Gateway {
+	t.Helper()
+	g := New(interceptors...)
+	rewardrpc.RegisterRewardServiceServer(g, f)
+	return g
+}
+
+func TestGatewayEncodesResponse(t *testing.T) {
+	f := &fakeRewards{}
+	g := serve(t, f)
+	r := httptest.NewRequest(http.MethodPost, "/api/v1/rewards/claim", strings.NewReader(`{"award_id": "a1"}`))
+	r.Header.Set("Authorization", "Bearer t0k")
+	w := httptest.NewRecorder()
+	g.ServeHTTP(w, r)
+
+	if w.Code != http.StatusOK || w.Header().Get("Content-Type") != "application/json" {
+		t.Fatalf("got %d %s: %s", w.Code, w.Header().Get("Content-Type"), w.Body)
+	}
+	var res map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	claim, _ := res["claim"].(map[string]interface{})
+	if res["success"] != true || res["remaining_points"] != "0" || claim["award_id"] != "a1" {
+		t.Fatalf("got %s, want proto field names with zero values", w.Body)
+	}
+	if !reflect.DeepEqual(f.authorization, []string{"Bearer t0k"}) {
+		t.Fatalf("authorization metadata %q, want the request header", f.authorization)
+	}
+
+	w = httptest.NewRecorder()
+	g.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/rewards/claim", nil))
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("GET on a POST route: got %d, want 405", w.Code)
+	}
+}
+
+func TestGatewayUploadsCSV(t *testing.T) {
+	g := serve(t, &fakeRewards{})
+	r := httptest.NewRequest(http.MethodPost, "/api/v1/admin/awards/vouchers?award_id=a1", strings.NewReader("code\nA1\nB2\n"))
+	r.Header.Set("Content-Type", "text/csv")
+	w := httptest.NewRecorder()
+	g.ServeHTTP(w, r)
+
+	var res rewardrpc.UploadVouchersResponse
+	if w.Code != http.StatusOK {
+		t.Fatalf("got %d: %s", w.Code, w.Body)
+	}
+	if err := protojson.Unmarshal(w.Body.Bytes(), &res); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if res.Added != 3 || res.Award.GetId() != "a1" {
+		t.Fatalf("got %s, want 3 lines added to a1", w.Body)
+	}
+}
+
+func TestGatewayMapsErrors(t *testing.T) {
+	errNoAward := apperr.New(apperr.NotFound, "AWARD_NOT_FOUND", "award not found")
+	errInvalid := apperr.New(apperr.InvalidArgument, "INVALID_ARGUMENT", "invalid request").
+		WithViolations(apperr.FieldViolation{Field: "award_id", Description: "is required"})
+	errDenied := apperr.New(apperr.Unauthenticated, "UNAUTHENTICATED", "sign in first")
+	reject := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return nil, errDenied
+	}
+
+	for _, tc := range []struct {
+		name         string
+		body         string
+		err          error
+		interceptors []grpc.UnaryServerInterceptor
+		wantStatus   int
+		wantCode     string
+		wantDetail   string
+		wantFields   []string
+	}{
+		{
+			name:       "service error",
+			err:        errNoAward,
+			wantStatus: http.StatusNotFound,
+			wantCode:   "AWARD_NOT_FOUND",
+			wantDetail: "award not found",
+		},
+		{
+			name:         "service error through the status interceptor",
+			err:          errInvalid,
+			interceptors: []grpc.UnaryServerInterceptor{apperr.UnaryServerInterceptor()},
+			wantStatus:   http.StatusBadRequest,
+			wantCode:     "INVALID_ARGUMENT",
+			wantDetail:   "invalid request: award_id is required",
+			wantFields:   []string{"award_id"},
+		},
+		{
+			name:       "error without a kind",
+			err:        errors.New("dynamo: connection refused"),
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   "INTERNAL",
+			wantDetail: "internal error",
+		},
+		{
+			name:         "interceptor error",
+			interceptors: []grpc.UnaryServerInterceptor{apperr.UnaryServerInterceptor(), reject},
+			wantStatus:   http.StatusUnauthorized,
+			wantCode:     "UNAUTHENTICATED",
+			wantDetail:   "sign in first",
+		},
+		{
+			name:       "undecodable body",
+			body:       `{"award_id": 7}`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "INVALID_BODY",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			body := tc.body
+			if body == "" {
+				body = `{"award_id": "a1"}`
+			}
+			g := serve(t, &fakeRewards{err: tc.err}, tc.interceptors...)
+			w := httptest.NewRecorder()
+			g.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/rewards/claim", strings.NewReader(body)))
+
+			var p apperr.Problem
+			if err := json.Unmarshal(w.Body.Bytes(), &p); err != nil {
+				t.Fatalf("decode problem: %v", err)
+			}
+			if w.Code != tc.wantStatus || p.Status != tc.wantStatus || p.Code != tc.wantCode {
+				t.Fatalf("got %d %s, want %d %s", w.Code, w.Body, tc.wantStatus, tc.wantCode)
+			}
+			if tc.wantDetail != "" && p.Detail != tc.wantDetail {
+				t.Fatalf("detail %q, want %q", p.Detail, tc.wantDetail)
+			}
+			var fields []string
+			for _, v := range p.Violations {
+				fields = append(fields, v.Field)
+			}
+			if !reflect.DeepEqual(fields, tc.wantFields) {
+				t.Fatalf("violations %v, want fields %v", p.Violations, tc.wantFields)
+			}
+			if got := w.Header().Get("Content-Type"); got != "application/problem+json" {
+				t.Fatalf("content type %q, want application/problem+json", got)
+			}
+		})
+	}
+}
+
+func TestRegisterServicePanicsOnUnknownService(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("RegisterService did not panic")
+		}
+	}()
+	New().RegisterService(&grpc.ServiceDesc{ServiceName: "quiz.nope.NoService"}, nil)
+}
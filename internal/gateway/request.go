@@ -0,0 +1,207 @@
+package gateway
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/rprajapati0067/quiz-game-backend/internal/apperr"
+	"github.com/rprajapati0067/quiz-game-backend/internal/validate"
+)
+
+// maxBodyBytes bounds request bodies, which are read into memory whole.
+const maxBodyBytes = 10 << 20
+
+var errInvalidBody = apperr.New(apperr.InvalidArgument, "INVALID_BODY", "invalid request body")
+
+// decode fills req from r: the body as the binding says, then the path
+// variables, then, unless the whole request came from the body, the query
+// string. A bytes body field takes the raw body unless it is sent as JSON.
+func (b binding) decode(w http.ResponseWriter, r *http.Request, req proto.Message) error {
+	m := req.ProtoReflect()
+	if b.body != "" {
+		raw, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+		if err != nil {
+			return fmt.Errorf("%w: %v", errInvalidBody, err)
+		}
+		if err := b.decodeBody(r, raw, m); err != nil {
+			return fmt.Errorf("%w: %v", errInvalidBody, err)
+		}
+	}
+
+	var v validate.Violations
+	for _, name := range b.vars {
+		setField(&v, m, name, []string{r.PathValue(name)})
+	}
+	if b.body != "*" {
+		for key, values := range r.URL.Query() {
+			setField(&v, m, key, values)
+		}
+	}
+	return v.Err()
+}
+
+func (b binding) decodeBody(r *http.Request, raw []byte, m protoreflect.Message) error {
+	if b.body == "*" {
+		if len(raw) == 0 {
+			return nil
+		}
+		return protojson.Unmarshal(raw, m.Interface())
+	}
+
+	fd := m.Descriptor().Fields().ByName(protoreflect.Name(b.body))
+	if fd.Kind() == protoreflect.BytesKind {
+		if !isJSON(r) {
+			m.Set(fd, protoreflect.ValueOfBytes(raw))
+			return nil
+		}
+		var data []byte
+		if err := json.Unmarshal(raw, &data); err != nil {
+			return err
+		}
+		m.Set(fd, protoreflect.ValueOfBytes(data))
+		return nil
+	}
+	return protojson.Unmarshal(raw, m.Mutable(fd).Message().Interface())
+}
+
+func isJSON(r *http.Request) bool {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	return mediaType == "application/json"
+}
+
+// setField sets the field at path, a dotted list of proto or JSON field
+// names, to values, recording a violation if that is not possible. Like
+// grpc-gateway, it ignores paths naming no field, such as cache-busting
+// query parameters.
+func setField(v *validate.Violations, m protoreflect.Message, path string, values []string) {
+	names := strings.Split(path, ".")
+	for i, name := range names {
+		fd := fieldByName(m.Descriptor(), name)
+		if fd == nil {
+			return
+		}
+		if i < len(names)-1 {
+			if fd.Message() == nil || fd.IsList() || fd.IsMap() {
+				v.Add(path, "is not a field of the request")
+				return
+			}
+			m = m.Mutable(fd).Message()
+			continue
+		}
+
+		if fd.IsMap() || fd.Message() != nil {
+			v.Add(path, "cannot be set from the URL")
+			return
+		}
+		if !fd.IsList() {
+			if len(values) > 1 {
+				v.Add(path, "must be given once")
+				return
+			}
+			value, err := parseScalar(fd, values[0])
+			if err != nil {
+				v.Add(path, "%v", err)
+				return
+			}
+			m.Set(fd, value)
+			return
+		}
+		list := m.Mutable(fd).List()
+		for _, s := range values {
+			value, err := parseScalar(fd, s)
+			if err != nil {
+				v.Add(path, "%v", err)
+				return
+			}
+			list.Append(value)
+		}
+	}
+}
+
+func fieldByName(md protoreflect.MessageDescriptor, name string) protoreflect.FieldDescriptor {
+	if fd := md.Fields().ByName(protoreflect.Name(name)); fd != nil {
+		return fd
+	}
+	return md.Fields().ByJSONName(name)
+}
+
+// parseScalar parses s as a value of fd's kind, using the same formats as
+// the proto JSON mapping.
+func parseScalar(fd protoreflect.FieldDescriptor, s string) (protoreflect.Value, error) {
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(s), nil
+	case protoreflect.BytesKind:
+		b, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			b, err = base64.URLEncoding.DecodeString(s)
+		}
+		if err != nil {
+			return protoreflect.Value{}, errors.New("must be base64")
+		}
+		return protoreflect.ValueOfBytes(b), nil
+	case protoreflect.BoolKind:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return protoreflect.Value{}, errors.New("must be true or false")
+		}
+		return protoreflect.ValueOfBool(b), nil
+	case protoreflect.EnumKind:
+		if ev := fd.Enum().Values().ByName(protoreflect.Name(s)); ev != nil {
+			return protoreflect.ValueOfEnum(ev.Number()), nil
+		}
+		n, err := strconv.ParseInt(s, 10, 32)
+		if err != nil || fd.Enum().Values().ByNumber(protoreflect.EnumNumber(n)) == nil {
+			return protoreflect.Value{}, fmt.Errorf("must be one of the %s values", fd.Enum().Name())
+		}
+		return protoreflect.ValueOfEnum(protoreflect.EnumNumber(n)), nil
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		n, err := strconv.ParseInt(s, 10, 32)
+		if err != nil {
+			return protoreflect.Value{}, errors.New("must be a 32-bit integer")
+		}
+		return protoreflect.ValueOfInt32(int32(n)), nil
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return protoreflect.Value{}, errors.New("must be a 64-bit integer")
+		}
+		return protoreflect.ValueOfInt64(n), nil
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		n, err := strconv.ParseUint(s, 10, 32)
+		if err != nil {
+			return protoreflect.Value{}, errors.New("must be an unsigned 32-bit integer")
+		}
+		return protoreflect.ValueOfUint32(uint32(n)), nil
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		n, err := strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			return protoreflect.Value{}, errors.New("must be an unsigned 64-bit integer")
+		}
+		return protoreflect.ValueOfUint64(n), nil
+	case protoreflect.FloatKind:
+		f, err := strconv.ParseFloat(s, 32)
+		if err != nil {
+			return protoreflect.Value{}, errors.New("must be a number")
+		}
+		return protoreflect.ValueOfFloat32(float32(f)), nil
+	case protoreflect.DoubleKind:
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return protoreflect.Value{}, errors.New("must be a number")
+		}
+		return protoreflect.ValueOfFloat64(f), nil
+	}
+	return protoreflect.Value{}, fmt.Errorf("has unsupported type %s", fd.Kind())
+}
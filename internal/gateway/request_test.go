@@ -0,0 +1,257 @@
+package gateway
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	questionrpc "github.com/rprajapati0067/quiz-game-backend/rpc/question"
+	rewardrpc "github.com/rprajapati0067/quiz-game-backend/rpc/reward"
+
+	"github.com/rprajapati0067/quiz-game-backend/internal/apperr"
+)
+
+func get(path string) *annotations.HttpRule {
+	return &annotations.HttpRule{Pattern: &annotations.HttpRule_Get{Get: path}}
+}
+
+func post(path, body string) *annotations.HttpRule {
+	return &annotations.HttpRule{Pattern: &annotations.HttpRule_Post{Post: path}, Body: body}
+}
+
+func TestDecode(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		rule        *annotations.HttpRule
+		target      string
+		contentType string
+		body        string
+		want        proto.Message
+		// wantFields lists the violated fields when decoding fails with
+		// INVALID_ARGUMENT; wantCode is the code of any other failure.
+		wantFields []string
+		wantCode   string
+	}{
+		{
+			name:   "path variable",
+			rule:   get("/awards/{award_id}"),
+			target: "/awards/a1",
+			want:   &rewardrpc.RetireAwardRequest{AwardId: "a1"},
+		},
+		{
+			name:   "path variables of several types",
+			rule:   get("/awards/{award_id}/restock/{quantity}"),
+			target: "/awards/a1/restock/5",
+			want:   &rewardrpc.RestockAwardRequest{AwardId: "a1", Quantity: 5},
+		},
+		{
+			name:       "unparsable path variable",
+			rule:       get("/awards/{award_id}/restock/{quantity}"),
+			target:     "/awards/a1/restock/five",
+			want:       &rewardrpc.RestockAwardRequest{},
+			wantFields: []string{"quantity"},
+		},
+		{
+			name:   "path variable and query",
+			rule:   get("/claims/{claim_id}"),
+			target: "/claims/c1?note=shipped",
+			want:   &rewardrpc.ClaimTransitionRequest{ClaimId: "c1", Note: "shipped"},
+		},
+		{
+			name:   "query",
+			rule:   get("/questions"),
+			target: "/questions?slot=2",
+			want:   &questionrpc.ListQuestionsRequest{Slot: 2},
+		},
+		{
+			name:   "query by JSON name",
+			rule:   get("/answers"),
+			target: "/answers?questionId=q1&selectedIndex=3",
+			want:   &questionrpc.SubmitAnswerRequest{QuestionId: "q1", SelectedIndex: 3},
+		},
+		{
+			name:   "query into a nested message",
+			rule:   get("/awards"),
+			target: "/awards?award_id=a1&spec.point_cost=40&spec.digital=true",
+			want: &rewardrpc.UpdateAwardRequest{
+				AwardId: "a1",
+				Spec:    &rewardrpc.AwardSpec{PointCost: 40, Digital: true},
+			},
+		},
+		{
+			name:   "repeated query",
+			rule:   get("/questions/new"),
+			target: "/questions/new?options=a&options=b",
+			want:   &questionrpc.CreateQuestionRequest{Options: []string{"a", "b"}},
+		},
+		{
+			name:   "unknown query keys are ignored",
+			rule:   get("/questions"),
+			target: "/questions?slot=2&_=123&spec.point_cost=1",
+			want:   &questionrpc.ListQuestionsRequest{Slot: 2},
+		},
+		{
+			name:       "invalid query",
+			rule:       get("/awards"),
+			target:     "/awards?award_id=a1&award_id=a2&spec=x&spec.point_cost=lots&award_id.x=1",
+			want:       &rewardrpc.UpdateAwardRequest{},
+			wantFields: []string{"award_id", "award_id.x", "spec", "spec.point_cost"},
+		},
+		{
+			name:        "whole body",
+			rule:        post("/claim", "*"),
+			target:      "/claim?award_id=ignored",
+			contentType: "application/json",
+			body:        `{"award_id": "a1"}`,
+			want:        &rewardrpc.ClaimAwardRequest{AwardId: "a1"},
+		},
+		{
+			name:   "empty body",
+			rule:   post("/claim", "*"),
+			target: "/claim",
+			want:   &rewardrpc.ClaimAwardRequest{},
+		},
+		{
+			name:        "malformed body",
+			rule:        post("/claim", "*"),
+			target:      "/claim",
+			contentType: "application/json",
+			body:        `{"award_id": `,
+			want:        &rewardrpc.ClaimAwardRequest{},
+			wantCode:    "INVALID_BODY",
+		},
+		{
+			name:        "unknown body field",
+			rule:        post("/claim", "*"),
+			target:      "/claim",
+			contentType: "application/json",
+			body:        `{"award": "a1"}`,
+			want:        &rewardrpc.ClaimAwardRequest{},
+			wantCode:    "INVALID_BODY",
+		},
+		{
+			name:        "message field body",
+			rule:        post("/awards/{award_id}", "spec"),
+			target:      "/awards/a1",
+			contentType: "application/json",
+			body:        `{"product": "mug", "point_cost": "30"}`,
+			want: &rewardrpc.UpdateAwardRequest{
+				AwardId: "a1",
+				Spec:    &rewardrpc.AwardSpec{Product: "mug", PointCost: 30},
+			},
+		},
+		{
+			name:        "raw bytes body",
+			rule:        post("/awards/{award_id}/vouchers", "csv"),
+			target:      "/awards/a1/vouchers",
+			contentType: "text/csv",
+			body:        "code\nA1\nB2\n",
+			want:        &rewardrpc.UploadVouchersRequest{AwardId: "a1", Csv: []byte("code\nA1\nB2\n")},
+		},
+		{
+			name:        "JSON bytes body",
+			rule:        post("/awards/{award_id}/vouchers", "csv"),
+			target:      "/awards/a1/vouchers",
+			contentType: "application/json; charset=utf-8",
+			body:        `"QTEKQjIK"`,
+			want:        &rewardrpc.UploadVouchersRequest{AwardId: "a1", Csv: []byte("A1\nB2\n")},
+		},
+		{
+			name:        "JSON bytes body that is not base64",
+			rule:        post("/awards/{award_id}/vouchers", "csv"),
+			target:      "/awards/a1/vouchers",
+			contentType: "application/json",
+			body:        "code\nA1\n",
+			want:        &rewardrpc.UploadVouchersRequest{},
+			wantCode:    "INVALID_BODY",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			b := ruleBinding(tc.rule)
+			if err := b.check(tc.want.ProtoReflect().Descriptor()); err != nil {
+				t.Fatalf("check: %v", err)
+			}
+
+			got := tc.want.ProtoReflect().New().Interface()
+			var err error
+			decoded := false
+			mux := http.NewServeMux()
+			mux.HandleFunc(b.method+" "+b.path, func(w http.ResponseWriter, r *http.Request) {
+				decoded = true
+				err = b.decode(w, r, got)
+			})
+			r := httptest.NewRequest(b.method, tc.target, strings.NewReader(tc.body))
+			if tc.contentType != "" {
+				r.Header.Set("Content-Type", tc.contentType)
+			}
+			mux.ServeHTTP(httptest.NewRecorder(), r)
+			if !decoded {
+				t.Fatalf("%s %s did not match %s %s", b.method, tc.target, b.method, b.path)
+			}
+
+			switch {
+			case tc.wantFields != nil:
+				e, ok := apperr.From(err)
+				if !ok || e.Code != "INVALID_ARGUMENT" {
+					t.Fatalf("decode: got %v, want INVALID_ARGUMENT", err)
+				}
+				var fields []string
+				for _, v := range e.Violations {
+					fields = append(fields, v.Field)
+				}
+				sort.Strings(fields)
+				if !reflect.DeepEqual(fields, tc.wantFields) {
+					t.Fatalf("violations %v, want fields %v", e.Violations, tc.wantFields)
+				}
+			case tc.wantCode != "":
+				if apperr.CodeOf(err) != tc.wantCode {
+					t.Fatalf("decode: got %v, want %s", err, tc.wantCode)
+				}
+			case err != nil:
+				t.Fatalf("decode: %v", err)
+			case !proto.Equal(got, tc.want):
+				t.Fatalf("decoded %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCheckRejectsUnsupportedRules(t *testing.T) {
+	update := (&rewardrpc.UpdateAwardRequest{}).ProtoReflect().Descriptor()
+	question := (&questionrpc.CreateQuestionRequest{}).ProtoReflect().Descriptor()
+	for _, tc := range []struct {
+		name string
+		rule *annotations.HttpRule
+		in   protoreflect.MessageDescriptor
+		want string
+	}{
+		{
+			name: "response body",
+			rule: &annotations.HttpRule{Pattern: &annotations.HttpRule_Get{Get: "/awards"}, ResponseBody: "award"},
+			in:   update,
+			want: "response_body",
+		},
+		{name: "unknown path variable", rule: get("/awards/{id}"), in: update, want: "{id}"},
+		{name: "message path variable", rule: get("/awards/{spec}"), in: update, want: "{spec}"},
+		{name: "repeated path variable", rule: get("/questions/{options}"), in: question, want: "{options}"},
+		{name: "partial segment variable", rule: get("/awards/award-{award_id}"), in: update, want: "award-{award_id}"},
+		{name: "nested path variable", rule: get("/awards/{spec.product}"), in: update, want: "{spec.product}"},
+		{name: "unknown body field", rule: post("/awards", "award"), in: update, want: `"award"`},
+		{name: "scalar body field", rule: post("/awards", "award_id"), in: update, want: `"award_id"`},
+		{name: "repeated body field", rule: post("/questions", "options"), in: question, want: `"options"`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ruleBinding(tc.rule).check(tc.in)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("check() = %v, want an error about %s", err, tc.want)
+			}
+		})
+	}
+}
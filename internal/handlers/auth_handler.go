@@ -2,9 +2,13 @@ package handlers
 
 import (
     "context"
+    "strings"
+
+    "google.golang.org/grpc/metadata"
 
     auth "github.com/rprajapati0067/quiz-game-backend/rpc/auth"
 
+    "github.com/rprajapati0067/quiz-game-backend/internal/access"
     "github.com/rprajapati0067/quiz-game-backend/internal/service"
 )
 
@@ -25,4 +29,79 @@ func (h *AuthHandler) Signup(ctx context.Context, req *auth.SignupRequest) (*aut
     return &auth.SignupResponse{UserId: u.ID}, nil
 }
 
-// TODO: implement Login and VerifyPhone using AuthService once repository supports it.
+func (h *AuthHandler) VerifyPhone(ctx context.Context, req *auth.VerifyPhoneRequest) (*auth.VerifyPhoneResponse, error) {
+    u, err := h.svc.VerifyPhone(ctx, req.Phone, req.Otp)
+    if err != nil {
+        return nil, err
+    }
+    return &auth.VerifyPhoneResponse{Verified: u.Verified}, nil
+}
+
+func (h *AuthHandler) Login(ctx context.Context, req *auth.LoginRequest) (*auth.LoginResponse, error) {
+    var userAgent string
+    if md, ok := metadata.FromIncomingContext(ctx); ok {
+        userAgent = strings.Join(md.Get("user-agent"), " ")
+    }
+
+    res, err := h.svc.Login(ctx, req.Phone, req.Otp, userAgent)
+    if err != nil {
+        return nil, err
+    }
+    if res.OTPSent {
+        return &auth.LoginResponse{OtpSent: true}, nil
+    }
+    return &auth.LoginResponse{
+        Token:        res.AccessToken,
+        ExpiresAt:    res.ExpiresAt.Unix(),
+        RefreshToken: res.RefreshToken,
+    }, nil
+}
+
+func (h *AuthHandler) Refresh(ctx context.Context, req *auth.RefreshRequest) (*auth.RefreshResponse, error) {
+    res, err := h.svc.Refresh(ctx, req.RefreshToken)
+    if err != nil {
+        return nil, err
+    }
+    return &auth.RefreshResponse{
+        Token:        res.AccessToken,
+        ExpiresAt:    res.ExpiresAt.Unix(),
+        RefreshToken: res.RefreshToken,
+    }, nil
+}
+
+func (h *AuthHandler) Logout(ctx context.Context, req *auth.LogoutRequest) (*auth.LogoutResponse, error) {
+    if err := h.svc.Logout(ctx, req.RefreshToken); err != nil {
+        return nil, err
+    }
+    return &auth.LogoutResponse{}, nil
+}
+
+func (h *AuthHandler) ListSessions(ctx context.Context, req *auth.ListSessionsRequest) (*auth.ListSessionsResponse, error) {
+    sessions, err := h.svc.ListSessions(ctx)
+    if err != nil {
+        return nil, err
+    }
+    var current string
+    if p, ok := access.PrincipalFromContext(ctx); ok {
+        current = p.SessionID
+    }
+    res := &auth.ListSessionsResponse{}
+    for _, s := range sessions {
+        res.Sessions = append(res.Sessions, &auth.Session{
+            Id:         s.ID,
+            UserAgent:  s.UserAgent,
+            CreatedAt:  s.CreatedAt.Unix(),
+            LastUsedAt: s.LastUsedAt.Unix(),
+            ExpiresAt:  s.ExpiresAt.Unix(),
+            Current:    s.ID == current,
+        })
+    }
+    return res, nil
+}
+
+func (h *AuthHandler) RevokeSession(ctx context.Context, req *auth.RevokeSessionRequest) (*auth.RevokeSessionResponse, error) {
+    if err := h.svc.RevokeSession(ctx, req.SessionId); err != nil {
+        return nil, err
+    }
+    return &auth.RevokeSessionResponse{}, nil
+}
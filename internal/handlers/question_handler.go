@@ -3,8 +3,12 @@ package handlers
 import (
     "context"
 
+    "google.golang.org/protobuf/proto"
+
     question "github.com/rprajapati0067/quiz-game-backend/rpc/question"
 
+    "github.com/rprajapati0067/quiz-game-backend/internal/access"
+    "github.com/rprajapati0067/quiz-game-backend/internal/models"
     "github.com/rprajapati0067/quiz-game-backend/internal/service"
 )
 
@@ -18,37 +22,67 @@ func NewQuestionHandler(svc service.QuestionService) *QuestionHandler {
 }
 
 func (h *QuestionHandler) CreateQuestion(ctx context.Context, req *question.CreateQuestionRequest) (*question.CreateQuestionResponse, error) {
-    q, err := h.svc.Create(ctx, req.Text, req.Options, req.CorrectIndex, req.Slot, "admin")
+    q, err := h.svc.Create(ctx, req.Text, req.Options, req.CorrectIndex, req.Slot)
     if err != nil {
         return nil, err
     }
-    return &question.CreateQuestionResponse{
-        Question: &question.Question{
-            Id:           q.ID,
-            Text:         q.Text,
-            Options:      q.Options,
-            CorrectIndex: q.CorrectIndex,
-            Slot:         q.Slot,
-        },
-    }, nil
+    return &question.CreateQuestionResponse{Question: fullQuestion(q)}, nil
 }
 
 func (h *QuestionHandler) ListQuestions(ctx context.Context, req *question.ListQuestionsRequest) (*question.ListQuestionsResponse, error) {
-    qs, err := h.svc.ListBySlot(ctx, req.Slot)
+    res := &question.ListQuestionsResponse{}
+    if canSeeAnswers(ctx) {
+        qs, err := h.svc.ListBySlot(ctx, req.Slot)
+        if err != nil {
+            return nil, err
+        }
+        for _, q := range qs {
+            res.Questions = append(res.Questions, fullQuestion(q))
+        }
+        return res, nil
+    }
+
+    qs, err := h.svc.ListForPlayer(ctx, req.Slot)
     if err != nil {
         return nil, err
     }
-    res := &question.ListQuestionsResponse{}
     for _, q := range qs {
         res.Questions = append(res.Questions, &question.Question{
-            Id:           q.ID,
-            Text:         q.Text,
-            Options:      q.Options,
-            CorrectIndex: q.CorrectIndex,
-            Slot:         q.Slot,
+            Id:      q.ID,
+            Text:    q.Text,
+            Options: q.Options,
+            Slot:    q.Slot,
         })
     }
     return res, nil
 }
 
-// SubmitAnswer is left as TODO for you to implement.
+func (h *QuestionHandler) SubmitAnswer(ctx context.Context, req *question.SubmitAnswerRequest) (*question.SubmitAnswerResponse, error) {
+    res, err := h.svc.SubmitAnswer(ctx, req.QuestionId, req.SelectedIndex, req.IdempotencyKey)
+    if err != nil {
+        return nil, err
+    }
+    return &question.SubmitAnswerResponse{
+        Correct:       res.Answer.Correct,
+        UpdatedPoints: res.UpdatedPoints,
+        Replayed:      res.Replayed,
+    }, nil
+}
+
+func fullQuestion(q *models.Question) *question.Question {
+    return &question.Question{
+        Id:           q.ID,
+        Text:         q.Text,
+        Options:      q.Options,
+        CorrectIndex: proto.Int32(q.CorrectIndex),
+        Slot:         q.Slot,
+        CreatedBy:    q.CreatedBy,
+    }
+}
+
+// canSeeAnswers reports whether the caller gets the editor view of
+// questions, including correct answers and authorship.
+func canSeeAnswers(ctx context.Context) bool {
+    p, ok := access.PrincipalFromContext(ctx)
+    return ok && p.Can(access.PermManageQuestion)
+}
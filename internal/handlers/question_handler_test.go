@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	question "github.com/rprajapati0067/quiz-game-backend/rpc/question"
+
+	"github.com/rprajapati0067/quiz-game-backend/internal/access"
+	"github.com/rprajapati0067/quiz-game-backend/internal/models"
+	"github.com/rprajapati0067/quiz-game-backend/internal/repository"
+	"github.com/rprajapati0067/quiz-game-backend/internal/service"
+)
+
+func as(userID string, role models.Role) context.Context {
+	return access.WithPrincipal(context.Background(), &access.Principal{UserID: userID, Roles: []models.Role{role}})
+}
+
+func TestListQuestionsHidesAnswersFromPlayers(t *testing.T) {
+	svc := service.NewQuestionService(repository.NewMemoryQuestionRepository(), repository.NewMemoryAnswerRepository(),
+		repository.NewMemoryLedgerRepository(), service.QuestionConfig{})
+	h := NewQuestionHandler(svc)
+	created, err := h.CreateQuestion(as("editor", models.RoleEditor), &question.CreateQuestionRequest{
+		Text:         "2 + 2?",
+		Options:      []string{"3", "4"},
+		CorrectIndex: 1,
+		Slot:         1,
+	})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if created.Question.CorrectIndex == nil || created.Question.GetCorrectIndex() != 1 {
+		t.Fatalf("created %v, want the correct index", created.Question)
+	}
+
+	for _, tc := range []struct {
+		role        models.Role
+		wantAnswers bool
+	}{
+		{role: models.RolePlayer},
+		{role: models.RoleSupport},
+		{role: models.RoleEditor, wantAnswers: true},
+		{role: models.RoleAdmin, wantAnswers: true},
+	} {
+		res, err := h.ListQuestions(as("u-"+string(tc.role), tc.role), &question.ListQuestionsRequest{Slot: 1})
+		if err != nil {
+			t.Fatalf("list as %s: %v", tc.role, err)
+		}
+		if len(res.Questions) != 1 {
+			t.Fatalf("list as %s returned %d questions, want 1", tc.role, len(res.Questions))
+		}
+		q := res.Questions[0]
+		if q.Id != created.Question.Id || q.Text != "2 + 2?" || len(q.Options) != 2 {
+			t.Fatalf("list as %s returned %v, want the question", tc.role, q)
+		}
+		seesAnswers := q.CorrectIndex != nil || q.CreatedBy != ""
+		if seesAnswers != tc.wantAnswers {
+			t.Fatalf("list as %s returned %v, want answers shown: %v", tc.role, q, tc.wantAnswers)
+		}
+	}
+}
@@ -0,0 +1,205 @@
+package handlers
+
+import (
+    "bytes"
+    "context"
+    "time"
+
+    reward "github.com/rprajapati0067/quiz-game-backend/rpc/reward"
+
+    "github.com/rprajapati0067/quiz-game-backend/internal/access"
+    "github.com/rprajapati0067/quiz-game-backend/internal/models"
+    "github.com/rprajapati0067/quiz-game-backend/internal/service"
+)
+
+type RewardHandler struct {
+    reward.UnimplementedRewardServiceServer
+    svc service.RewardService
+}
+
+func NewRewardHandler(svc service.RewardService) *RewardHandler {
+    return &RewardHandler{svc: svc}
+}
+
+func (h *RewardHandler) ListAwards(ctx context.Context, req *reward.ListAwardsRequest) (*reward.ListAwardsResponse, error) {
+    list := h.svc.ListAwards
+    if canManageAwards(ctx) {
+        list = h.svc.ListAllAwards
+    }
+    awards, err := list(ctx)
+    if err != nil {
+        return nil, err
+    }
+    res := &reward.ListAwardsResponse{}
+    for _, a := range awards {
+        res.Awards = append(res.Awards, awardMessage(a))
+    }
+    return res, nil
+}
+
+func (h *RewardHandler) ClaimAward(ctx context.Context, req *reward.ClaimAwardRequest) (*reward.ClaimAwardResponse, error) {
+    res, err := h.svc.ClaimAward(ctx, req.AwardId)
+    if err != nil {
+        return nil, err
+    }
+    return &reward.ClaimAwardResponse{
+        Success:         true,
+        RemainingPoints: res.RemainingPoints,
+        Claim:           claimMessage(res.Claim),
+        VoucherCode:     res.Claim.VoucherCode,
+    }, nil
+}
+
+func (h *RewardHandler) CreateAward(ctx context.Context, req *reward.CreateAwardRequest) (*reward.AwardResponse, error) {
+    a, err := h.svc.CreateAward(ctx, awardSpec(req.Spec), req.Stock)
+    return awardResponse(a, err)
+}
+
+func (h *RewardHandler) UpdateAward(ctx context.Context, req *reward.UpdateAwardRequest) (*reward.AwardResponse, error) {
+    a, err := h.svc.UpdateAward(ctx, req.AwardId, awardSpec(req.Spec))
+    return awardResponse(a, err)
+}
+
+func (h *RewardHandler) RestockAward(ctx context.Context, req *reward.RestockAwardRequest) (*reward.AwardResponse, error) {
+    a, err := h.svc.RestockAward(ctx, req.AwardId, req.Quantity)
+    return awardResponse(a, err)
+}
+
+func (h *RewardHandler) RetireAward(ctx context.Context, req *reward.RetireAwardRequest) (*reward.AwardResponse, error) {
+    a, err := h.svc.RetireAward(ctx, req.AwardId)
+    return awardResponse(a, err)
+}
+
+func (h *RewardHandler) UploadVouchers(ctx context.Context, req *reward.UploadVouchersRequest) (*reward.UploadVouchersResponse, error) {
+    res, err := h.svc.UploadVouchers(ctx, req.AwardId, bytes.NewReader(req.Csv))
+    if err != nil {
+        return nil, err
+    }
+    return &reward.UploadVouchersResponse{
+        Award:      awardMessage(res.Award),
+        Added:      int32(res.Added),
+        Duplicates: int32(res.Duplicates),
+    }, nil
+}
+
+func (h *RewardHandler) ListMyClaims(ctx context.Context, req *reward.ListMyClaimsRequest) (*reward.ListClaimsResponse, error) {
+    claims, err := h.svc.ListMyClaims(ctx)
+    return claimsResponse(claims, err)
+}
+
+func (h *RewardHandler) ListClaims(ctx context.Context, req *reward.ListClaimsRequest) (*reward.ListClaimsResponse, error) {
+    status := models.ClaimStatus(req.Status)
+    if status == "" {
+        status = models.ClaimPending
+    }
+    claims, err := h.svc.ListClaims(ctx, status)
+    return claimsResponse(claims, err)
+}
+
+func (h *RewardHandler) ApproveClaim(ctx context.Context, req *reward.ClaimTransitionRequest) (*reward.ClaimResponse, error) {
+    c, err := h.svc.ApproveClaim(ctx, req.ClaimId, req.Note)
+    return claimResponse(c, err)
+}
+
+func (h *RewardHandler) FulfillClaim(ctx context.Context, req *reward.ClaimTransitionRequest) (*reward.ClaimResponse, error) {
+    c, err := h.svc.FulfillClaim(ctx, req.ClaimId, req.Note)
+    return claimResponse(c, err)
+}
+
+func (h *RewardHandler) RejectClaim(ctx context.Context, req *reward.ClaimTransitionRequest) (*reward.ClaimResponse, error) {
+    c, err := h.svc.RejectClaim(ctx, req.ClaimId, req.Note)
+    return claimResponse(c, err)
+}
+
+func (h *RewardHandler) RefundClaim(ctx context.Context, req *reward.ClaimTransitionRequest) (*reward.ClaimResponse, error) {
+    c, err := h.svc.RefundClaim(ctx, req.ClaimId)
+    return claimResponse(c, err)
+}
+
+func claimsResponse(claims []*models.Claim, err error) (*reward.ListClaimsResponse, error) {
+    if err != nil {
+        return nil, err
+    }
+    res := &reward.ListClaimsResponse{}
+    for _, c := range claims {
+        res.Claims = append(res.Claims, claimMessage(c))
+    }
+    return res, nil
+}
+
+func claimResponse(c *models.Claim, err error) (*reward.ClaimResponse, error) {
+    if err != nil {
+        return nil, err
+    }
+    return &reward.ClaimResponse{Claim: claimMessage(c)}, nil
+}
+
+func claimMessage(c *models.Claim) *reward.Claim {
+    return &reward.Claim{
+        Id:          c.ID,
+        UserId:      c.UserID,
+        AwardId:     c.AwardID,
+        Points:      c.Points,
+        Status:      string(c.Status),
+        Note:        c.Note,
+        ClaimedAt:   c.ClaimedAt.Unix(),
+        UpdatedAt:   c.UpdatedAt.Unix(),
+        VoucherCode: c.VoucherCode,
+    }
+}
+
+func awardResponse(a *models.Award, err error) (*reward.AwardResponse, error) {
+    if err != nil {
+        return nil, err
+    }
+    return &reward.AwardResponse{Award: awardMessage(a)}, nil
+}
+
+func awardSpec(spec *reward.AwardSpec) service.AwardSpec {
+    return service.AwardSpec{
+        Digital:        spec.GetDigital(),
+        Product:        spec.GetProduct(),
+        PointCost:      spec.GetPointCost(),
+        PerUserLimit:   spec.GetPerUserLimit(),
+        AvailableFrom:  fromUnix(spec.GetAvailableFrom()),
+        AvailableUntil: fromUnix(spec.GetAvailableUntil()),
+    }
+}
+
+func awardMessage(a *models.Award) *reward.Award {
+    return &reward.Award{
+        Id:             a.ID,
+        Product:        a.Product,
+        PointCost:      a.PointCost,
+        TotalStock:     a.TotalStock,
+        RemainingStock: a.RemainingStock,
+        PerUserLimit:   a.PerUserLimit,
+        AvailableFrom:  toUnix(a.AvailableFrom),
+        AvailableUntil: toUnix(a.AvailableUntil),
+        Active:         a.Active,
+        Digital:        a.Digital,
+    }
+}
+
+// canManageAwards reports whether the caller gets the admin view of awards,
+// including retired, scheduled and sold-out ones.
+func canManageAwards(ctx context.Context) bool {
+    p, ok := access.PrincipalFromContext(ctx)
+    return ok && p.Can(access.PermManageAwards)
+}
+
+// fromUnix and toUnix map the zero time to 0 and back, so an unset
+// availability bound stays unset across the wire.
+func fromUnix(sec int64) time.Time {
+    if sec == 0 {
+        return time.Time{}
+    }
+    return time.Unix(sec, 0)
+}
+
+func toUnix(t time.Time) int64 {
+    if t.IsZero() {
+        return 0
+    }
+    return t.Unix()
+}
@@ -5,6 +5,7 @@ import (
 
     user "github.com/rprajapati0067/quiz-game-backend/rpc/user"
 
+    "github.com/rprajapati0067/quiz-game-backend/internal/models"
     "github.com/rprajapati0067/quiz-game-backend/internal/service"
 )
 
@@ -18,6 +19,63 @@ func NewUserHandler(svc service.UserService) *UserHandler {
 }
 
 func (h *UserHandler) Me(ctx context.Context, req *user.MeRequest) (*user.MeResponse, error) {
-    // TODO: extract user id from auth (e.g. JWT). For now this is just a stub.
-    return &user.MeResponse{}, nil
+    u, err := h.svc.Me(ctx)
+    if err != nil {
+        return nil, err
+    }
+    return &user.MeResponse{
+        UserId:   u.ID,
+        Name:     u.Name,
+        Phone:    u.Phone,
+        Email:    u.Email,
+        Verified: u.Verified,
+        Blocked:  u.Blocked,
+        Points:   u.Points,
+        Roles:    roleNames(u.Roles),
+    }, nil
+}
+
+func (h *UserHandler) GrantRole(ctx context.Context, req *user.GrantRoleRequest) (*user.GrantRoleResponse, error) {
+    u, err := h.svc.GrantRole(ctx, req.UserId, models.Role(req.Role))
+    if err != nil {
+        return nil, err
+    }
+    return &user.GrantRoleResponse{Roles: roleNames(u.Roles)}, nil
+}
+
+func (h *UserHandler) RevokeRole(ctx context.Context, req *user.RevokeRoleRequest) (*user.RevokeRoleResponse, error) {
+    u, err := h.svc.RevokeRole(ctx, req.UserId, models.Role(req.Role))
+    if err != nil {
+        return nil, err
+    }
+    return &user.RevokeRoleResponse{Roles: roleNames(u.Roles)}, nil
+}
+
+func (h *UserHandler) PointsHistory(ctx context.Context, req *user.PointsHistoryRequest) (*user.PointsHistoryResponse, error) {
+    page, err := h.svc.PointsHistory(ctx, int(req.PageSize), req.PageToken)
+    if err != nil {
+        return nil, err
+    }
+    res := &user.PointsHistoryResponse{NextPageToken: page.NextPageToken}
+    for _, e := range page.Entries {
+        res.Entries = append(res.Entries, &user.PointsEntry{
+            Id:            e.ID,
+            Kind:          string(e.Kind),
+            Amount:        e.Amount,
+            Reason:        string(e.Reason),
+            ReferenceType: e.RefType,
+            ReferenceId:   e.RefID,
+            Balance:       e.Balance,
+            CreatedAt:     e.CreatedAt.Unix(),
+        })
+    }
+    return res, nil
+}
+
+func roleNames(roles []models.Role) []string {
+    names := make([]string, 0, len(roles))
+    for _, r := range roles {
+        names = append(names, string(r))
+    }
+    return names
 }
@@ -0,0 +1,97 @@
+package health
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// watchInterval is how often Watch re-runs the checks.
+const watchInterval = 5 * time.Second
+
+// GRPCServer implements grpc.health.v1.Health on top of a Registry. The
+// overall service "" and every gRPC service on the server share the
+// readiness verdict. Watch streams end once the server starts shutting
+// down, so they do not hold up a graceful stop.
+type GRPCServer struct {
+	healthpb.UnimplementedHealthServer
+	registry *Registry
+	ready    func() bool
+	services map[string]bool
+}
+
+// RegisterGRPC adds the health service to s, covering the services already
+// registered on it.
+func (r *Registry) RegisterGRPC(s *grpc.Server, ready func() bool) {
+	services := map[string]bool{"": true}
+	for name := range s.GetServiceInfo() {
+		services[name] = true
+	}
+	healthpb.RegisterHealthServer(s, &GRPCServer{registry: r, ready: ready, services: services})
+}
+
+func (h *GRPCServer) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	if !h.services[req.Service] {
+		return nil, status.Errorf(codes.NotFound, "unknown service %q", req.Service)
+	}
+	return &healthpb.HealthCheckResponse{Status: h.status(ctx)}, nil
+}
+
+func (h *GRPCServer) List(ctx context.Context, req *healthpb.HealthListRequest) (*healthpb.HealthListResponse, error) {
+	st := h.status(ctx)
+	res := &healthpb.HealthListResponse{Statuses: make(map[string]*healthpb.HealthCheckResponse, len(h.services))}
+	for name := range h.services {
+		res.Statuses[name] = &healthpb.HealthCheckResponse{Status: st}
+	}
+	return res, nil
+}
+
+// Watch sends the status and then every change to it. For an unknown
+// service it sends SERVICE_UNKNOWN and ends the stream.
+func (h *GRPCServer) Watch(req *healthpb.HealthCheckRequest, stream grpc.ServerStreamingServer[healthpb.HealthCheckResponse]) error {
+	ctx := stream.Context()
+	if !h.services[req.Service] {
+		return stream.Send(&healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVICE_UNKNOWN})
+	}
+
+	// Readiness is polled every second so streams close promptly on
+	// shutdown; the checks themselves only run every watchInterval.
+	poll := time.NewTicker(time.Second)
+	defer poll.Stop()
+	last := healthpb.HealthCheckResponse_UNKNOWN
+	var checked time.Time
+	for {
+		st := last
+		switch {
+		case !h.ready():
+			st = healthpb.HealthCheckResponse_NOT_SERVING
+		case time.Since(checked) >= watchInterval:
+			st, checked = h.status(ctx), time.Now()
+		}
+		if st != last {
+			if err := stream.Send(&healthpb.HealthCheckResponse{Status: st}); err != nil {
+				return err
+			}
+			last = st
+		}
+		if !h.ready() {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-poll.C:
+		}
+	}
+}
+
+func (h *GRPCServer) status(ctx context.Context) healthpb.HealthCheckResponse_ServingStatus {
+	if h.registry.Check(ctx, h.ready).OK() {
+		return healthpb.HealthCheckResponse_SERVING
+	}
+	return healthpb.HealthCheckResponse_NOT_SERVING
+}
@@ -0,0 +1,117 @@
+// Package health tells load balancers and orchestrators whether the server
+// can do its job. Components such as the repositories, the token keys and
+// the OTP sender register a Checker; liveness only says the process
+// answers, while readiness runs every check and also goes down while the
+// server is shutting down. The same verdict is served over HTTP
+// (/livez, /readyz) and as the standard grpc.health.v1 service.
+package health
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Status values reported per component and overall.
+const (
+	StatusOK           = "ok"
+	StatusFailing      = "failing"
+	StatusShuttingDown = "shutting_down"
+)
+
+// checkTimeout bounds each check so one hung dependency cannot hang the
+// probe.
+const checkTimeout = 2 * time.Second
+
+// Checker is implemented by components that can tell whether they work.
+type Checker interface {
+	Check(ctx context.Context) error
+}
+
+// CheckerFunc adapts a function to Checker.
+type CheckerFunc func(ctx context.Context) error
+
+func (f CheckerFunc) Check(ctx context.Context) error {
+	return f(ctx)
+}
+
+type Registry struct {
+	mu     sync.Mutex
+	checks map[string]Checker
+}
+
+func NewRegistry() *Registry {
+	return &Registry{checks: make(map[string]Checker)}
+}
+
+// Register adds a check under name, replacing any earlier one.
+func (r *Registry) Register(name string, c Checker) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.checks[name] = c
+}
+
+// Report is the outcome of a readiness check.
+type Report struct {
+	Status     string               `json:"status"`
+	Components map[string]Component `json:"components"`
+}
+
+// Component is one check's outcome. Error is kept out of the JSON so a
+// probe cannot read hosts, paths or driver messages; ReadyHandler logs it.
+type Component struct {
+	Status string `json:"status"`
+	Error  string `json:"-"`
+}
+
+// OK reports whether the server should receive traffic.
+func (r Report) OK() bool {
+	return r.Status == StatusOK
+}
+
+// Check runs every registered check concurrently. ready is the server's
+// own state; when it returns false the report is StatusShuttingDown
+// whatever the checks say.
+func (r *Registry) Check(ctx context.Context, ready func() bool) Report {
+	r.mu.Lock()
+	names := make([]string, 0, len(r.checks))
+	for name := range r.checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	checks := make([]Checker, len(names))
+	for i, name := range names {
+		checks[i] = r.checks[name]
+	}
+	r.mu.Unlock()
+
+	results := make([]Component, len(names))
+	var wg sync.WaitGroup
+	for i, c := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+			defer cancel()
+			if err := c.Check(ctx); err != nil {
+				results[i] = Component{Status: StatusFailing, Error: err.Error()}
+				return
+			}
+			results[i] = Component{Status: StatusOK}
+		}()
+	}
+	wg.Wait()
+
+	report := Report{Status: StatusOK, Components: make(map[string]Component, len(names))}
+	for i, name := range names {
+		report.Components[name] = results[i]
+		if results[i].Status != StatusOK {
+			report.Status = StatusFailing
+		}
+	}
+	if !ready() {
+		report.Status = StatusShuttingDown
+	}
+	return report
+}
@@ -0,0 +1,55 @@
+package health
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/rprajapati0067/quiz-game-backend/internal/logging"
+)
+
+// LiveHandler answers 200 as long as the process can serve HTTP at all. It
+// checks no dependencies: restarting the server would not fix a database
+// outage.
+func LiveHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]string{"status": StatusOK})
+	})
+}
+
+// ReadyHandler runs the checks and answers 200 with the per-component
+// report when the server should receive traffic and 503 otherwise. The
+// report names each component and its status; why a check failed is only
+// logged.
+func (r *Registry) ReadyHandler(ready func() bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		report := r.Check(req.Context(), ready)
+		code := http.StatusOK
+		if !report.OK() {
+			code = http.StatusServiceUnavailable
+			logFailures(report)
+		}
+		writeJSON(w, code, report)
+	})
+}
+
+func logFailures(report Report) {
+	names := make([]string, 0, len(report.Components))
+	for name := range report.Components {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if c := report.Components[name]; c.Error != "" {
+			logging.Error(fmt.Sprintf("Readiness check %s failed: %s", name, c.Error))
+		}
+	}
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,45 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestReadyHandlerHidesCheckErrors(t *testing.T) {
+	r := NewRegistry()
+	r.Register("storage", CheckerFunc(func(ctx context.Context) error { return nil }))
+	r.Register("otp_sender", CheckerFunc(func(ctx context.Context) error {
+		return errors.New("open /var/otp/outbox: permission denied")
+	}))
+
+	rec := httptest.NewRecorder()
+	r.ReadyHandler(func() bool { return true }).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("status %d, want %d", rec.Code, http.StatusServiceUnavailable)
+	}
+	if body := rec.Body.String(); strings.Contains(body, "outbox") || strings.Contains(body, "error") {
+		t.Fatalf("response leaks the check error: %s", body)
+	}
+	var report struct {
+		Status     string                       `json:"status"`
+		Components map[string]map[string]string `json:"components"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &report); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	want := map[string]string{"storage": StatusOK, "otp_sender": StatusFailing}
+	if report.Status != StatusFailing || len(report.Components) != len(want) {
+		t.Fatalf("report %+v, want %s with %v", report, StatusFailing, want)
+	}
+	for name, status := range want {
+		if c := report.Components[name]; len(c) != 1 || c["status"] != status {
+			t.Fatalf("component %s is %v, want only status %s", name, c, status)
+		}
+	}
+}
@@ -0,0 +1,192 @@
+// Package lifecycle runs the server's listeners until the process is told to
+// stop, then drains them within a deadline and releases what they used.
+//
+// Shutdown happens in three steps: the Manager stops reporting ready and
+// keeps serving for the drain delay so load balancers move traffic away,
+// then every server stops accepting new requests and waits for in-flight
+// ones (an answer being recorded, a claim being reserved) to finish, and
+// finally the OnStop hooks run in reverse order of registration, e.g. to
+// close database connections. Servers still busy when the deadline passes
+// are stopped hard.
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/rprajapati0067/quiz-game-backend/internal/logging"
+)
+
+type Manager struct {
+	timeout    time.Duration
+	drainDelay time.Duration
+	servers    []server
+	hooks      []hook
+	ready      atomic.Bool
+}
+
+// server is a listener loop. serve blocks until stop is called and then
+// returns nil; any other return is a failure.
+type server struct {
+	name  string
+	serve func() error
+	stop  func(ctx context.Context) error
+}
+
+type hook struct {
+	name string
+	fn   func() error
+}
+
+// New returns a Manager that gives its servers shutdownTimeout to drain.
+// On a stop request it reports not ready for drainDelay before it stops
+// the servers, giving load balancers time to notice.
+func New(shutdownTimeout, drainDelay time.Duration) *Manager {
+	return &Manager{timeout: shutdownTimeout, drainDelay: drainDelay}
+}
+
+// Serve adds a server. stop must make serve return and should wait for
+// in-flight work until ctx is done.
+func (m *Manager) Serve(name string, serve func() error, stop func(ctx context.Context) error) {
+	m.servers = append(m.servers, server{name: name, serve: serve, stop: stop})
+}
+
+// ServeHTTP adds an HTTP server listening on ln.
+func (m *Manager) ServeHTTP(name string, srv *http.Server, ln net.Listener) {
+	m.Serve(name, func() error {
+		if err := srv.Serve(ln); !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	}, func(ctx context.Context) error {
+		if err := srv.Shutdown(ctx); err != nil {
+			srv.Close()
+			return err
+		}
+		return nil
+	})
+}
+
+// ServeGRPC adds a gRPC server listening on ln.
+func (m *Manager) ServeGRPC(name string, srv *grpc.Server, ln net.Listener) {
+	m.Serve(name, func() error {
+		if err := srv.Serve(ln); !errors.Is(err, grpc.ErrServerStopped) {
+			return err
+		}
+		return nil
+	}, func(ctx context.Context) error {
+		done := make(chan struct{})
+		go func() {
+			srv.GracefulStop()
+			close(done)
+		}()
+		select {
+		case <-done:
+			return nil
+		case <-ctx.Done():
+			srv.Stop()
+			<-done
+			return ctx.Err()
+		}
+	})
+}
+
+// OnStop registers fn to run once every server has stopped. Hooks run in
+// reverse order of registration.
+func (m *Manager) OnStop(name string, fn func() error) {
+	m.hooks = append(m.hooks, hook{name: name, fn: fn})
+}
+
+// Ready reports whether every server is serving and none is shutting down.
+func (m *Manager) Ready() bool {
+	return m.ready.Load()
+}
+
+// Run starts the servers and blocks until ctx is done or a server fails,
+// then shuts everything down. It returns the failure, if any, joined with
+// any error from draining or the hooks.
+func (m *Manager) Run(ctx context.Context) error {
+	failed := make(chan error, len(m.servers))
+	var wg sync.WaitGroup
+	for _, s := range m.servers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := s.serve(); err != nil {
+				failed <- fmt.Errorf("%s: %w", s.name, err)
+			}
+		}()
+	}
+	m.ready.Store(true)
+	logging.Info("Server is ready")
+
+	var runErr error
+	select {
+	case <-ctx.Done():
+		m.ready.Store(false)
+		runErr = m.drain(failed)
+		logging.Info(fmt.Sprintf("Shutting down, draining requests for up to %s", m.timeout))
+	case runErr = <-failed:
+		m.ready.Store(false)
+		logging.Error(fmt.Sprintf("Shutting down after failure: %v", runErr))
+	}
+
+	errs := []error{runErr}
+	errs = append(errs, m.stopServers()...)
+	wg.Wait()
+	for i := len(m.hooks) - 1; i >= 0; i-- {
+		if err := m.hooks[i].fn(); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", m.hooks[i].name, err))
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+	logging.Info("Shutdown complete")
+	return nil
+}
+
+// drain keeps the servers up, reporting not ready, for the drain delay.
+// A server failing meanwhile ends the wait early with its error.
+func (m *Manager) drain(failed <-chan error) error {
+	if m.drainDelay <= 0 {
+		return nil
+	}
+	logging.Info(fmt.Sprintf("Not ready, waiting %s for traffic to move away", m.drainDelay))
+	timer := time.NewTimer(m.drainDelay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case err := <-failed:
+		return err
+	}
+}
+
+// stopServers drains all servers at once under one shared deadline.
+func (m *Manager) stopServers() []error {
+	ctx, cancel := context.WithTimeout(context.Background(), m.timeout)
+	defer cancel()
+
+	errs := make([]error, len(m.servers))
+	var wg sync.WaitGroup
+	for i, s := range m.servers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := s.stop(ctx); err != nil {
+				errs[i] = fmt.Errorf("%s: stopped before draining: %w", s.name, err)
+			}
+		}()
+	}
+	wg.Wait()
+	return errs
+}
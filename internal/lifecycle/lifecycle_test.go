@@ -0,0 +1,70 @@
+package lifecycle
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRunReportsNotReadyBeforeStopping(t *testing.T) {
+	const drainDelay = 200 * time.Millisecond
+	m := New(time.Second, drainDelay)
+
+	var stoppedAt atomic.Int64
+	var readyAtStop atomic.Bool
+	done := make(chan struct{})
+	m.Serve("fake", func() error {
+		<-done
+		return nil
+	}, func(ctx context.Context) error {
+		readyAtStop.Store(m.Ready())
+		stoppedAt.Store(time.Now().UnixNano())
+		close(done)
+		return nil
+	})
+	hookRan := false
+	m.OnStop("hook", func() error {
+		if stoppedAt.Load() == 0 {
+			t.Error("hook ran before the server stopped")
+		}
+		hookRan = true
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	result := make(chan error, 1)
+	go func() { result <- m.Run(ctx) }()
+	waitFor(t, "ready", m.Ready)
+
+	cancel()
+	canceledAt := time.Now()
+	waitFor(t, "not ready", func() bool { return !m.Ready() })
+	if stoppedAt.Load() != 0 {
+		t.Fatal("server stopped before the drain delay")
+	}
+
+	if err := <-result; err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if readyAtStop.Load() {
+		t.Fatal("still ready when the server was stopped")
+	}
+	if waited := time.Unix(0, stoppedAt.Load()).Sub(canceledAt); waited < drainDelay {
+		t.Fatalf("server stopped %s after the stop request, want at least %s", waited, drainDelay)
+	}
+	if !hookRan {
+		t.Fatal("hook did not run")
+	}
+}
+
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
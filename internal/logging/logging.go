@@ -0,0 +1,53 @@
+// Package logging filters the quiz-app-tools logger by level. The server
+// logs through Info and Error here rather than calling the logger
+// directly, so the configured log_level applies to every message.
+package logging
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/rprajapati0067/quiz-app-tools/logger"
+)
+
+// Level is the least severe kind of message that is written.
+type Level int32
+
+const (
+	// LevelInfo writes everything. It is the default.
+	LevelInfo Level = iota
+	// LevelError writes only errors.
+	LevelError
+)
+
+// Levels lists the level names ParseLevel accepts.
+var Levels = []string{"info", "error"}
+
+var level atomic.Int32
+
+// ParseLevel returns the level named s.
+func ParseLevel(s string) (Level, error) {
+	for i, name := range Levels {
+		if s == name {
+			return Level(i), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown log level %q", s)
+}
+
+// SetLevel changes the level for every later message.
+func SetLevel(l Level) {
+	level.Store(int32(l))
+}
+
+// Info logs routine events such as startup and shutdown steps.
+func Info(msg string) {
+	if Level(level.Load()) <= LevelInfo {
+		logger.Info(msg)
+	}
+}
+
+// Error logs failures that need attention. It is written at every level.
+func Error(msg string) {
+	logger.Error(msg)
+}
@@ -0,0 +1,111 @@
+// Package metrics exposes the server's Prometheus metrics at /metrics.
+//
+// Transport metrics are recorded by the gRPC interceptors and the HTTP
+// middleware in this package, so every RPC and route is covered without
+// the handlers doing anything. Game metrics (signups, OTP sends, answers,
+// points, claims) are counters the services increment when the event has
+// been committed, so failed or rolled back attempts are not counted.
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// namespace prefixes the game metrics.
+const namespace = "quiz"
+
+// registry holds every metric of this package plus the Go runtime and
+// process collectors. It is separate from prometheus.DefaultRegisterer so
+// libraries cannot add metrics behind our back.
+var registry = prometheus.NewRegistry()
+
+var factory = promauto.With(registry)
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+}
+
+// Handler serves the metrics in the Prometheus exposition format.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+
+var (
+	signups = factory.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "signups_total",
+		Help:      "Users who signed up.",
+	})
+	otpSends = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "otp_sends_total",
+		Help:      "One-time passwords handed to the sender, by result (sent or failed).",
+	}, []string{"result"})
+	answersSubmitted = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "answers_submitted_total",
+		Help:      "Answers graded, by question slot. Replayed retries are not counted.",
+	}, []string{"slot"})
+	answersCorrect = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "answers_correct_total",
+		Help:      "Correct answers, by question slot.",
+	}, []string{"slot"})
+	pointsAwarded = factory.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "points_awarded_total",
+		Help:      "Points earned by correct answers. Claim refunds are not counted.",
+	})
+	awardsClaimed = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "awards_claimed_total",
+		Help:      "Award claims placed, by award type (digital or physical).",
+	}, []string{"type"})
+)
+
+// Signup records a new user.
+func Signup() {
+	signups.Inc()
+}
+
+// OTPSent records an attempt to deliver a one-time password; err is the
+// sender's result.
+func OTPSent(err error) {
+	result := "sent"
+	if err != nil {
+		result = "failed"
+	}
+	otpSends.WithLabelValues(result).Inc()
+}
+
+// AnswerSubmitted records a graded answer to a question in slot.
+func AnswerSubmitted(slot int32, correct bool) {
+	label := strconv.Itoa(int(slot))
+	answersSubmitted.WithLabelValues(label).Inc()
+	if correct {
+		answersCorrect.WithLabelValues(label).Inc()
+	}
+}
+
+// PointsAwarded records points earned by a correct answer.
+func PointsAwarded(points int64) {
+	pointsAwarded.Add(float64(points))
+}
+
+// AwardClaimed records a completed claim.
+func AwardClaimed(digital bool) {
+	kind := "physical"
+	if digital {
+		kind = "digital"
+	}
+	awardsClaimed.WithLabelValues(kind).Inc()
+}
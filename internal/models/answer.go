@@ -2,9 +2,16 @@ package models
 
 import "time"
 
+// Answer is a user's single graded attempt at a question. UpdatedPoints is
+// the balance reported back at submit time, kept so a retry carrying the
+// same IdempotencyKey gets the original response.
 type Answer struct {
-    UserID      string    `dynamodbav:"user_id"`
-    QuestionID  string    `dynamodbav:"question_id"`
-    SubmittedAt time.Time `dynamodbav:"submitted_at"`
-    Correct     bool      `dynamodbav:"correct"`
+    UserID         string    `dynamodbav:"user_id"`
+    QuestionID     string    `dynamodbav:"question_id"`
+    SelectedIndex  int32     `dynamodbav:"selected_index"`
+    SubmittedAt    time.Time `dynamodbav:"submitted_at"`
+    Correct        bool      `dynamodbav:"correct"`
+    PointsAwarded  int64     `dynamodbav:"points_awarded"`
+    UpdatedPoints  int64     `dynamodbav:"updated_points"`
+    IdempotencyKey string    `dynamodbav:"idempotency_key,omitempty"`
 }
@@ -1,7 +1,36 @@
 package models
 
+import "time"
+
+// Award is a reward players can buy with points. RemainingStock counts the
+// units left out of TotalStock. A zero PerUserLimit means no per-user cap,
+// and a zero AvailableFrom or AvailableUntil leaves that end of the window
+// open. Retired awards have Active unset and can no longer be claimed.
+// Digital awards hand out a code from their voucher pool and take their
+// stock from it.
 type Award struct {
-    ID        string `dynamodbav:"award_id"`
-    Product   string `dynamodbav:"product"`
-    PointCost int64  `dynamodbav:"point_cost"`
+    ID             string    `dynamodbav:"award_id"`
+    Product        string    `dynamodbav:"product"`
+    PointCost      int64     `dynamodbav:"point_cost"`
+    TotalStock     int64     `dynamodbav:"total_stock"`
+    RemainingStock int64     `dynamodbav:"remaining_stock"`
+    PerUserLimit   int32     `dynamodbav:"per_user_limit"`
+    AvailableFrom  time.Time `dynamodbav:"available_from"`
+    AvailableUntil time.Time `dynamodbav:"available_until"`
+    Active         bool      `dynamodbav:"active"`
+    Digital        bool      `dynamodbav:"digital"`
+}
+
+// AvailableAt reports whether the award can be claimed at t, ignoring stock.
+func (a *Award) AvailableAt(t time.Time) bool {
+    if !a.Active {
+        return false
+    }
+    if !a.AvailableFrom.IsZero() && t.Before(a.AvailableFrom) {
+        return false
+    }
+    if !a.AvailableUntil.IsZero() && !t.Before(a.AvailableUntil) {
+        return false
+    }
+    return true
 }
@@ -2,9 +2,52 @@ package models
 
 import "time"
 
+type ClaimStatus string
+
+const (
+    ClaimPending   ClaimStatus = "pending"
+    ClaimApproved  ClaimStatus = "approved"
+    ClaimFulfilled ClaimStatus = "fulfilled"
+    ClaimRejected  ClaimStatus = "rejected"
+    ClaimRefunded  ClaimStatus = "refunded"
+)
+
+// claimTransitions lists the statuses each status may move to. A rejected
+// claim becomes refunded once its points have been returned.
+var claimTransitions = map[ClaimStatus][]ClaimStatus{
+    ClaimPending:  {ClaimApproved, ClaimRejected},
+    ClaimApproved: {ClaimFulfilled, ClaimRejected},
+    ClaimRejected: {ClaimRefunded},
+}
+
+func (s ClaimStatus) Valid() bool {
+    switch s {
+    case ClaimPending, ClaimApproved, ClaimFulfilled, ClaimRejected, ClaimRefunded:
+        return true
+    }
+    return false
+}
+
+func (s ClaimStatus) CanMoveTo(next ClaimStatus) bool {
+    for _, allowed := range claimTransitions[s] {
+        if allowed == next {
+            return true
+        }
+    }
+    return false
+}
+
+// Claim records a player buying an award. Note holds the admin's reason for
+// the latest status change, such as a rejection reason or tracking number.
+// VoucherCode is the code handed out for a digital award.
 type Claim struct {
-    UserID   string    `dynamodbav:"user_id"`
-    AwardID  string    `dynamodbav:"award_id"`
-    Points   int64     `dynamodbav:"points"`
-    ClaimedAt time.Time `dynamodbav:"claimed_at"`
+    ID          string      `dynamodbav:"claim_id"`
+    UserID      string      `dynamodbav:"user_id"`
+    AwardID     string      `dynamodbav:"award_id"`
+    Points      int64       `dynamodbav:"points"`
+    ClaimedAt   time.Time   `dynamodbav:"claimed_at"`
+    Status      ClaimStatus `dynamodbav:"status"`
+    Note        string      `dynamodbav:"note"`
+    UpdatedAt   time.Time   `dynamodbav:"updated_at"`
+    VoucherCode string      `dynamodbav:"voucher_code"`
 }
@@ -0,0 +1,51 @@
+package models
+
+import "time"
+
+type LedgerKind string
+
+const (
+    LedgerCredit LedgerKind = "credit"
+    LedgerDebit  LedgerKind = "debit"
+)
+
+type LedgerReason string
+
+const (
+    ReasonCorrectAnswer LedgerReason = "correct_answer"
+    ReasonAwardClaim    LedgerReason = "award_claim"
+    ReasonClaimRefund   LedgerReason = "claim_refund"
+)
+
+// LedgerEntry is one immutable change to a user's points. Entries are the
+// source of truth for balances; Seq orders a user's entries and Balance is
+// the running total after this entry was applied.
+type LedgerEntry struct {
+    ID        string       `dynamodbav:"entry_id"`
+    UserID    string       `dynamodbav:"user_id"`
+    Seq       int64        `dynamodbav:"seq"`
+    Kind      LedgerKind   `dynamodbav:"kind"`
+    Amount    int64        `dynamodbav:"amount"`
+    Reason    LedgerReason `dynamodbav:"reason"`
+    RefType   string       `dynamodbav:"ref_type"`
+    RefID     string       `dynamodbav:"ref_id"`
+    Balance   int64        `dynamodbav:"balance"`
+    CreatedAt time.Time    `dynamodbav:"created_at"`
+}
+
+// Signed returns the amount as it applies to the balance.
+func (e *LedgerEntry) Signed() int64 {
+    if e.Kind == LedgerDebit {
+        return -e.Amount
+    }
+    return e.Amount
+}
+
+// BalanceSnapshot caches a user's balance as of entry Seq so it can be
+// derived without replaying the whole ledger.
+type BalanceSnapshot struct {
+    UserID  string    `dynamodbav:"user_id"`
+    Seq     int64     `dynamodbav:"seq"`
+    Balance int64     `dynamodbav:"balance"`
+    TakenAt time.Time `dynamodbav:"taken_at"`
+}
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// OTP is the outstanding code for a phone. Attempts counts verifications
+// since WindowStart, when the first code of the current window was issued;
+// codes reissued within the window keep the count so a new code does not
+// buy more guesses.
+type OTP struct {
+    Phone       string    `dynamodbav:"phone"`
+    CodeHash    string    `dynamodbav:"code_hash"`
+    Attempts    int32     `dynamodbav:"attempts"`
+    ExpiresAt   time.Time `dynamodbav:"expires_at"`
+    CreatedAt   time.Time `dynamodbav:"created_at"`
+    WindowStart time.Time `dynamodbav:"window_start"`
+}
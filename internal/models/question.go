@@ -8,3 +8,12 @@ type Question struct {
     Slot         int32    `dynamodbav:"slot"`
     CreatedBy    string   `dynamodbav:"created_by"`
 }
+
+// PlayerQuestion is what players see: no answer, no author, and options
+// possibly in a per-player order.
+type PlayerQuestion struct {
+    ID      string
+    Text    string
+    Options []string
+    Slot    int32
+}
@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// Session is one logged-in device. All refresh tokens minted for it form a
+// single rotation family, so revoking the session revokes every token.
+type Session struct {
+    ID         string    `dynamodbav:"session_id"`
+    UserID     string    `dynamodbav:"user_id"`
+    UserAgent  string    `dynamodbav:"user_agent"`
+    CreatedAt  time.Time `dynamodbav:"created_at"`
+    LastUsedAt time.Time `dynamodbav:"last_used_at"`
+    ExpiresAt  time.Time `dynamodbav:"expires_at"`
+    Revoked    bool      `dynamodbav:"revoked"`
+}
+
+type RefreshToken struct {
+    Hash      string    `dynamodbav:"token_hash"`
+    SessionID string    `dynamodbav:"session_id"`
+    UserID    string    `dynamodbav:"user_id"`
+    IssuedAt  time.Time `dynamodbav:"issued_at"`
+    ExpiresAt time.Time `dynamodbav:"expires_at"`
+    Rotated   bool      `dynamodbav:"rotated"`
+}
@@ -1,5 +1,26 @@
 package models
 
+type Role string
+
+const (
+    RolePlayer  Role = "player"
+    RoleEditor  Role = "editor"
+    RoleAdmin   Role = "admin"
+    RoleSupport Role = "support"
+)
+
+func (r Role) Valid() bool {
+    switch r {
+    case RolePlayer, RoleEditor, RoleAdmin, RoleSupport:
+        return true
+    }
+    return false
+}
+
+// User.Version is bumped by every write so concurrent updates can be
+// detected instead of silently overwriting each other. User.Points is not
+// stored; it is the points ledger balance, filled in when the user is read
+// through UserService.
 type User struct {
     ID        string `dynamodbav:"user_id"`
     Name      string `dynamodbav:"name"`
@@ -7,5 +28,16 @@ type User struct {
     Email     string `dynamodbav:"email"`
     Verified  bool   `dynamodbav:"verified"`
     Blocked   bool   `dynamodbav:"blocked"`
-    Points    int64  `dynamodbav:"points"`
+    Points    int64  `dynamodbav:"-"`
+    Roles     []Role `dynamodbav:"roles"`
+    Version   int64  `dynamodbav:"version"`
+}
+
+func (u *User) HasRole(r Role) bool {
+    for _, role := range u.Roles {
+        if role == r {
+            return true
+        }
+    }
+    return false
 }
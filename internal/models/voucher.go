@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// Voucher is one code in an award's pool. ClaimID is empty until the code
+// is handed out.
+type Voucher struct {
+    AwardID    string    `dynamodbav:"award_id"`
+    Code       string    `dynamodbav:"code"`
+    ClaimID    string    `dynamodbav:"claim_id,omitempty"`
+    UserID     string    `dynamodbav:"user_id,omitempty"`
+    UploadedAt time.Time `dynamodbav:"uploaded_at"`
+    AssignedAt time.Time `dynamodbav:"assigned_at"`
+}
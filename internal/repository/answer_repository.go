@@ -0,0 +1,19 @@
+package repository
+
+import (
+    "context"
+
+    "github.com/rprajapati0067/quiz-game-backend/internal/models"
+)
+
+var ErrAnswerExists = conflict("answer already recorded")
+
+type AnswerRepository interface {
+    // Create stores a, failing with ErrAnswerExists if the user already
+    // answered the question.
+    Create(ctx context.Context, a *models.Answer) error
+    Update(ctx context.Context, a *models.Answer) error
+    GetByUserAndQuestion(ctx context.Context, userID, questionID string) (*models.Answer, error)
+    GetByIdempotencyKey(ctx context.Context, userID, key string) (*models.Answer, error)
+    ListByUser(ctx context.Context, userID string) ([]*models.Answer, error)
+}
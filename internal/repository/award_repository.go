@@ -2,12 +2,57 @@ package repository
 
 import (
     "context"
+    "errors"
+    "time"
 
     "github.com/rprajapati0067/quiz-game-backend/internal/models"
 )
 
+var (
+    ErrOutOfStock         = errors.New("award out of stock")
+    ErrClaimLimitReached  = errors.New("per-user claim limit reached")
+    ErrNoReservation      = errors.New("no reservation to release")
+    ErrClaimStatusChanged = conflict("claim status changed concurrently")
+)
+
+// AwardRepository reports a missing award or claim with an error matching
+// ErrNotFound and a duplicate ID with one matching ErrConflict.
 type AwardRepository interface {
     List(ctx context.Context) ([]*models.Award, error)
     GetByID(ctx context.Context, id string) (*models.Award, error)
+    Create(ctx context.Context, a *models.Award) error
+    // Update saves the product, cost, per-user limit and availability
+    // window. The stock counters only change through Reserve, Release and
+    // Restock, Active only through Retire, and Digital never, so an update
+    // made from a stale read cannot undo them.
+    Update(ctx context.Context, a *models.Award) error
+    // Restock adds quantity units to both the total and remaining stock.
+    Restock(ctx context.Context, id string, quantity int64) (*models.Award, error)
+    // Retire clears Active for good and returns the award. Retiring a
+    // retired award changes nothing.
+    Retire(ctx context.Context, id string) (*models.Award, error)
+    // Reserve atomically takes one unit of stock for userID, failing with
+    // ErrOutOfStock or ErrClaimLimitReached. Every reservation must end in
+    // CreateClaim or be undone with Release.
+    Reserve(ctx context.Context, awardID, userID string) error
+    // PlaceClaim reserves a unit of the claim's award for its user, appends
+    // debit to the user's ledger and stores the claim in one transaction,
+    // so a claim is either paid for and holding its stock or not there at
+    // all. It fails like Reserve, with ErrInsufficientBalance, or with an
+    // error matching ErrConflict if the claim or the debit already exists.
+    // On success debit's Seq and Balance are filled in.
+    PlaceClaim(ctx context.Context, c *models.Claim, debit *models.LedgerEntry) error
+    // Release gives back one unit reserved by userID, failing with
+    // ErrNoReservation if the user holds none, so a repeated Release cannot
+    // add stock that was never taken.
+    Release(ctx context.Context, awardID, userID string) error
     CreateClaim(ctx context.Context, c *models.Claim) error
+    GetClaim(ctx context.Context, id string) (*models.Claim, error)
+    // ListClaimsByUser returns the user's claims, newest first.
+    ListClaimsByUser(ctx context.Context, userID string) ([]*models.Claim, error)
+    // ListClaimsByStatus returns claims in the given status, oldest first.
+    ListClaimsByStatus(ctx context.Context, status models.ClaimStatus) ([]*models.Claim, error)
+    // SetClaimStatus moves a claim from status from to status to, failing
+    // with ErrClaimStatusChanged if it is no longer in from.
+    SetClaimStatus(ctx context.Context, id string, from, to models.ClaimStatus, note string, at time.Time) (*models.Claim, error)
 }
@@ -0,0 +1,307 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// DynamoTables names the tables used by the DynamoDB repositories.
+type DynamoTables struct {
+	Users         string
+	Questions     string
+	Answers       string
+	Awards        string
+	AwardHolds    string
+	Claims        string
+	Ledger        string
+	LedgerIDs     string
+	Balances      string
+	Vouchers      string
+	Sessions      string
+	RefreshTokens string
+	OTPs          string
+}
+
+// DefaultDynamoTables returns the standard table names, each starting with
+// prefix so several environments can share an account.
+func DefaultDynamoTables(prefix string) DynamoTables {
+	return DynamoTables{
+		Users:         prefix + "users",
+		Questions:     prefix + "questions",
+		Answers:       prefix + "answers",
+		Awards:        prefix + "awards",
+		AwardHolds:    prefix + "award_holds",
+		Claims:        prefix + "claims",
+		Ledger:        prefix + "ledger",
+		LedgerIDs:     prefix + "ledger_ids",
+		Balances:      prefix + "balances",
+		Vouchers:      prefix + "vouchers",
+		Sessions:      prefix + "sessions",
+		RefreshTokens: prefix + "refresh_tokens",
+		OTPs:          prefix + "otps",
+	}
+}
+
+const dynamoTableWait = 2 * time.Minute
+
+// Secondary indexes queried by the repositories.
+const (
+	phoneIndex       = "phone-index"
+	slotIndex        = "slot-index"
+	idempotencyIndex = "idempotency-index"
+	userIndex        = "user-index"
+	statusIndex      = "status-index"
+	freeIndex        = "free-index"
+	claimIndex       = "claim-index"
+)
+
+type dynamoKey struct {
+	name string
+	typ  types.ScalarAttributeType
+}
+
+type dynamoTableSpec struct {
+	name    string
+	hash    dynamoKey
+	rng     *dynamoKey
+	indexes map[string]dynamoIndexSpec
+}
+
+type dynamoIndexSpec struct {
+	hash dynamoKey
+	rng  *dynamoKey
+}
+
+func (t DynamoTables) specs() []dynamoTableSpec {
+	str := func(name string) dynamoKey { return dynamoKey{name, types.ScalarAttributeTypeS} }
+	num := func(name string) *dynamoKey { return &dynamoKey{name, types.ScalarAttributeTypeN} }
+	strp := func(name string) *dynamoKey { k := str(name); return &k }
+
+	return []dynamoTableSpec{
+		{name: t.Users, hash: str("user_id"), indexes: map[string]dynamoIndexSpec{
+			phoneIndex: {hash: str("phone")},
+		}},
+		{name: t.Questions, hash: str("question_id"), indexes: map[string]dynamoIndexSpec{
+			slotIndex: {hash: dynamoKey{"slot", types.ScalarAttributeTypeN}},
+		}},
+		{name: t.Answers, hash: str("user_id"), rng: strp("question_id"), indexes: map[string]dynamoIndexSpec{
+			idempotencyIndex: {hash: str("user_id"), rng: strp("idempotency_key")},
+		}},
+		{name: t.Awards, hash: str("award_id")},
+		{name: t.AwardHolds, hash: str("award_id"), rng: strp("user_id")},
+		{name: t.Claims, hash: str("claim_id"), indexes: map[string]dynamoIndexSpec{
+			userIndex:   {hash: str("user_id")},
+			statusIndex: {hash: str("status")},
+		}},
+		{name: t.Ledger, hash: str("user_id"), rng: num("seq")},
+		{name: t.LedgerIDs, hash: str("user_id"), rng: strp("entry_id")},
+		{name: t.Balances, hash: str("user_id")},
+		{name: t.Vouchers, hash: str("award_id"), rng: strp("code"), indexes: map[string]dynamoIndexSpec{
+			freeIndex:  {hash: str("free_award_id")},
+			claimIndex: {hash: str("claim_id")},
+		}},
+		{name: t.Sessions, hash: str("session_id"), indexes: map[string]dynamoIndexSpec{
+			userIndex: {hash: str("user_id")},
+		}},
+		{name: t.RefreshTokens, hash: str("token_hash")},
+		{name: t.OTPs, hash: str("phone")},
+	}
+}
+
+// CreateDynamoTables creates any missing table with on-demand billing. It is
+// meant for DynamoDB Local and first-time setup; production tables are
+// usually managed by infrastructure code.
+func CreateDynamoTables(ctx context.Context, client *dynamodb.Client, tables DynamoTables) error {
+	for _, spec := range tables.specs() {
+		_, err := client.DescribeTable(ctx, &dynamodb.DescribeTableInput{TableName: aws.String(spec.name)})
+		if err == nil {
+			continue
+		}
+		var notFound *types.ResourceNotFoundException
+		if !errors.As(err, &notFound) {
+			return err
+		}
+
+		attrs := map[string]types.ScalarAttributeType{}
+		schema := func(hash dynamoKey, rng *dynamoKey) []types.KeySchemaElement {
+			attrs[hash.name] = hash.typ
+			keys := []types.KeySchemaElement{{AttributeName: aws.String(hash.name), KeyType: types.KeyTypeHash}}
+			if rng != nil {
+				attrs[rng.name] = rng.typ
+				keys = append(keys, types.KeySchemaElement{AttributeName: aws.String(rng.name), KeyType: types.KeyTypeRange})
+			}
+			return keys
+		}
+
+		input := &dynamodb.CreateTableInput{
+			TableName:   aws.String(spec.name),
+			BillingMode: types.BillingModePayPerRequest,
+			KeySchema:   schema(spec.hash, spec.rng),
+		}
+		for name, index := range spec.indexes {
+			input.GlobalSecondaryIndexes = append(input.GlobalSecondaryIndexes, types.GlobalSecondaryIndex{
+				IndexName:  aws.String(name),
+				KeySchema:  schema(index.hash, index.rng),
+				Projection: &types.Projection{ProjectionType: types.ProjectionTypeAll},
+			})
+		}
+		for name, typ := range attrs {
+			input.AttributeDefinitions = append(input.AttributeDefinitions, types.AttributeDefinition{
+				AttributeName: aws.String(name),
+				AttributeType: typ,
+			})
+		}
+
+		if _, err := client.CreateTable(ctx, input); err != nil {
+			return err
+		}
+		waiter := dynamodb.NewTableExistsWaiter(client)
+		if err := waiter.Wait(ctx, &dynamodb.DescribeTableInput{TableName: aws.String(spec.name)}, dynamoTableWait); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func isConditionFailed(err error) bool {
+	var ccf *types.ConditionalCheckFailedException
+	return errors.As(err, &ccf)
+}
+
+// canceledAt reports whether a transaction failed because the condition on
+// its i-th item did not hold.
+func canceledAt(err error, i int) bool {
+	var tce *types.TransactionCanceledException
+	if !errors.As(err, &tce) || i >= len(tce.CancellationReasons) {
+		return false
+	}
+	return aws.ToString(tce.CancellationReasons[i].Code) == "ConditionalCheckFailed"
+}
+
+// transactAttempts bounds how often transactWrite retries a transaction
+// that DynamoDB cancelled for colliding with another one.
+const transactAttempts = 5
+
+// transactWrite runs items as one transaction. A transaction cancelled only
+// because another one in flight touched the same items is retried after a
+// pause; a failed condition is returned at once for the caller to inspect
+// with canceledAt.
+func transactWrite(ctx context.Context, client *dynamodb.Client, items []types.TransactWriteItem) error {
+	for attempt := 1; ; attempt++ {
+		_, err := client.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{TransactItems: items})
+		if !onlyConflicted(err) || attempt == transactAttempts {
+			return err
+		}
+		if err := pause(ctx, attempt); err != nil {
+			return err
+		}
+	}
+}
+
+// onlyConflicted reports whether a transaction was cancelled for colliding
+// with another one rather than for a failed condition.
+func onlyConflicted(err error) bool {
+	var tce *types.TransactionCanceledException
+	if !errors.As(err, &tce) {
+		return false
+	}
+	conflicted := false
+	for _, reason := range tce.CancellationReasons {
+		switch aws.ToString(reason.Code) {
+		case "ConditionalCheckFailed":
+			return false
+		case "TransactionConflict":
+			conflicted = true
+		}
+	}
+	return conflicted
+}
+
+// pause waits up to attempt*10ms, at random, before a retry so writers that
+// collided do not collide again in lockstep.
+func pause(ctx context.Context, attempt int) error {
+	t := time.NewTimer(rand.N(time.Duration(attempt) * 10 * time.Millisecond))
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+func isTransactionConflict(err error) bool {
+	var tce *types.TransactionCanceledException
+	if !errors.As(err, &tce) {
+		return false
+	}
+	for _, reason := range tce.CancellationReasons {
+		code := aws.ToString(reason.Code)
+		if code == "ConditionalCheckFailed" || code == "TransactionConflict" {
+			return true
+		}
+	}
+	return false
+}
+
+// notExists is the condition for inserting an item keyed by attr.
+func notExists(attr string) (expression.Expression, error) {
+	return expression.NewBuilder().WithCondition(expression.AttributeNotExists(expression.Name(attr))).Build()
+}
+
+// exists is the condition for overwriting an item keyed by attr.
+func exists(attr string) (expression.Expression, error) {
+	return expression.NewBuilder().WithCondition(expression.AttributeExists(expression.Name(attr))).Build()
+}
+
+// keyEquals builds a query for the items whose attr equals value.
+func keyEquals(attr string, value interface{}) (expression.Expression, error) {
+	return expression.NewBuilder().WithKeyCondition(expression.Key(attr).Equal(expression.Value(value))).Build()
+}
+
+func stringKey(name, value string) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{name: &types.AttributeValueMemberS{Value: value}}
+}
+
+func attrS(v string) types.AttributeValue {
+	return &types.AttributeValueMemberS{Value: v}
+}
+
+// getItem loads the item at key into out and reports whether it existed.
+func getItem(ctx context.Context, client *dynamodb.Client, table string, key map[string]types.AttributeValue, out interface{}) (bool, error) {
+	res, err := client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName:      aws.String(table),
+		Key:            key,
+		ConsistentRead: aws.Bool(true),
+	})
+	if err != nil {
+		return false, err
+	}
+	if res.Item == nil {
+		return false, nil
+	}
+	return true, attributevalue.UnmarshalMap(res.Item, out)
+}
+
+// queryAll runs input to completion, following pagination, and returns
+// every item.
+func queryAll(ctx context.Context, client *dynamodb.Client, input *dynamodb.QueryInput) ([]map[string]types.AttributeValue, error) {
+	var items []map[string]types.AttributeValue
+	paginator := dynamodb.NewQueryPaginator(client, input)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, page.Items...)
+	}
+	return items, nil
+}
@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"context"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"github.com/rprajapati0067/quiz-game-backend/internal/models"
+)
+
+// DynamoAnswerRepository keys answers by user and question, which makes the
+// one-answer-per-question rule a conditional put.
+type DynamoAnswerRepository struct {
+	client *dynamodb.Client
+	table  string
+}
+
+func NewDynamoAnswerRepository(client *dynamodb.Client, table string) *DynamoAnswerRepository {
+	return &DynamoAnswerRepository{client: client, table: table}
+}
+
+func (r *DynamoAnswerRepository) Create(ctx context.Context, a *models.Answer) error {
+	cond, err := notExists("user_id")
+	if err != nil {
+		return err
+	}
+	err = r.put(ctx, a, cond)
+	if isConditionFailed(err) {
+		return ErrAnswerExists
+	}
+	return err
+}
+
+func (r *DynamoAnswerRepository) Update(ctx context.Context, a *models.Answer) error {
+	cond, err := exists("user_id")
+	if err != nil {
+		return err
+	}
+	err = r.put(ctx, a, cond)
+	if isConditionFailed(err) {
+		return errAnswerNotFound
+	}
+	return err
+}
+
+func (r *DynamoAnswerRepository) put(ctx context.Context, a *models.Answer, cond expression.Expression) error {
+	item, err := attributevalue.MarshalMap(a)
+	if err != nil {
+		return err
+	}
+	_, err = r.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:                aws.String(r.table),
+		Item:                     item,
+		ConditionExpression:      cond.Condition(),
+		ExpressionAttributeNames: cond.Names(),
+	})
+	return err
+}
+
+func (r *DynamoAnswerRepository) GetByUserAndQuestion(ctx context.Context, userID, questionID string) (*models.Answer, error) {
+	key := map[string]types.AttributeValue{
+		"user_id":     attrS(userID),
+		"question_id": attrS(questionID),
+	}
+	var a models.Answer
+	found, err := getItem(ctx, r.client, r.table, key, &a)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &a, nil
+}
+
+// GetByIdempotencyKey reads an eventually consistent index. A retry that
+// misses a just-written answer still collides with it in Create, where
+// SubmitAnswer detects the replay.
+func (r *DynamoAnswerRepository) GetByIdempotencyKey(ctx context.Context, userID, key string) (*models.Answer, error) {
+	expr, err := expression.NewBuilder().WithKeyCondition(expression.KeyAnd(
+		expression.Key("user_id").Equal(expression.Value(userID)),
+		expression.Key("idempotency_key").Equal(expression.Value(key)),
+	)).Build()
+	if err != nil {
+		return nil, err
+	}
+	res, err := r.client.Query(ctx, &dynamodb.QueryInput{
+		TableName:                 aws.String(r.table),
+		IndexName:                 aws.String(idempotencyIndex),
+		KeyConditionExpression:    expr.KeyCondition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		Limit:                     aws.Int32(1),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(res.Items) == 0 {
+		return nil, nil
+	}
+	var a models.Answer
+	if err := attributevalue.UnmarshalMap(res.Items[0], &a); err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+func (r *DynamoAnswerRepository) ListByUser(ctx context.Context, userID string) ([]*models.Answer, error) {
+	expr, err := keyEquals("user_id", userID)
+	if err != nil {
+		return nil, err
+	}
+	items, err := queryAll(ctx, r.client, &dynamodb.QueryInput{
+		TableName:                 aws.String(r.table),
+		KeyConditionExpression:    expr.KeyCondition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		ConsistentRead:            aws.Bool(true),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*models.Answer
+	if err := attributevalue.UnmarshalListOfMaps(items, &result); err != nil {
+		return nil, err
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].SubmittedAt.Before(result[j].SubmittedAt)
+	})
+	return result, nil
+}
@@ -0,0 +1,473 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"github.com/rprajapati0067/quiz-game-backend/internal/models"
+)
+
+// DynamoAwardRepository stores awards, claims and, in a third table, how
+// many units of each award every user holds. Reserve updates the stock and
+// the holding in one transaction; PlaceClaim adds the claim and the debit
+// written to ledger to it.
+type DynamoAwardRepository struct {
+	client *dynamodb.Client
+	awards string
+	holds  string
+	claims string
+	ledger *DynamoLedgerRepository
+}
+
+func NewDynamoAwardRepository(client *dynamodb.Client, awards, holds, claims string, ledger *DynamoLedgerRepository) *DynamoAwardRepository {
+	return &DynamoAwardRepository{client: client, awards: awards, holds: holds, claims: claims, ledger: ledger}
+}
+
+func (r *DynamoAwardRepository) List(ctx context.Context) ([]*models.Award, error) {
+	var items []map[string]types.AttributeValue
+	paginator := dynamodb.NewScanPaginator(r.client, &dynamodb.ScanInput{
+		TableName:      aws.String(r.awards),
+		ConsistentRead: aws.Bool(true),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, page.Items...)
+	}
+
+	var result []*models.Award
+	if err := attributevalue.UnmarshalListOfMaps(items, &result); err != nil {
+		return nil, err
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].PointCost < result[j].PointCost
+	})
+	return result, nil
+}
+
+func (r *DynamoAwardRepository) GetByID(ctx context.Context, id string) (*models.Award, error) {
+	var a models.Award
+	found, err := getItem(ctx, r.client, r.awards, stringKey("award_id", id), &a)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errAwardNotFound
+	}
+	return &a, nil
+}
+
+func (r *DynamoAwardRepository) Create(ctx context.Context, a *models.Award) error {
+	item, err := attributevalue.MarshalMap(a)
+	if err != nil {
+		return err
+	}
+	cond, err := notExists("award_id")
+	if err != nil {
+		return err
+	}
+	_, err = r.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:                aws.String(r.awards),
+		Item:                     item,
+		ConditionExpression:      cond.Condition(),
+		ExpressionAttributeNames: cond.Names(),
+	})
+	if isConditionFailed(err) {
+		return errAwardExists
+	}
+	return err
+}
+
+func (r *DynamoAwardRepository) Update(ctx context.Context, a *models.Award) error {
+	update := expression.
+		Set(expression.Name("product"), expression.Value(a.Product)).
+		Set(expression.Name("point_cost"), expression.Value(a.PointCost)).
+		Set(expression.Name("per_user_limit"), expression.Value(a.PerUserLimit)).
+		Set(expression.Name("available_from"), expression.Value(a.AvailableFrom)).
+		Set(expression.Name("available_until"), expression.Value(a.AvailableUntil))
+	expr, err := expression.NewBuilder().
+		WithUpdate(update).
+		WithCondition(expression.AttributeExists(expression.Name("award_id"))).
+		Build()
+	if err != nil {
+		return err
+	}
+	_, err = r.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName:                 aws.String(r.awards),
+		Key:                       stringKey("award_id", a.ID),
+		UpdateExpression:          expr.Update(),
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	})
+	if isConditionFailed(err) {
+		return errAwardNotFound
+	}
+	return err
+}
+
+func (r *DynamoAwardRepository) Restock(ctx context.Context, id string, quantity int64) (*models.Award, error) {
+	update := expression.
+		Add(expression.Name("total_stock"), expression.Value(quantity)).
+		Add(expression.Name("remaining_stock"), expression.Value(quantity))
+	expr, err := expression.NewBuilder().
+		WithUpdate(update).
+		WithCondition(expression.AttributeExists(expression.Name("award_id"))).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+	res, err := r.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName:                 aws.String(r.awards),
+		Key:                       stringKey("award_id", id),
+		UpdateExpression:          expr.Update(),
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		ReturnValues:              types.ReturnValueAllNew,
+	})
+	if isConditionFailed(err) {
+		return nil, errAwardNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	var a models.Award
+	if err := attributevalue.UnmarshalMap(res.Attributes, &a); err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+func (r *DynamoAwardRepository) Retire(ctx context.Context, id string) (*models.Award, error) {
+	update := expression.Set(expression.Name("active"), expression.Value(false))
+	expr, err := expression.NewBuilder().
+		WithUpdate(update).
+		WithCondition(expression.AttributeExists(expression.Name("award_id"))).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+	res, err := r.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName:                 aws.String(r.awards),
+		Key:                       stringKey("award_id", id),
+		UpdateExpression:          expr.Update(),
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		ReturnValues:              types.ReturnValueAllNew,
+	})
+	if isConditionFailed(err) {
+		return nil, errAwardNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	var a models.Award
+	if err := attributevalue.UnmarshalMap(res.Attributes, &a); err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+func (r *DynamoAwardRepository) Reserve(ctx context.Context, awardID, userID string) error {
+	items, err := r.reserveItems(ctx, awardID, userID)
+	if err != nil {
+		return err
+	}
+	err = transactWrite(ctx, r.client, items)
+	switch {
+	case canceledAt(err, 0):
+		return ErrOutOfStock
+	case canceledAt(err, 1):
+		return ErrClaimLimitReached
+	}
+	return err
+}
+
+// reserveItems returns the writes that take a unit of the award for
+// userID: the stock update, conditioned on stock being left, followed by
+// the holding update, conditioned on the per-user limit.
+func (r *DynamoAwardRepository) reserveItems(ctx context.Context, awardID, userID string) ([]types.TransactWriteItem, error) {
+	award, err := r.GetByID(ctx, awardID)
+	if err != nil {
+		return nil, err
+	}
+
+	take, err := expression.NewBuilder().
+		WithUpdate(expression.Add(expression.Name("remaining_stock"), expression.Value(-1))).
+		WithCondition(expression.Name("remaining_stock").GreaterThan(expression.Value(0))).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+	holdBuilder := expression.NewBuilder().
+		WithUpdate(expression.Add(expression.Name("held"), expression.Value(1)))
+	if award.PerUserLimit > 0 {
+		holdBuilder = holdBuilder.WithCondition(expression.Or(
+			expression.AttributeNotExists(expression.Name("held")),
+			expression.Name("held").LessThan(expression.Value(award.PerUserLimit)),
+		))
+	}
+	hold, err := holdBuilder.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	return []types.TransactWriteItem{
+		{Update: &types.Update{
+			TableName:                 aws.String(r.awards),
+			Key:                       stringKey("award_id", awardID),
+			UpdateExpression:          take.Update(),
+			ConditionExpression:       take.Condition(),
+			ExpressionAttributeNames:  take.Names(),
+			ExpressionAttributeValues: take.Values(),
+		}},
+		{Update: &types.Update{
+			TableName:                 aws.String(r.holds),
+			Key:                       r.holdKey(awardID, userID),
+			UpdateExpression:          hold.Update(),
+			ConditionExpression:       hold.Condition(),
+			ExpressionAttributeNames:  hold.Names(),
+			ExpressionAttributeValues: hold.Values(),
+		}},
+	}, nil
+}
+
+func (r *DynamoAwardRepository) PlaceClaim(ctx context.Context, c *models.Claim, debit *models.LedgerEntry) error {
+	for attempt := 1; ; attempt++ {
+		err := r.placeClaim(ctx, c, debit)
+		if err == nil || !isTransactionConflict(err) || attempt == ledgerAppendAttempts {
+			return err
+		}
+		if err := pause(ctx, attempt); err != nil {
+			return err
+		}
+	}
+}
+
+// placeClaim writes the reservation, the claim and the debit in one
+// transaction. A failed condition on the balance snapshot or the entry
+// means another append got in first and is returned as is for PlaceClaim
+// to retry; every other failed condition is reported as what it means.
+func (r *DynamoAwardRepository) placeClaim(ctx context.Context, c *models.Claim, debit *models.LedgerEntry) error {
+	items, err := r.reserveItems(ctx, c.AwardID, c.UserID)
+	if err != nil {
+		return err
+	}
+	claimItem, err := attributevalue.MarshalMap(c)
+	if err != nil {
+		return err
+	}
+	claimCond, err := notExists("claim_id")
+	if err != nil {
+		return err
+	}
+	items = append(items, types.TransactWriteItem{Put: &types.Put{
+		TableName:                aws.String(r.claims),
+		Item:                     claimItem,
+		ConditionExpression:      claimCond.Condition(),
+		ExpressionAttributeNames: claimCond.Names(),
+	}})
+	ledgerItems, entry, err := r.ledger.appendItems(ctx, debit)
+	if err != nil {
+		return err
+	}
+	ledgerAt := len(items)
+	items = append(items, ledgerItems...)
+
+	err = transactWrite(ctx, r.client, items)
+	switch {
+	case canceledAt(err, 0):
+		return ErrOutOfStock
+	case canceledAt(err, 1):
+		return ErrClaimLimitReached
+	case canceledAt(err, 2):
+		return errClaimExists
+	case canceledAt(err, ledgerAt+ledgerIDItemIndex):
+		if recorded, getErr := r.ledger.recorded(ctx, debit); recorded || getErr != nil {
+			return getErr
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	debit.Seq = entry.Seq
+	debit.Balance = entry.Balance
+	return nil
+}
+
+func (r *DynamoAwardRepository) Release(ctx context.Context, awardID, userID string) error {
+	restore, err := expression.NewBuilder().
+		WithUpdate(expression.Add(expression.Name("remaining_stock"), expression.Value(1))).
+		WithCondition(expression.AttributeExists(expression.Name("award_id"))).
+		Build()
+	if err != nil {
+		return err
+	}
+	unhold, err := expression.NewBuilder().
+		WithUpdate(expression.Add(expression.Name("held"), expression.Value(-1))).
+		WithCondition(expression.Name("held").GreaterThan(expression.Value(0))).
+		Build()
+	if err != nil {
+		return err
+	}
+
+	err = transactWrite(ctx, r.client, []types.TransactWriteItem{
+		{Update: &types.Update{
+			TableName:                 aws.String(r.awards),
+			Key:                       stringKey("award_id", awardID),
+			UpdateExpression:          restore.Update(),
+			ConditionExpression:       restore.Condition(),
+			ExpressionAttributeNames:  restore.Names(),
+			ExpressionAttributeValues: restore.Values(),
+		}},
+		{Update: &types.Update{
+			TableName:                 aws.String(r.holds),
+			Key:                       r.holdKey(awardID, userID),
+			UpdateExpression:          unhold.Update(),
+			ConditionExpression:       unhold.Condition(),
+			ExpressionAttributeNames:  unhold.Names(),
+			ExpressionAttributeValues: unhold.Values(),
+		}},
+	})
+	switch {
+	case canceledAt(err, 0):
+		return errAwardNotFound
+	case canceledAt(err, 1):
+		return ErrNoReservation
+	}
+	return err
+}
+
+func (r *DynamoAwardRepository) holdKey(awardID, userID string) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{
+		"award_id": attrS(awardID),
+		"user_id":  attrS(userID),
+	}
+}
+
+func (r *DynamoAwardRepository) CreateClaim(ctx context.Context, c *models.Claim) error {
+	item, err := attributevalue.MarshalMap(c)
+	if err != nil {
+		return err
+	}
+	cond, err := notExists("claim_id")
+	if err != nil {
+		return err
+	}
+	_, err = r.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:                aws.String(r.claims),
+		Item:                     item,
+		ConditionExpression:      cond.Condition(),
+		ExpressionAttributeNames: cond.Names(),
+	})
+	if isConditionFailed(err) {
+		return errClaimExists
+	}
+	return err
+}
+
+func (r *DynamoAwardRepository) GetClaim(ctx context.Context, id string) (*models.Claim, error) {
+	var c models.Claim
+	found, err := getItem(ctx, r.client, r.claims, stringKey("claim_id", id), &c)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errClaimNotFound
+	}
+	return &c, nil
+}
+
+func (r *DynamoAwardRepository) ListClaimsByUser(ctx context.Context, userID string) ([]*models.Claim, error) {
+	result, err := r.queryClaims(ctx, userIndex, "user_id", userID)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ClaimedAt.After(result[j].ClaimedAt)
+	})
+	return result, nil
+}
+
+func (r *DynamoAwardRepository) ListClaimsByStatus(ctx context.Context, status models.ClaimStatus) ([]*models.Claim, error) {
+	result, err := r.queryClaims(ctx, statusIndex, "status", string(status))
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ClaimedAt.Before(result[j].ClaimedAt)
+	})
+	return result, nil
+}
+
+func (r *DynamoAwardRepository) queryClaims(ctx context.Context, index, attr, value string) ([]*models.Claim, error) {
+	expr, err := keyEquals(attr, value)
+	if err != nil {
+		return nil, err
+	}
+	items, err := queryAll(ctx, r.client, &dynamodb.QueryInput{
+		TableName:                 aws.String(r.claims),
+		IndexName:                 aws.String(index),
+		KeyConditionExpression:    expr.KeyCondition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*models.Claim
+	if err := attributevalue.UnmarshalListOfMaps(items, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (r *DynamoAwardRepository) SetClaimStatus(ctx context.Context, id string, from, to models.ClaimStatus, note string, at time.Time) (*models.Claim, error) {
+	update := expression.
+		Set(expression.Name("status"), expression.Value(to)).
+		Set(expression.Name("note"), expression.Value(note)).
+		Set(expression.Name("updated_at"), expression.Value(at))
+	expr, err := expression.NewBuilder().
+		WithUpdate(update).
+		WithCondition(expression.Name("status").Equal(expression.Value(from))).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+	res, err := r.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName:                 aws.String(r.claims),
+		Key:                       stringKey("claim_id", id),
+		UpdateExpression:          expr.Update(),
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		ReturnValues:              types.ReturnValueAllNew,
+	})
+	if isConditionFailed(err) {
+		if _, err := r.GetClaim(ctx, id); err != nil {
+			return nil, err
+		}
+		return nil, ErrClaimStatusChanged
+	}
+	if err != nil {
+		return nil, err
+	}
+	var c models.Claim
+	if err := attributevalue.UnmarshalMap(res.Attributes, &c); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
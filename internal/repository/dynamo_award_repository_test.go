@@ -0,0 +1,123 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/google/uuid"
+
+	"github.com/rprajapati0067/quiz-game-backend/internal/models"
+	"github.com/rprajapati0067/quiz-game-backend/internal/repository"
+	"github.com/rprajapati0067/quiz-game-backend/internal/repository/repotest"
+)
+
+// TestDynamoAwardRepository runs against DynamoDB Local and is skipped unless
+// DYNAMODB_ENDPOINT is set.
+func TestDynamoAwardRepository(t *testing.T) {
+	repotest.TestAwardRepository(t, func(t *testing.T) repository.AwardRepository {
+		return repository.NewDynamoRepositories(repotest.Dynamo(t)).Awards
+	})
+}
+
+// TestDynamoPlaceClaim runs against DynamoDB Local and is skipped unless
+// DYNAMODB_ENDPOINT is set.
+func TestDynamoPlaceClaim(t *testing.T) {
+	repotest.TestPlaceClaim(t, func(t *testing.T) repository.Repositories {
+		return repository.NewDynamoRepositories(repotest.Dynamo(t))
+	})
+}
+
+func TestDynamoReserveAndReleaseKeepStockAndHoldsInStep(t *testing.T) {
+	ctx := context.Background()
+	client, tables := repotest.Dynamo(t)
+	repo := repository.NewDynamoRepositories(client, tables).Awards
+	const limit = 3
+	a := &models.Award{ID: uuid.NewString(), Product: "mug", PointCost: 10, TotalStock: 10, RemainingStock: 10, PerUserLimit: limit, Active: true}
+	if err := repo.Create(ctx, a); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	reserved := countConcurrent(t, 20, repository.ErrClaimLimitReached, func() error {
+		return repo.Reserve(ctx, a.ID, "u1")
+	})
+	if held := dynamoHeld(t, client, tables, a.ID, "u1"); reserved != limit || held != limit {
+		t.Fatalf("%d reservations recorded as %d held, want %d", reserved, held, limit)
+	}
+	if got := dynamoRemaining(t, repo, a.ID); got != 10-limit {
+		t.Fatalf("remaining stock %d after %d reservations, want %d", got, limit, 10-limit)
+	}
+
+	released := countConcurrent(t, 5, repository.ErrNoReservation, func() error {
+		return repo.Release(ctx, a.ID, "u1")
+	})
+	if held := dynamoHeld(t, client, tables, a.ID, "u1"); released != limit || held != 0 {
+		t.Fatalf("%d releases left %d held; want %d and 0", released, held, limit)
+	}
+	if got := dynamoRemaining(t, repo, a.ID); got != 10 {
+		t.Fatalf("remaining stock %d after releasing everything, want 10", got)
+	}
+}
+
+// countConcurrent runs fn n times at once and returns how many calls
+// succeeded, failing the test on any error other than refused.
+func countConcurrent(t *testing.T, n int, refused error, fn func() error) int {
+	t.Helper()
+	var wg sync.WaitGroup
+	errs := make([]error, n)
+	for i := range errs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = fn()
+		}()
+	}
+	wg.Wait()
+
+	ok := 0
+	for _, err := range errs {
+		switch {
+		case err == nil:
+			ok++
+		case !errors.Is(err, refused):
+			t.Fatalf("got error %v, want nil or %v", err, refused)
+		}
+	}
+	return ok
+}
+
+func dynamoHeld(t *testing.T, client *dynamodb.Client, tables repository.DynamoTables, awardID, userID string) int {
+	t.Helper()
+	res, err := client.GetItem(context.Background(), &dynamodb.GetItemInput{
+		TableName: aws.String(tables.AwardHolds),
+		Key: map[string]types.AttributeValue{
+			"award_id": &types.AttributeValueMemberS{Value: awardID},
+			"user_id":  &types.AttributeValueMemberS{Value: userID},
+		},
+		ConsistentRead: aws.Bool(true),
+	})
+	if err != nil {
+		t.Fatalf("get hold: %v", err)
+	}
+	var hold struct {
+		Held int `dynamodbav:"held"`
+	}
+	if err := attributevalue.UnmarshalMap(res.Item, &hold); err != nil {
+		t.Fatalf("unmarshal hold: %v", err)
+	}
+	return hold.Held
+}
+
+func dynamoRemaining(t *testing.T, repo repository.AwardRepository, awardID string) int64 {
+	t.Helper()
+	a, err := repo.GetByID(context.Background(), awardID)
+	if err != nil {
+		t.Fatalf("get award: %v", err)
+	}
+	return a.RemainingStock
+}
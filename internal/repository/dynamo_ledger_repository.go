@@ -0,0 +1,225 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"github.com/rprajapati0067/quiz-game-backend/internal/models"
+)
+
+// ledgerAppendAttempts bounds how often Append retries after losing a race
+// with another append for the same user. Each attempt loses only to one
+// that committed, so a burst of that many appends still all succeed.
+const ledgerAppendAttempts = 20
+
+// DynamoLedgerRepository keeps entries keyed by user and seq, and one
+// balance snapshot per user that always reflects the latest entry. Entry
+// IDs are claimed by an item keyed by user and ID in a separate table.
+// Append writes all three in a transaction conditioned on the snapshot's
+// seq, so balances never need a replay.
+type DynamoLedgerRepository struct {
+	client   *dynamodb.Client
+	entries  string
+	ids      string
+	balances string
+}
+
+func NewDynamoLedgerRepository(client *dynamodb.Client, entries, ids, balances string) *DynamoLedgerRepository {
+	return &DynamoLedgerRepository{client: client, entries: entries, ids: ids, balances: balances}
+}
+
+// ledgerIDItem claims an entry ID for a user and records where the entry
+// landed.
+type ledgerIDItem struct {
+	UserID  string `dynamodbav:"user_id"`
+	EntryID string `dynamodbav:"entry_id"`
+	Seq     int64  `dynamodbav:"seq"`
+	Balance int64  `dynamodbav:"balance"`
+}
+
+func (r *DynamoLedgerRepository) Append(ctx context.Context, e *models.LedgerEntry) error {
+	for attempt := 1; ; attempt++ {
+		err := r.append(ctx, e)
+		if err == nil || !isTransactionConflict(err) || attempt == ledgerAppendAttempts {
+			return err
+		}
+		if err := pause(ctx, attempt); err != nil {
+			return err
+		}
+	}
+}
+
+func (r *DynamoLedgerRepository) append(ctx context.Context, e *models.LedgerEntry) error {
+	items, entry, err := r.appendItems(ctx, e)
+	if err != nil {
+		return err
+	}
+	err = transactWrite(ctx, r.client, items)
+	if canceledAt(err, ledgerIDItemIndex) {
+		// Lost to an append of the same entry.
+		if recorded, getErr := r.recorded(ctx, e); recorded || getErr != nil {
+			return getErr
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	e.Seq = entry.Seq
+	e.Balance = entry.Balance
+	return nil
+}
+
+// ledgerIDItemIndex is where appendItems puts the item claiming the entry
+// ID, so a cancelled transaction can be checked for a duplicate entry.
+const ledgerIDItemIndex = 2
+
+// appendItems returns the writes that append e on top of the user's latest
+// balance and the entry as it will be stored. It fails with ErrEntryExists
+// or ErrInsufficientBalance without building them. The writes only succeed
+// if no other append commits first.
+func (r *DynamoLedgerRepository) appendItems(ctx context.Context, e *models.LedgerEntry) ([]types.TransactWriteItem, *models.LedgerEntry, error) {
+	if recorded, err := r.recorded(ctx, e); recorded || err != nil {
+		return nil, nil, err
+	}
+	head, err := r.snapshot(ctx, e.UserID)
+	if err != nil {
+		return nil, nil, err
+	}
+	balance := head.Balance + e.Signed()
+	if balance < 0 {
+		return nil, nil, ErrInsufficientBalance
+	}
+
+	entry := *e
+	entry.Seq = head.Seq + 1
+	entry.Balance = balance
+	entryItem, err := attributevalue.MarshalMap(&entry)
+	if err != nil {
+		return nil, nil, err
+	}
+	next := models.BalanceSnapshot{
+		UserID:  e.UserID,
+		Seq:     entry.Seq,
+		Balance: balance,
+		TakenAt: time.Now(),
+	}
+	headItem, err := attributevalue.MarshalMap(&next)
+	if err != nil {
+		return nil, nil, err
+	}
+	idItem, err := attributevalue.MarshalMap(&ledgerIDItem{
+		UserID:  e.UserID,
+		EntryID: e.ID,
+		Seq:     entry.Seq,
+		Balance: entry.Balance,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	idCond, err := notExists("entry_id")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	entryCond, err := notExists("user_id")
+	if err != nil {
+		return nil, nil, err
+	}
+	headCond := expression.AttributeNotExists(expression.Name("user_id"))
+	if head.Seq > 0 {
+		headCond = expression.Name("seq").Equal(expression.Value(head.Seq))
+	}
+	headExpr, err := expression.NewBuilder().WithCondition(headCond).Build()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return []types.TransactWriteItem{
+		{Put: &types.Put{
+			TableName:                 aws.String(r.balances),
+			Item:                      headItem,
+			ConditionExpression:       headExpr.Condition(),
+			ExpressionAttributeNames:  headExpr.Names(),
+			ExpressionAttributeValues: headExpr.Values(),
+		}},
+		{Put: &types.Put{
+			TableName:                aws.String(r.entries),
+			Item:                     entryItem,
+			ConditionExpression:      entryCond.Condition(),
+			ExpressionAttributeNames: entryCond.Names(),
+		}},
+		{Put: &types.Put{
+			TableName:                aws.String(r.ids),
+			Item:                     idItem,
+			ConditionExpression:      idCond.Condition(),
+			ExpressionAttributeNames: idCond.Names(),
+		}},
+	}, &entry, nil
+}
+
+// recorded reports whether the user already has an entry with e's ID, in
+// which case it fills in e from it and returns ErrEntryExists.
+func (r *DynamoLedgerRepository) recorded(ctx context.Context, e *models.LedgerEntry) (bool, error) {
+	var prev ledgerIDItem
+	key := map[string]types.AttributeValue{"user_id": attrS(e.UserID), "entry_id": attrS(e.ID)}
+	found, err := getItem(ctx, r.client, r.ids, key, &prev)
+	if err != nil || !found {
+		return false, err
+	}
+	e.Seq = prev.Seq
+	e.Balance = prev.Balance
+	return true, ErrEntryExists
+}
+
+func (r *DynamoLedgerRepository) Balance(ctx context.Context, userID string) (int64, error) {
+	head, err := r.snapshot(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	return head.Balance, nil
+}
+
+// snapshot returns the user's latest balance, which is zero at seq 0 for a
+// user without entries.
+func (r *DynamoLedgerRepository) snapshot(ctx context.Context, userID string) (models.BalanceSnapshot, error) {
+	var head models.BalanceSnapshot
+	_, err := getItem(ctx, r.client, r.balances, stringKey("user_id", userID), &head)
+	return head, err
+}
+
+func (r *DynamoLedgerRepository) List(ctx context.Context, userID string, beforeSeq int64, limit int) ([]*models.LedgerEntry, error) {
+	key := expression.Key("user_id").Equal(expression.Value(userID))
+	if beforeSeq > 0 {
+		key = expression.KeyAnd(key, expression.Key("seq").LessThan(expression.Value(beforeSeq)))
+	}
+	expr, err := expression.NewBuilder().WithKeyCondition(key).Build()
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := r.client.Query(ctx, &dynamodb.QueryInput{
+		TableName:                 aws.String(r.entries),
+		KeyConditionExpression:    expr.KeyCondition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		ScanIndexForward:          aws.Bool(false),
+		Limit:                     aws.Int32(int32(limit)),
+		ConsistentRead:            aws.Bool(true),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*models.LedgerEntry, 0, len(res.Items))
+	if err := attributevalue.UnmarshalListOfMaps(res.Items, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,110 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/google/uuid"
+
+	"github.com/rprajapati0067/quiz-game-backend/internal/models"
+	"github.com/rprajapati0067/quiz-game-backend/internal/repository"
+	"github.com/rprajapati0067/quiz-game-backend/internal/repository/repotest"
+)
+
+// TestDynamoLedgerRepository runs against DynamoDB Local and is skipped unless
+// DYNAMODB_ENDPOINT is set.
+func TestDynamoLedgerRepository(t *testing.T) {
+	repotest.TestLedgerRepository(t, func(t *testing.T) repository.LedgerRepository {
+		return repository.NewDynamoRepositories(repotest.Dynamo(t)).Ledger
+	})
+}
+
+func TestDynamoLedgerAppendCannotDoubleSpend(t *testing.T) {
+	ctx := context.Background()
+	client, tables := repotest.Dynamo(t)
+	repo := repository.NewDynamoLedgerRepository(client, tables.Ledger, tables.LedgerIDs, tables.Balances)
+	const user = "u1"
+	if err := repo.Append(ctx, dynamoLedgerEntry(user, models.LedgerCredit, 10)); err != nil {
+		t.Fatalf("credit: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, 10)
+	for i := range errs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = repo.Append(ctx, dynamoLedgerEntry(user, models.LedgerDebit, 10))
+		}()
+	}
+	wg.Wait()
+
+	spent := 0
+	for _, err := range errs {
+		switch {
+		case err == nil:
+			spent++
+		case !errors.Is(err, repository.ErrInsufficientBalance):
+			t.Fatalf("debit: %v", err)
+		}
+	}
+	balance, err := repo.Balance(ctx, user)
+	if err != nil {
+		t.Fatalf("balance: %v", err)
+	}
+	if spent != 1 || balance != 0 {
+		t.Fatalf("%d concurrent debits of the whole balance succeeded leaving %d; want 1 and 0", spent, balance)
+	}
+}
+
+func TestDynamoLedgerAppendWritesEntryAndBalanceTogether(t *testing.T) {
+	ctx := context.Background()
+	client, tables := repotest.Dynamo(t)
+	repo := repository.NewDynamoLedgerRepository(client, tables.Ledger, tables.LedgerIDs, tables.Balances)
+	const user = "u1"
+	if err := repo.Append(ctx, dynamoLedgerEntry(user, models.LedgerCredit, 10)); err != nil {
+		t.Fatalf("credit: %v", err)
+	}
+
+	// An entry already occupying the next seq makes the entry half of the
+	// transaction fail; the balance half must not commit without it.
+	stray := dynamoLedgerEntry(user, models.LedgerCredit, 1)
+	stray.Seq = 2
+	item, err := attributevalue.MarshalMap(stray)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if _, err := client.PutItem(ctx, &dynamodb.PutItemInput{TableName: aws.String(tables.Ledger), Item: item}); err != nil {
+		t.Fatalf("put stray entry: %v", err)
+	}
+
+	if err := repo.Append(ctx, dynamoLedgerEntry(user, models.LedgerCredit, 5)); err == nil {
+		t.Fatal("append over an existing seq succeeded")
+	}
+	balance, err := repo.Balance(ctx, user)
+	if err != nil {
+		t.Fatalf("balance: %v", err)
+	}
+	if balance != 10 {
+		t.Fatalf("balance %d after a failed append, want 10", balance)
+	}
+}
+
+func dynamoLedgerEntry(userID string, kind models.LedgerKind, amount int64) *models.LedgerEntry {
+	return &models.LedgerEntry{
+		ID:        uuid.NewString(),
+		UserID:    userID,
+		Kind:      kind,
+		Amount:    amount,
+		Reason:    models.ReasonCorrectAnswer,
+		RefType:   repository.RefAnswer,
+		RefID:     uuid.NewString(),
+		CreatedAt: time.Unix(0, 0),
+	}
+}
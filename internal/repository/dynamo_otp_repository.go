@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"github.com/rprajapati0067/quiz-game-backend/internal/models"
+)
+
+type DynamoOTPRepository struct {
+	client *dynamodb.Client
+	table  string
+}
+
+func NewDynamoOTPRepository(client *dynamodb.Client, table string) *DynamoOTPRepository {
+	return &DynamoOTPRepository{client: client, table: table}
+}
+
+func (r *DynamoOTPRepository) Save(ctx context.Context, o, prev *models.OTP) error {
+	item, err := attributevalue.MarshalMap(o)
+	if err != nil {
+		return err
+	}
+	cond := expression.AttributeNotExists(expression.Name("phone"))
+	if prev != nil {
+		cond = expression.Name("code_hash").Equal(expression.Value(prev.CodeHash)).
+			And(expression.Name("attempts").Equal(expression.Value(prev.Attempts)))
+	}
+	expr, err := expression.NewBuilder().WithCondition(cond).Build()
+	if err != nil {
+		return err
+	}
+	_, err = r.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:                 aws.String(r.table),
+		Item:                      item,
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	})
+	if isConditionFailed(err) {
+		return ErrOTPChanged
+	}
+	return err
+}
+
+func (r *DynamoOTPRepository) GetByPhone(ctx context.Context, phone string) (*models.OTP, error) {
+	var o models.OTP
+	found, err := getItem(ctx, r.client, r.table, stringKey("phone", phone), &o)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &o, nil
+}
+
+func (r *DynamoOTPRepository) UseAttempt(ctx context.Context, phone string, max int32) (*models.OTP, error) {
+	expr, err := expression.NewBuilder().
+		WithUpdate(expression.Add(expression.Name("attempts"), expression.Value(1))).
+		WithCondition(expression.AttributeExists(expression.Name("phone")).
+			And(expression.Name("attempts").LessThan(expression.Value(max)))).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+	res, err := r.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName:                 aws.String(r.table),
+		Key:                       stringKey("phone", phone),
+		UpdateExpression:          expr.Update(),
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		ReturnValues:              types.ReturnValueAllNew,
+	})
+	if isConditionFailed(err) {
+		// Either there is no code or its attempts are used up.
+		o, err := r.GetByPhone(ctx, phone)
+		if o == nil || err != nil {
+			return nil, err
+		}
+		return nil, ErrOTPAttemptsExhausted
+	}
+	if err != nil {
+		return nil, err
+	}
+	var o models.OTP
+	if err := attributevalue.UnmarshalMap(res.Attributes, &o); err != nil {
+		return nil, err
+	}
+	return &o, nil
+}
+
+func (r *DynamoOTPRepository) Delete(ctx context.Context, phone string) error {
+	_, err := r.client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		TableName: aws.String(r.table),
+		Key:       stringKey("phone", phone),
+	})
+	return err
+}
@@ -0,0 +1,16 @@
+package repository_test
+
+import (
+	"testing"
+
+	"github.com/rprajapati0067/quiz-game-backend/internal/repository"
+	"github.com/rprajapati0067/quiz-game-backend/internal/repository/repotest"
+)
+
+// TestDynamoOTPRepository runs against DynamoDB Local and is skipped unless
+// DYNAMODB_ENDPOINT is set.
+func TestDynamoOTPRepository(t *testing.T) {
+	repotest.TestOTPRepository(t, func(t *testing.T) repository.OTPRepository {
+		return repository.NewDynamoRepositories(repotest.Dynamo(t)).OTPs
+	})
+}
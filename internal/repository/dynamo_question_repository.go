@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+
+	"github.com/rprajapati0067/quiz-game-backend/internal/models"
+)
+
+type DynamoQuestionRepository struct {
+	client *dynamodb.Client
+	table  string
+}
+
+func NewDynamoQuestionRepository(client *dynamodb.Client, table string) *DynamoQuestionRepository {
+	return &DynamoQuestionRepository{client: client, table: table}
+}
+
+func (r *DynamoQuestionRepository) Create(ctx context.Context, q *models.Question) error {
+	item, err := attributevalue.MarshalMap(q)
+	if err != nil {
+		return err
+	}
+	cond, err := notExists("question_id")
+	if err != nil {
+		return err
+	}
+	_, err = r.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:                aws.String(r.table),
+		Item:                     item,
+		ConditionExpression:      cond.Condition(),
+		ExpressionAttributeNames: cond.Names(),
+	})
+	if isConditionFailed(err) {
+		return errQuestionExists
+	}
+	return err
+}
+
+func (r *DynamoQuestionRepository) ListBySlot(ctx context.Context, slot int32) ([]*models.Question, error) {
+	expr, err := keyEquals("slot", slot)
+	if err != nil {
+		return nil, err
+	}
+	items, err := queryAll(ctx, r.client, &dynamodb.QueryInput{
+		TableName:                 aws.String(r.table),
+		IndexName:                 aws.String(slotIndex),
+		KeyConditionExpression:    expr.KeyCondition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*models.Question
+	if err := attributevalue.UnmarshalListOfMaps(items, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (r *DynamoQuestionRepository) GetByID(ctx context.Context, id string) (*models.Question, error) {
+	var q models.Question
+	found, err := getItem(ctx, r.client, r.table, stringKey("question_id", id), &q)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errQuestionNotFound
+	}
+	return &q, nil
+}
@@ -0,0 +1,16 @@
+package repository_test
+
+import (
+	"testing"
+
+	"github.com/rprajapati0067/quiz-game-backend/internal/repository"
+	"github.com/rprajapati0067/quiz-game-backend/internal/repository/repotest"
+)
+
+// TestDynamoQuestionRepository runs against DynamoDB Local and is skipped unless
+// DYNAMODB_ENDPOINT is set.
+func TestDynamoQuestionRepository(t *testing.T) {
+	repotest.TestQuestionRepository(t, func(t *testing.T) repository.QuestionRepository {
+		return repository.NewDynamoRepositories(repotest.Dynamo(t)).Questions
+	})
+}
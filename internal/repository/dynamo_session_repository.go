@@ -0,0 +1,195 @@
+package repository
+
+import (
+	"context"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"github.com/rprajapati0067/quiz-game-backend/internal/models"
+)
+
+type DynamoSessionRepository struct {
+	client   *dynamodb.Client
+	sessions string
+	tokens   string
+}
+
+func NewDynamoSessionRepository(client *dynamodb.Client, sessions, tokens string) *DynamoSessionRepository {
+	return &DynamoSessionRepository{client: client, sessions: sessions, tokens: tokens}
+}
+
+func (r *DynamoSessionRepository) Create(ctx context.Context, s *models.Session, t *models.RefreshToken) error {
+	sessionItem, err := attributevalue.MarshalMap(s)
+	if err != nil {
+		return err
+	}
+	tokenItem, err := attributevalue.MarshalMap(t)
+	if err != nil {
+		return err
+	}
+	sessionCond, err := notExists("session_id")
+	if err != nil {
+		return err
+	}
+	tokenCond, err := notExists("token_hash")
+	if err != nil {
+		return err
+	}
+
+	err = transactWrite(ctx, r.client, []types.TransactWriteItem{
+		{Put: &types.Put{
+			TableName:                aws.String(r.sessions),
+			Item:                     sessionItem,
+			ConditionExpression:      sessionCond.Condition(),
+			ExpressionAttributeNames: sessionCond.Names(),
+		}},
+		{Put: &types.Put{
+			TableName:                aws.String(r.tokens),
+			Item:                     tokenItem,
+			ConditionExpression:      tokenCond.Condition(),
+			ExpressionAttributeNames: tokenCond.Names(),
+		}},
+	})
+	if canceledAt(err, 0) {
+		return errSessionExists
+	}
+	return err
+}
+
+func (r *DynamoSessionRepository) GetByID(ctx context.Context, id string) (*models.Session, error) {
+	var s models.Session
+	found, err := getItem(ctx, r.client, r.sessions, stringKey("session_id", id), &s)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (r *DynamoSessionRepository) ListByUser(ctx context.Context, userID string) ([]*models.Session, error) {
+	expr, err := keyEquals("user_id", userID)
+	if err != nil {
+		return nil, err
+	}
+	items, err := queryAll(ctx, r.client, &dynamodb.QueryInput{
+		TableName:                 aws.String(r.sessions),
+		IndexName:                 aws.String(userIndex),
+		KeyConditionExpression:    expr.KeyCondition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*models.Session
+	if err := attributevalue.UnmarshalListOfMaps(items, &result); err != nil {
+		return nil, err
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.Before(result[j].CreatedAt)
+	})
+	return result, nil
+}
+
+func (r *DynamoSessionRepository) Revoke(ctx context.Context, id string) error {
+	expr, err := expression.NewBuilder().
+		WithUpdate(expression.Set(expression.Name("revoked"), expression.Value(true))).
+		WithCondition(expression.AttributeExists(expression.Name("session_id"))).
+		Build()
+	if err != nil {
+		return err
+	}
+	_, err = r.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName:                 aws.String(r.sessions),
+		Key:                       stringKey("session_id", id),
+		UpdateExpression:          expr.Update(),
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	})
+	if isConditionFailed(err) {
+		return errSessionNotFound
+	}
+	return err
+}
+
+func (r *DynamoSessionRepository) GetRefreshToken(ctx context.Context, hash string) (*models.RefreshToken, error) {
+	var t models.RefreshToken
+	found, err := getItem(ctx, r.client, r.tokens, stringKey("token_hash", hash), &t)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (r *DynamoSessionRepository) Rotate(ctx context.Context, oldHash string, next *models.RefreshToken) error {
+	tokenItem, err := attributevalue.MarshalMap(next)
+	if err != nil {
+		return err
+	}
+	rotate, err := expression.NewBuilder().
+		WithUpdate(expression.Set(expression.Name("rotated"), expression.Value(true))).
+		WithCondition(expression.And(
+			expression.AttributeExists(expression.Name("token_hash")),
+			expression.Name("rotated").Equal(expression.Value(false)),
+		)).
+		Build()
+	if err != nil {
+		return err
+	}
+	insert, err := notExists("token_hash")
+	if err != nil {
+		return err
+	}
+	touch, err := expression.NewBuilder().
+		WithUpdate(expression.Set(expression.Name("last_used_at"), expression.Value(next.IssuedAt))).
+		WithCondition(expression.AttributeExists(expression.Name("session_id"))).
+		Build()
+	if err != nil {
+		return err
+	}
+
+	err = transactWrite(ctx, r.client, []types.TransactWriteItem{
+		{Update: &types.Update{
+			TableName:                 aws.String(r.tokens),
+			Key:                       stringKey("token_hash", oldHash),
+			UpdateExpression:          rotate.Update(),
+			ConditionExpression:       rotate.Condition(),
+			ExpressionAttributeNames:  rotate.Names(),
+			ExpressionAttributeValues: rotate.Values(),
+		}},
+		{Put: &types.Put{
+			TableName:                aws.String(r.tokens),
+			Item:                     tokenItem,
+			ConditionExpression:      insert.Condition(),
+			ExpressionAttributeNames: insert.Names(),
+		}},
+		{Update: &types.Update{
+			TableName:                 aws.String(r.sessions),
+			Key:                       stringKey("session_id", next.SessionID),
+			UpdateExpression:          touch.Update(),
+			ConditionExpression:       touch.Condition(),
+			ExpressionAttributeNames:  touch.Names(),
+			ExpressionAttributeValues: touch.Values(),
+		}},
+	})
+	if canceledAt(err, 0) {
+		old, getErr := r.GetRefreshToken(ctx, oldHash)
+		if getErr != nil {
+			return getErr
+		}
+		if old == nil {
+			return errTokenNotFound
+		}
+		return ErrRefreshTokenReused
+	}
+	if canceledAt(err, 2) {
+		return errSessionNotFound
+	}
+	return err
+}
@@ -0,0 +1,16 @@
+package repository_test
+
+import (
+	"testing"
+
+	"github.com/rprajapati0067/quiz-game-backend/internal/repository"
+	"github.com/rprajapati0067/quiz-game-backend/internal/repository/repotest"
+)
+
+// TestDynamoSessionRepository runs against DynamoDB Local and is skipped
+// unless DYNAMODB_ENDPOINT is set.
+func TestDynamoSessionRepository(t *testing.T) {
+	repotest.TestSessionRepository(t, func(t *testing.T) repository.SessionRepository {
+		return repository.NewDynamoRepositories(repotest.Dynamo(t)).Sessions
+	})
+}
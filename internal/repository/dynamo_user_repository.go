@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"github.com/rprajapati0067/quiz-game-backend/internal/models"
+)
+
+// phoneClaimPrefix marks the items that reserve a phone number in the users
+// table. A GSI cannot enforce uniqueness, so CreateUser writes the user and
+// its phone claim in one transaction.
+const phoneClaimPrefix = "phone#"
+
+type DynamoUserRepository struct {
+	client *dynamodb.Client
+	table  string
+}
+
+func NewDynamoUserRepository(client *dynamodb.Client, table string) *DynamoUserRepository {
+	return &DynamoUserRepository{client: client, table: table}
+}
+
+func (r *DynamoUserRepository) CreateUser(ctx context.Context, u *models.User) error {
+	item, err := attributevalue.MarshalMap(u)
+	if err != nil {
+		return err
+	}
+	phoneClaim := map[string]types.AttributeValue{
+		"user_id":  attrS(phoneClaimPrefix + u.Phone),
+		"owner_id": attrS(u.ID),
+	}
+	cond, err := notExists("user_id")
+	if err != nil {
+		return err
+	}
+
+	err = transactWrite(ctx, r.client, []types.TransactWriteItem{
+		{Put: &types.Put{
+			TableName:                aws.String(r.table),
+			Item:                     item,
+			ConditionExpression:      cond.Condition(),
+			ExpressionAttributeNames: cond.Names(),
+		}},
+		{Put: &types.Put{
+			TableName:                aws.String(r.table),
+			Item:                     phoneClaim,
+			ConditionExpression:      cond.Condition(),
+			ExpressionAttributeNames: cond.Names(),
+		}},
+	})
+	switch {
+	case canceledAt(err, 0):
+		return errUserExists
+	case canceledAt(err, 1):
+		return errPhoneTaken
+	}
+	return err
+}
+
+func (r *DynamoUserRepository) GetByPhone(ctx context.Context, phone string) (*models.User, error) {
+	expr, err := keyEquals("phone", phone)
+	if err != nil {
+		return nil, err
+	}
+	res, err := r.client.Query(ctx, &dynamodb.QueryInput{
+		TableName:                 aws.String(r.table),
+		IndexName:                 aws.String(phoneIndex),
+		KeyConditionExpression:    expr.KeyCondition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		Limit:                     aws.Int32(1),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(res.Items) == 0 {
+		return nil, errUserNotFound
+	}
+	// The index is eventually consistent; re-read the user so callers never
+	// act on a stale version.
+	var indexed models.User
+	if err := attributevalue.UnmarshalMap(res.Items[0], &indexed); err != nil {
+		return nil, err
+	}
+	return r.GetByID(ctx, indexed.ID)
+}
+
+func (r *DynamoUserRepository) GetByID(ctx context.Context, id string) (*models.User, error) {
+	var u models.User
+	found, err := getItem(ctx, r.client, r.table, stringKey("user_id", id), &u)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errUserNotFound
+	}
+	return &u, nil
+}
+
+func (r *DynamoUserRepository) Update(ctx context.Context, u *models.User) error {
+	next := *u
+	next.Version++
+	item, err := attributevalue.MarshalMap(&next)
+	if err != nil {
+		return err
+	}
+	cond, err := expression.NewBuilder().WithCondition(expression.And(
+		expression.AttributeExists(expression.Name("user_id")),
+		expression.Name("version").Equal(expression.Value(u.Version)),
+	)).Build()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:                 aws.String(r.table),
+		Item:                      item,
+		ConditionExpression:       cond.Condition(),
+		ExpressionAttributeNames:  cond.Names(),
+		ExpressionAttributeValues: cond.Values(),
+	})
+	if isConditionFailed(err) {
+		if _, err := r.GetByID(ctx, u.ID); err != nil {
+			return err
+		}
+		return ErrVersionConflict
+	}
+	if err != nil {
+		return err
+	}
+	u.Version = next.Version
+	return nil
+}
@@ -0,0 +1,16 @@
+package repository_test
+
+import (
+	"testing"
+
+	"github.com/rprajapati0067/quiz-game-backend/internal/repository"
+	"github.com/rprajapati0067/quiz-game-backend/internal/repository/repotest"
+)
+
+// TestDynamoUserRepository runs against DynamoDB Local and is skipped unless
+// DYNAMODB_ENDPOINT is set.
+func TestDynamoUserRepository(t *testing.T) {
+	repotest.TestUserRepository(t, func(t *testing.T) repository.UserRepository {
+		return repository.NewDynamoRepositories(repotest.Dynamo(t)).Users
+	})
+}
@@ -0,0 +1,297 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"github.com/rprajapati0067/quiz-game-backend/internal/models"
+)
+
+// voucherCandidates is how many free codes Assign reads at once; concurrent
+// claims race for them, so reading a few avoids retrying on every loss.
+// The free index lags behind assignments, so Assign gives up after
+// voucherAssignRounds reads that only turned up taken codes.
+const (
+	voucherCandidates   = 10
+	voucherAssignRounds = 5
+)
+
+// voucherBatch is how many codes Add stores per transaction; the award's
+// restock takes the last of the 100 items a transaction may hold.
+const voucherBatch = 99
+
+// dynamoVoucher is the stored form of a voucher. FreeAwardID is set only
+// while the code is unassigned, which keeps the free index sparse.
+type dynamoVoucher struct {
+	models.Voucher
+	FreeAwardID string `dynamodbav:"free_award_id,omitempty"`
+}
+
+// DynamoVoucherRepository does not guarantee first-in, first-out hand-out;
+// any free code may be assigned. Codes are restocked into the award items
+// in the awards table.
+type DynamoVoucherRepository struct {
+	client *dynamodb.Client
+	table  string
+	awards string
+}
+
+func NewDynamoVoucherRepository(client *dynamodb.Client, table, awards string) *DynamoVoucherRepository {
+	return &DynamoVoucherRepository{client: client, table: table, awards: awards}
+}
+
+// Add stores the codes in batches of voucherBatch, each restocking the
+// award in its own transaction. A failed upload may have stored some
+// batches, but the stock always matches the codes stored.
+func (r *DynamoVoucherRepository) Add(ctx context.Context, awardID string, codes []string, at time.Time) (int, error) {
+	// A transaction may not write the same item twice.
+	unique := make([]string, 0, len(codes))
+	seen := make(map[string]bool, len(codes))
+	for _, code := range codes {
+		if !seen[code] {
+			seen[code] = true
+			unique = append(unique, code)
+		}
+	}
+
+	added := 0
+	for start := 0; start < len(unique); start += voucherBatch {
+		n, err := r.addBatch(ctx, awardID, unique[start:min(start+voucherBatch, len(unique))], at)
+		added += n
+		if err != nil {
+			return added, err
+		}
+	}
+	return added, nil
+}
+
+// addBatch stores codes and restocks the award by as many in one
+// transaction. Codes already in the pool fail their condition and cancel
+// it, so they are dropped and the rest written again.
+func (r *DynamoVoucherRepository) addBatch(ctx context.Context, awardID string, codes []string, at time.Time) (int, error) {
+	cond, err := notExists("code")
+	if err != nil {
+		return 0, err
+	}
+	for len(codes) > 0 {
+		items := make([]types.TransactWriteItem, 0, len(codes)+1)
+		for _, code := range codes {
+			item, err := attributevalue.MarshalMap(&dynamoVoucher{
+				Voucher:     models.Voucher{AwardID: awardID, Code: code, UploadedAt: at},
+				FreeAwardID: awardID,
+			})
+			if err != nil {
+				return 0, err
+			}
+			items = append(items, types.TransactWriteItem{Put: &types.Put{
+				TableName:                aws.String(r.table),
+				Item:                     item,
+				ConditionExpression:      cond.Condition(),
+				ExpressionAttributeNames: cond.Names(),
+			}})
+		}
+		quantity := int64(len(codes))
+		restock, err := expression.NewBuilder().
+			WithUpdate(expression.
+				Add(expression.Name("total_stock"), expression.Value(quantity)).
+				Add(expression.Name("remaining_stock"), expression.Value(quantity))).
+			WithCondition(expression.AttributeExists(expression.Name("award_id"))).
+			Build()
+		if err != nil {
+			return 0, err
+		}
+		items = append(items, types.TransactWriteItem{Update: &types.Update{
+			TableName:                 aws.String(r.awards),
+			Key:                       stringKey("award_id", awardID),
+			UpdateExpression:          restock.Update(),
+			ConditionExpression:       restock.Condition(),
+			ExpressionAttributeNames:  restock.Names(),
+			ExpressionAttributeValues: restock.Values(),
+		}})
+
+		err = transactWrite(ctx, r.client, items)
+		if err == nil {
+			return len(codes), nil
+		}
+		if canceledAt(err, len(codes)) {
+			return 0, errAwardNotFound
+		}
+		var fresh []string
+		for i, code := range codes {
+			if !canceledAt(err, i) {
+				fresh = append(fresh, code)
+			}
+		}
+		if len(fresh) == len(codes) {
+			return 0, err
+		}
+		codes = fresh
+	}
+	return 0, nil
+}
+
+func (r *DynamoVoucherRepository) Assign(ctx context.Context, awardID, claimID, userID string, at time.Time) (*models.Voucher, int, error) {
+	update := expression.
+		Set(expression.Name("claim_id"), expression.Value(claimID)).
+		Set(expression.Name("user_id"), expression.Value(userID)).
+		Set(expression.Name("assigned_at"), expression.Value(at)).
+		Remove(expression.Name("free_award_id"))
+	expr, err := expression.NewBuilder().
+		WithUpdate(update).
+		WithCondition(expression.AttributeExists(expression.Name("free_award_id"))).
+		Build()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for round := 0; round < voucherAssignRounds; round++ {
+		candidates, err := r.free(ctx, awardID, voucherCandidates)
+		if err != nil {
+			return nil, 0, err
+		}
+		if len(candidates) == 0 {
+			return nil, 0, ErrNoVoucherAvailable
+		}
+
+		for _, c := range candidates {
+			res, err := r.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+				TableName:                 aws.String(r.table),
+				Key:                       r.key(awardID, c.Code),
+				UpdateExpression:          expr.Update(),
+				ConditionExpression:       expr.Condition(),
+				ExpressionAttributeNames:  expr.Names(),
+				ExpressionAttributeValues: expr.Values(),
+				ReturnValues:              types.ReturnValueAllNew,
+			})
+			if isConditionFailed(err) {
+				// Another claim took this code first.
+				continue
+			}
+			if err != nil {
+				return nil, 0, err
+			}
+
+			var v models.Voucher
+			if err := attributevalue.UnmarshalMap(res.Attributes, &v); err != nil {
+				return nil, 0, err
+			}
+			left, err := r.Available(ctx, awardID)
+			if err != nil {
+				return nil, 0, err
+			}
+			return &v, left, nil
+		}
+	}
+	return nil, 0, ErrVoucherContention
+}
+
+func (r *DynamoVoucherRepository) Unassign(ctx context.Context, awardID, claimID string) error {
+	key, err := keyEquals("claim_id", claimID)
+	if err != nil {
+		return err
+	}
+	res, err := r.client.Query(ctx, &dynamodb.QueryInput{
+		TableName:                 aws.String(r.table),
+		IndexName:                 aws.String(claimIndex),
+		KeyConditionExpression:    key.KeyCondition(),
+		ExpressionAttributeNames:  key.Names(),
+		ExpressionAttributeValues: key.Values(),
+		Limit:                     aws.Int32(1),
+	})
+	if err != nil {
+		return err
+	}
+	if len(res.Items) == 0 {
+		return errVoucherNotFound
+	}
+	var v models.Voucher
+	if err := attributevalue.UnmarshalMap(res.Items[0], &v); err != nil {
+		return err
+	}
+
+	update := expression.
+		Set(expression.Name("free_award_id"), expression.Value(awardID)).
+		Remove(expression.Name("claim_id")).
+		Remove(expression.Name("user_id")).
+		Remove(expression.Name("assigned_at"))
+	expr, err := expression.NewBuilder().
+		WithUpdate(update).
+		WithCondition(expression.Name("claim_id").Equal(expression.Value(claimID))).
+		Build()
+	if err != nil {
+		return err
+	}
+	_, err = r.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName:                 aws.String(r.table),
+		Key:                       r.key(awardID, v.Code),
+		UpdateExpression:          expr.Update(),
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	})
+	if isConditionFailed(err) {
+		return errVoucherNotFound
+	}
+	return err
+}
+
+// Available counts the free index, which may briefly lag assignments.
+func (r *DynamoVoucherRepository) Available(ctx context.Context, awardID string) (int, error) {
+	expr, err := keyEquals("free_award_id", awardID)
+	if err != nil {
+		return 0, err
+	}
+	count := 0
+	paginator := dynamodb.NewQueryPaginator(r.client, &dynamodb.QueryInput{
+		TableName:                 aws.String(r.table),
+		IndexName:                 aws.String(freeIndex),
+		KeyConditionExpression:    expr.KeyCondition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		Select:                    types.SelectCount,
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return 0, err
+		}
+		count += int(page.Count)
+	}
+	return count, nil
+}
+
+func (r *DynamoVoucherRepository) free(ctx context.Context, awardID string, limit int32) ([]*models.Voucher, error) {
+	expr, err := keyEquals("free_award_id", awardID)
+	if err != nil {
+		return nil, err
+	}
+	res, err := r.client.Query(ctx, &dynamodb.QueryInput{
+		TableName:                 aws.String(r.table),
+		IndexName:                 aws.String(freeIndex),
+		KeyConditionExpression:    expr.KeyCondition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		Limit:                     aws.Int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+	var result []*models.Voucher
+	if err := attributevalue.UnmarshalListOfMaps(res.Items, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (r *DynamoVoucherRepository) key(awardID, code string) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{
+		"award_id": attrS(awardID),
+		"code":     attrS(code),
+	}
+}
@@ -0,0 +1,16 @@
+package repository_test
+
+import (
+	"testing"
+
+	"github.com/rprajapati0067/quiz-game-backend/internal/repository"
+	"github.com/rprajapati0067/quiz-game-backend/internal/repository/repotest"
+)
+
+// TestDynamoVoucherRepository runs against DynamoDB Local and is skipped
+// unless DYNAMODB_ENDPOINT is set.
+func TestDynamoVoucherRepository(t *testing.T) {
+	repotest.TestVoucherRepository(t, func(t *testing.T) repository.Repositories {
+		return repository.NewDynamoRepositories(repotest.Dynamo(t))
+	})
+}
@@ -0,0 +1,47 @@
+package repository
+
+import "errors"
+
+// ErrNotFound and ErrConflict classify repository failures so callers can
+// handle every backend alike. Errors about a missing item match ErrNotFound
+// with errors.Is; errors about a write that collides with stored state,
+// such as a duplicate key or a lost race, match ErrConflict.
+var (
+    ErrNotFound = errors.New("not found")
+    ErrConflict = errors.New("conflict")
+)
+
+var (
+    errUserNotFound     = notFound("user not found")
+    errUserExists       = conflict("user already exists")
+    errPhoneTaken       = conflict("phone number already registered")
+    errQuestionNotFound = notFound("question not found")
+    errQuestionExists   = conflict("question already exists")
+    errAwardNotFound    = notFound("award not found")
+    errAwardExists      = conflict("award already exists")
+    errClaimNotFound    = notFound("claim not found")
+    errClaimExists      = conflict("claim already exists")
+    errSessionNotFound  = notFound("session not found")
+    errSessionExists    = conflict("session already exists")
+    errTokenNotFound    = notFound("refresh token not found")
+    errVoucherNotFound  = notFound("voucher not found")
+    errAnswerNotFound   = notFound("answer not found")
+)
+
+// kindError keeps its own message while matching kind.
+type kindError struct {
+    msg  string
+    kind error
+}
+
+func (e *kindError) Error() string { return e.msg }
+
+func (e *kindError) Unwrap() error { return e.kind }
+
+func notFound(msg string) error {
+    return &kindError{msg: msg, kind: ErrNotFound}
+}
+
+func conflict(msg string) error {
+    return &kindError{msg: msg, kind: ErrConflict}
+}
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+)
+
+// Hooks into unexported state for the tests in repository_test.
+
+const (
+	LedgerAppendAttempts = ledgerAppendAttempts
+	VoucherAssignRounds  = voucherAssignRounds
+)
+
+var ErrLedgerRace = errLedgerRace
+
+func (r *SQLLedgerRepository) SetBeforeAdvance(fn func(ctx context.Context, tx *sql.Tx) error) {
+	r.beforeAdvance = fn
+}
+
+func (r *SQLVoucherRepository) SetBeforeAssign(fn func(ctx context.Context, code string) error) {
+	r.beforeAssign = fn
+}
@@ -0,0 +1,36 @@
+package repository
+
+import (
+    "context"
+    "errors"
+
+    "github.com/rprajapati0067/quiz-game-backend/internal/models"
+)
+
+var (
+    ErrInsufficientBalance = errors.New("insufficient balance")
+    ErrEntryExists         = conflict("ledger entry already recorded")
+)
+
+// RefAnswer and RefClaim are the LedgerEntry.RefType values used for entries
+// that belong to an answer or a claim.
+const (
+    RefAnswer = "answer"
+    RefClaim  = "claim"
+)
+
+type LedgerRepository interface {
+    // Append records e, filling in its Seq and Balance. A debit that would
+    // take the balance below zero fails with ErrInsufficientBalance and
+    // records nothing.
+    //
+    // Entry IDs are unique per user, which makes appends idempotent when
+    // the ID is derived from what the entry pays for: appending an ID the
+    // user already has records nothing and fails with ErrEntryExists,
+    // after filling e's Seq and Balance from the recorded entry.
+    Append(ctx context.Context, e *models.LedgerEntry) error
+    Balance(ctx context.Context, userID string) (int64, error)
+    // List returns up to limit entries older than beforeSeq, newest first.
+    // A beforeSeq of 0 starts from the most recent entry.
+    List(ctx context.Context, userID string, beforeSeq int64, limit int) ([]*models.LedgerEntry, error)
+}
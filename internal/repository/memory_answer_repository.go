@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/rprajapati0067/quiz-game-backend/internal/models"
+)
+
+type MemoryAnswerRepository struct {
+	mu     sync.RWMutex
+	byUser map[string]map[string]*models.Answer
+	byKey  map[answerKey]*models.Answer
+}
+
+type answerKey struct {
+	userID string
+	key    string
+}
+
+func NewMemoryAnswerRepository() *MemoryAnswerRepository {
+	return &MemoryAnswerRepository{
+		byUser: make(map[string]map[string]*models.Answer),
+		byKey:  make(map[answerKey]*models.Answer),
+	}
+}
+
+func (r *MemoryAnswerRepository) Create(ctx context.Context, a *models.Answer) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	answers, exists := r.byUser[a.UserID]
+	if !exists {
+		answers = make(map[string]*models.Answer)
+		r.byUser[a.UserID] = answers
+	}
+	if _, exists := answers[a.QuestionID]; exists {
+		return ErrAnswerExists
+	}
+
+	answer := *a
+	answers[a.QuestionID] = &answer
+	if a.IdempotencyKey != "" {
+		r.byKey[answerKey{a.UserID, a.IdempotencyKey}] = &answer
+	}
+	return nil
+}
+
+func (r *MemoryAnswerRepository) Update(ctx context.Context, a *models.Answer) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, exists := r.byUser[a.UserID][a.QuestionID]
+	if !exists {
+		return errAnswerNotFound
+	}
+
+	*existing = *a
+	return nil
+}
+
+func (r *MemoryAnswerRepository) GetByUserAndQuestion(ctx context.Context, userID, questionID string) (*models.Answer, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	answer, exists := r.byUser[userID][questionID]
+	if !exists {
+		return nil, nil
+	}
+
+	// Return a copy to avoid race conditions
+	a := *answer
+	return &a, nil
+}
+
+func (r *MemoryAnswerRepository) GetByIdempotencyKey(ctx context.Context, userID, key string) (*models.Answer, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	answer, exists := r.byKey[answerKey{userID, key}]
+	if !exists {
+		return nil, nil
+	}
+
+	a := *answer
+	return &a, nil
+}
+
+func (r *MemoryAnswerRepository) ListByUser(ctx context.Context, userID string) ([]*models.Answer, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*models.Answer, 0, len(r.byUser[userID]))
+	for _, a := range r.byUser[userID] {
+		aCopy := *a
+		result = append(result, &aCopy)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].SubmittedAt.Before(result[j].SubmittedAt)
+	})
+	return result, nil
+}
@@ -0,0 +1,280 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rprajapati0067/quiz-game-backend/internal/models"
+)
+
+// MemoryAwardRepository places claims against the ledger it is given, so
+// both must belong to the same backend.
+type MemoryAwardRepository struct {
+	ledger *MemoryLedgerRepository
+
+	mu     sync.RWMutex
+	awards map[string]*models.Award
+	claims map[string]*models.Claim
+	// held counts each user's claims and open reservations per award.
+	held map[string]map[string]int32
+}
+
+// NewMemoryAwardRepository returns a repository preloaded with awards whose
+// claims are paid from ledger.
+func NewMemoryAwardRepository(ledger *MemoryLedgerRepository, awards ...*models.Award) *MemoryAwardRepository {
+	r := &MemoryAwardRepository{
+		ledger: ledger,
+		awards: make(map[string]*models.Award),
+		claims: make(map[string]*models.Claim),
+		held:   make(map[string]map[string]int32),
+	}
+	for _, a := range awards {
+		award := *a
+		r.awards[a.ID] = &award
+	}
+	return r
+}
+
+func (r *MemoryAwardRepository) List(ctx context.Context) ([]*models.Award, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*models.Award, 0, len(r.awards))
+	for _, a := range r.awards {
+		// Return a copy to avoid race conditions
+		aCopy := *a
+		result = append(result, &aCopy)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].PointCost < result[j].PointCost
+	})
+	return result, nil
+}
+
+func (r *MemoryAwardRepository) GetByID(ctx context.Context, id string) (*models.Award, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	award, exists := r.awards[id]
+	if !exists {
+		return nil, errAwardNotFound
+	}
+
+	a := *award
+	return &a, nil
+}
+
+func (r *MemoryAwardRepository) Create(ctx context.Context, a *models.Award) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.awards[a.ID]; exists {
+		return errAwardExists
+	}
+
+	award := *a
+	r.awards[a.ID] = &award
+	return nil
+}
+
+func (r *MemoryAwardRepository) Update(ctx context.Context, a *models.Award) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, exists := r.awards[a.ID]
+	if !exists {
+		return errAwardNotFound
+	}
+
+	award := *a
+	award.TotalStock = existing.TotalStock
+	award.RemainingStock = existing.RemainingStock
+	award.Active = existing.Active
+	award.Digital = existing.Digital
+	r.awards[a.ID] = &award
+	return nil
+}
+
+func (r *MemoryAwardRepository) Restock(ctx context.Context, id string, quantity int64) (*models.Award, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	award, exists := r.awards[id]
+	if !exists {
+		return nil, errAwardNotFound
+	}
+
+	award.TotalStock += quantity
+	award.RemainingStock += quantity
+	a := *award
+	return &a, nil
+}
+
+func (r *MemoryAwardRepository) Retire(ctx context.Context, id string) (*models.Award, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	award, exists := r.awards[id]
+	if !exists {
+		return nil, errAwardNotFound
+	}
+
+	award.Active = false
+	a := *award
+	return &a, nil
+}
+
+func (r *MemoryAwardRepository) Reserve(ctx context.Context, awardID, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.checkReserve(awardID, userID); err != nil {
+		return err
+	}
+	r.reserve(awardID, userID)
+	return nil
+}
+
+// checkReserve reports whether userID may take a unit of the award. The
+// caller holds r.mu.
+func (r *MemoryAwardRepository) checkReserve(awardID, userID string) error {
+	award, exists := r.awards[awardID]
+	if !exists {
+		return errAwardNotFound
+	}
+	if award.RemainingStock <= 0 {
+		return ErrOutOfStock
+	}
+	if award.PerUserLimit > 0 && r.held[awardID][userID] >= award.PerUserLimit {
+		return ErrClaimLimitReached
+	}
+	return nil
+}
+
+// reserve takes the unit checkReserve allowed. The caller holds r.mu.
+func (r *MemoryAwardRepository) reserve(awardID, userID string) {
+	held := r.held[awardID]
+	if held == nil {
+		held = make(map[string]int32)
+		r.held[awardID] = held
+	}
+	held[userID]++
+	r.awards[awardID].RemainingStock--
+}
+
+func (r *MemoryAwardRepository) PlaceClaim(ctx context.Context, c *models.Claim, debit *models.LedgerEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.ledger.mu.Lock()
+	defer r.ledger.mu.Unlock()
+
+	if _, exists := r.claims[c.ID]; exists {
+		return errClaimExists
+	}
+	if err := r.checkReserve(c.AwardID, c.UserID); err != nil {
+		return err
+	}
+	if err := r.ledger.check(debit); err != nil {
+		return err
+	}
+
+	r.reserve(c.AwardID, c.UserID)
+	r.ledger.append(debit)
+	claim := *c
+	r.claims[c.ID] = &claim
+	return nil
+}
+
+func (r *MemoryAwardRepository) Release(ctx context.Context, awardID, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	award, exists := r.awards[awardID]
+	if !exists {
+		return errAwardNotFound
+	}
+	if r.held[awardID][userID] <= 0 {
+		return ErrNoReservation
+	}
+	r.held[awardID][userID]--
+	award.RemainingStock++
+	return nil
+}
+
+func (r *MemoryAwardRepository) CreateClaim(ctx context.Context, c *models.Claim) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.claims[c.ID]; exists {
+		return errClaimExists
+	}
+
+	claim := *c
+	r.claims[c.ID] = &claim
+	return nil
+}
+
+func (r *MemoryAwardRepository) GetClaim(ctx context.Context, id string) (*models.Claim, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	claim, exists := r.claims[id]
+	if !exists {
+		return nil, errClaimNotFound
+	}
+
+	c := *claim
+	return &c, nil
+}
+
+func (r *MemoryAwardRepository) ListClaimsByUser(ctx context.Context, userID string) ([]*models.Claim, error) {
+	result := r.filterClaims(func(c *models.Claim) bool { return c.UserID == userID })
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ClaimedAt.After(result[j].ClaimedAt)
+	})
+	return result, nil
+}
+
+func (r *MemoryAwardRepository) ListClaimsByStatus(ctx context.Context, status models.ClaimStatus) ([]*models.Claim, error) {
+	result := r.filterClaims(func(c *models.Claim) bool { return c.Status == status })
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ClaimedAt.Before(result[j].ClaimedAt)
+	})
+	return result, nil
+}
+
+func (r *MemoryAwardRepository) filterClaims(match func(*models.Claim) bool) []*models.Claim {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*models.Claim
+	for _, c := range r.claims {
+		if match(c) {
+			// Return a copy to avoid race conditions
+			cCopy := *c
+			result = append(result, &cCopy)
+		}
+	}
+	return result
+}
+
+func (r *MemoryAwardRepository) SetClaimStatus(ctx context.Context, id string, from, to models.ClaimStatus, note string, at time.Time) (*models.Claim, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	claim, exists := r.claims[id]
+	if !exists {
+		return nil, errClaimNotFound
+	}
+	if claim.Status != from {
+		return nil, ErrClaimStatusChanged
+	}
+
+	claim.Status = to
+	claim.Note = note
+	claim.UpdatedAt = at
+	c := *claim
+	return &c, nil
+}
@@ -0,0 +1,20 @@
+package repository_test
+
+import (
+	"testing"
+
+	"github.com/rprajapati0067/quiz-game-backend/internal/repository"
+	"github.com/rprajapati0067/quiz-game-backend/internal/repository/repotest"
+)
+
+func TestMemoryAwardRepository(t *testing.T) {
+	repotest.TestAwardRepository(t, func(t *testing.T) repository.AwardRepository {
+		return repository.NewMemoryAwardRepository(repository.NewMemoryLedgerRepository())
+	})
+}
+
+func TestMemoryPlaceClaim(t *testing.T) {
+	repotest.TestPlaceClaim(t, func(t *testing.T) repository.Repositories {
+		return repository.NewMemoryRepositories()
+	})
+}
@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rprajapati0067/quiz-game-backend/internal/models"
+)
+
+// snapshotInterval is how many entries may accumulate after a user's last
+// snapshot before a new one is taken.
+const snapshotInterval = 50
+
+type MemoryLedgerRepository struct {
+	mu        sync.RWMutex
+	entries   map[string][]*models.LedgerEntry
+	snapshots map[string]models.BalanceSnapshot
+	// ids indexes each user's entries by ID.
+	ids map[string]map[string]*models.LedgerEntry
+}
+
+func NewMemoryLedgerRepository() *MemoryLedgerRepository {
+	return &MemoryLedgerRepository{
+		entries:   make(map[string][]*models.LedgerEntry),
+		snapshots: make(map[string]models.BalanceSnapshot),
+		ids:       make(map[string]map[string]*models.LedgerEntry),
+	}
+}
+
+func (r *MemoryLedgerRepository) Append(ctx context.Context, e *models.LedgerEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.check(e); err != nil {
+		return err
+	}
+	r.append(e)
+	return nil
+}
+
+// check reports whether e can be appended, filling in e from the entry
+// already recorded under its ID. The caller holds r.mu.
+func (r *MemoryLedgerRepository) check(e *models.LedgerEntry) error {
+	if prev, exists := r.ids[e.UserID][e.ID]; exists {
+		e.Seq = prev.Seq
+		e.Balance = prev.Balance
+		return ErrEntryExists
+	}
+	if r.balance(e.UserID)+e.Signed() < 0 {
+		return ErrInsufficientBalance
+	}
+	return nil
+}
+
+// append records e, which check has accepted. The caller holds r.mu.
+func (r *MemoryLedgerRepository) append(e *models.LedgerEntry) {
+	balance := r.balance(e.UserID) + e.Signed()
+	entries := r.entries[e.UserID]
+	entry := *e
+	entry.Seq = int64(len(entries)) + 1
+	entry.Balance = balance
+	r.entries[e.UserID] = append(entries, &entry)
+	if r.ids[e.UserID] == nil {
+		r.ids[e.UserID] = make(map[string]*models.LedgerEntry)
+	}
+	r.ids[e.UserID][e.ID] = &entry
+
+	if entry.Seq-r.snapshots[e.UserID].Seq >= snapshotInterval {
+		r.snapshots[e.UserID] = models.BalanceSnapshot{
+			UserID:  e.UserID,
+			Seq:     entry.Seq,
+			Balance: balance,
+			TakenAt: time.Now(),
+		}
+	}
+
+	e.Seq = entry.Seq
+	e.Balance = entry.Balance
+}
+
+func (r *MemoryLedgerRepository) Balance(ctx context.Context, userID string) (int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.balance(userID), nil
+}
+
+// balance replays the entries recorded since the user's last snapshot.
+func (r *MemoryLedgerRepository) balance(userID string) int64 {
+	snap := r.snapshots[userID]
+	balance := snap.Balance
+	for _, e := range r.entries[userID][snap.Seq:] {
+		balance += e.Signed()
+	}
+	return balance
+}
+
+func (r *MemoryLedgerRepository) List(ctx context.Context, userID string, beforeSeq int64, limit int) ([]*models.LedgerEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entries := r.entries[userID]
+	end := int64(len(entries))
+	if beforeSeq > 0 && beforeSeq-1 < end {
+		end = beforeSeq - 1
+	}
+
+	result := make([]*models.LedgerEntry, 0, limit)
+	for i := end - 1; i >= 0 && len(result) < limit; i-- {
+		// Return a copy to avoid race conditions
+		e := *entries[i]
+		result = append(result, &e)
+	}
+	return result, nil
+}
@@ -0,0 +1,14 @@
+package repository_test
+
+import (
+	"testing"
+
+	"github.com/rprajapati0067/quiz-game-backend/internal/repository"
+	"github.com/rprajapati0067/quiz-game-backend/internal/repository/repotest"
+)
+
+func TestMemoryLedgerRepository(t *testing.T) {
+	repotest.TestLedgerRepository(t, func(t *testing.T) repository.LedgerRepository {
+		return repository.NewMemoryLedgerRepository()
+	})
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+	"sync"
+
+	"github.com/rprajapati0067/quiz-game-backend/internal/models"
+)
+
+type MemoryOTPRepository struct {
+	mu   sync.Mutex
+	otps map[string]*models.OTP
+}
+
+func NewMemoryOTPRepository() *MemoryOTPRepository {
+	return &MemoryOTPRepository{
+		otps: make(map[string]*models.OTP),
+	}
+}
+
+func (r *MemoryOTPRepository) Save(ctx context.Context, o, prev *models.OTP) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current, exists := r.otps[o.Phone]
+	if exists != (prev != nil) || exists && !sameOTP(current, prev) {
+		return ErrOTPChanged
+	}
+	otp := *o
+	r.otps[o.Phone] = &otp
+	return nil
+}
+
+func (r *MemoryOTPRepository) GetByPhone(ctx context.Context, phone string) (*models.OTP, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	otp, exists := r.otps[phone]
+	if !exists {
+		return nil, nil
+	}
+
+	// Return a copy to avoid race conditions
+	o := *otp
+	return &o, nil
+}
+
+func (r *MemoryOTPRepository) UseAttempt(ctx context.Context, phone string, max int32) (*models.OTP, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	otp, exists := r.otps[phone]
+	if !exists {
+		return nil, nil
+	}
+	if otp.Attempts >= max {
+		return nil, ErrOTPAttemptsExhausted
+	}
+
+	otp.Attempts++
+	o := *otp
+	return &o, nil
+}
+
+func (r *MemoryOTPRepository) Delete(ctx context.Context, phone string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.otps, phone)
+	return nil
+}
+
+// sameOTP reports whether a and b are the same code at the same attempt
+// count, which is what Save compares.
+func sameOTP(a, b *models.OTP) bool {
+	return a.CodeHash == b.CodeHash && a.Attempts == b.Attempts
+}
@@ -22,7 +22,13 @@ func (r *MemoryQuestionRepository) Create(ctx context.Context, q *models.Questio
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	r.questions[q.ID] = q
+	if _, exists := r.questions[q.ID]; exists {
+		return errQuestionExists
+	}
+
+	question := *q
+	question.Options = append([]string(nil), q.Options...)
+	r.questions[q.ID] = &question
 	return nil
 }
 
@@ -35,6 +41,7 @@ func (r *MemoryQuestionRepository) ListBySlot(ctx context.Context, slot int32) (
 		if q.Slot == slot {
 			// Return a copy to avoid race conditions
 			qCopy := *q
+			qCopy.Options = append([]string(nil), q.Options...)
 			result = append(result, &qCopy)
 		}
 	}
@@ -47,11 +54,12 @@ func (r *MemoryQuestionRepository) GetByID(ctx context.Context, id string) (*mod
 
 	question, exists := r.questions[id]
 	if !exists {
-		return nil, nil
+		return nil, errQuestionNotFound
 	}
-	
+
 	// Return a copy to avoid race conditions
 	q := *question
+	q.Options = append([]string(nil), question.Options...)
 	return &q, nil
 }
 
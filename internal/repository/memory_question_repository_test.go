@@ -0,0 +1,14 @@
+package repository_test
+
+import (
+	"testing"
+
+	"github.com/rprajapati0067/quiz-game-backend/internal/repository"
+	"github.com/rprajapati0067/quiz-game-backend/internal/repository/repotest"
+)
+
+func TestMemoryQuestionRepository(t *testing.T) {
+	repotest.TestQuestionRepository(t, func(t *testing.T) repository.QuestionRepository {
+		return repository.NewMemoryQuestionRepository()
+	})
+}
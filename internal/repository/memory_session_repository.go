@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/rprajapati0067/quiz-game-backend/internal/models"
+)
+
+type MemorySessionRepository struct {
+	mu       sync.RWMutex
+	sessions map[string]*models.Session
+	tokens   map[string]*models.RefreshToken
+}
+
+func NewMemorySessionRepository() *MemorySessionRepository {
+	return &MemorySessionRepository{
+		sessions: make(map[string]*models.Session),
+		tokens:   make(map[string]*models.RefreshToken),
+	}
+}
+
+func (r *MemorySessionRepository) Create(ctx context.Context, s *models.Session, t *models.RefreshToken) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.sessions[s.ID]; exists {
+		return errSessionExists
+	}
+
+	session := *s
+	token := *t
+	r.sessions[s.ID] = &session
+	r.tokens[t.Hash] = &token
+	return nil
+}
+
+func (r *MemorySessionRepository) GetByID(ctx context.Context, id string) (*models.Session, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	session, exists := r.sessions[id]
+	if !exists {
+		return nil, nil
+	}
+
+	// Return a copy to avoid race conditions
+	s := *session
+	return &s, nil
+}
+
+func (r *MemorySessionRepository) ListByUser(ctx context.Context, userID string) ([]*models.Session, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*models.Session
+	for _, s := range r.sessions {
+		if s.UserID == userID {
+			sCopy := *s
+			result = append(result, &sCopy)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.Before(result[j].CreatedAt)
+	})
+	return result, nil
+}
+
+func (r *MemorySessionRepository) Revoke(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	session, exists := r.sessions[id]
+	if !exists {
+		return errSessionNotFound
+	}
+
+	session.Revoked = true
+	return nil
+}
+
+func (r *MemorySessionRepository) GetRefreshToken(ctx context.Context, hash string) (*models.RefreshToken, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	token, exists := r.tokens[hash]
+	if !exists {
+		return nil, nil
+	}
+
+	t := *token
+	return &t, nil
+}
+
+func (r *MemorySessionRepository) Rotate(ctx context.Context, oldHash string, next *models.RefreshToken) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	old, exists := r.tokens[oldHash]
+	if !exists {
+		return errTokenNotFound
+	}
+	if old.Rotated {
+		return ErrRefreshTokenReused
+	}
+
+	old.Rotated = true
+	token := *next
+	r.tokens[next.Hash] = &token
+	if session, exists := r.sessions[next.SessionID]; exists {
+		session.LastUsedAt = next.IssuedAt
+	}
+	return nil
+}
@@ -2,7 +2,6 @@ package repository
 
 import (
 	"context"
-	"errors"
 	"sync"
 
 	"github.com/rprajapati0067/quiz-game-backend/internal/models"
@@ -26,14 +25,16 @@ func (r *MemoryUserRepository) CreateUser(ctx context.Context, u *models.User) e
 	defer r.mu.Unlock()
 
 	if _, exists := r.users[u.ID]; exists {
-		return errors.New("user already exists")
+		return errUserExists
 	}
 	if _, exists := r.byPhone[u.Phone]; exists {
-		return errors.New("phone number already registered")
+		return errPhoneTaken
 	}
 
-	r.users[u.ID] = u
-	r.byPhone[u.Phone] = u
+	user := *u
+	user.Roles = append([]models.Role(nil), u.Roles...)
+	r.users[u.ID] = &user
+	r.byPhone[u.Phone] = &user
 	return nil
 }
 
@@ -43,11 +44,12 @@ func (r *MemoryUserRepository) GetByPhone(ctx context.Context, phone string) (*m
 
 	user, exists := r.byPhone[phone]
 	if !exists {
-		return nil, nil
+		return nil, errUserNotFound
 	}
-	
+
 	// Return a copy to avoid race conditions
 	u := *user
+	u.Roles = append([]models.Role(nil), user.Roles...)
 	return &u, nil
 }
 
@@ -57,11 +59,12 @@ func (r *MemoryUserRepository) GetByID(ctx context.Context, id string) (*models.
 
 	user, exists := r.users[id]
 	if !exists {
-		return nil, nil
+		return nil, errUserNotFound
 	}
-	
+
 	// Return a copy to avoid race conditions
 	u := *user
+	u.Roles = append([]models.Role(nil), user.Roles...)
 	return &u, nil
 }
 
@@ -69,12 +72,25 @@ func (r *MemoryUserRepository) Update(ctx context.Context, u *models.User) error
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	if _, exists := r.users[u.ID]; !exists {
-		return errors.New("user not found")
+	stored, exists := r.users[u.ID]
+	if !exists {
+		return errUserNotFound
+	}
+	if stored.Version != u.Version {
+		return ErrVersionConflict
+	}
+	if u.Phone != stored.Phone {
+		if _, taken := r.byPhone[u.Phone]; taken {
+			return errPhoneTaken
+		}
+		delete(r.byPhone, stored.Phone)
 	}
 
-	r.users[u.ID] = u
-	r.byPhone[u.Phone] = u
+	next := *u
+	next.Roles = append([]models.Role(nil), u.Roles...)
+	next.Version++
+	r.users[u.ID] = &next
+	r.byPhone[u.Phone] = &next
+	u.Version = next.Version
 	return nil
 }
-
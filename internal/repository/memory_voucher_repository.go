@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rprajapati0067/quiz-game-backend/internal/models"
+)
+
+// MemoryVoucherRepository keeps codes in memory. Adding codes restocks the
+// linked award in the award repository it was created with.
+type MemoryVoucherRepository struct {
+	mu     sync.Mutex
+	pools  map[string]*voucherPool
+	awards *MemoryAwardRepository
+}
+
+// voucherPool keeps unassigned codes in upload order so they are handed out
+// first in, first out.
+type voucherPool struct {
+	codes map[string]*models.Voucher
+	free  []string
+}
+
+func NewMemoryVoucherRepository(awards *MemoryAwardRepository) *MemoryVoucherRepository {
+	return &MemoryVoucherRepository{
+		pools:  make(map[string]*voucherPool),
+		awards: awards,
+	}
+}
+
+func (r *MemoryVoucherRepository) Add(ctx context.Context, awardID string, codes []string, at time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Awards are never deleted, so once found the restock below cannot fail.
+	if _, err := r.awards.GetByID(ctx, awardID); err != nil {
+		return 0, err
+	}
+	pool, exists := r.pools[awardID]
+	if !exists {
+		pool = &voucherPool{codes: make(map[string]*models.Voucher)}
+		r.pools[awardID] = pool
+	}
+
+	added := 0
+	for _, code := range codes {
+		if _, exists := pool.codes[code]; exists {
+			continue
+		}
+		pool.codes[code] = &models.Voucher{AwardID: awardID, Code: code, UploadedAt: at}
+		pool.free = append(pool.free, code)
+		added++
+	}
+	if added > 0 {
+		if _, err := r.awards.Restock(ctx, awardID, int64(added)); err != nil {
+			return 0, err
+		}
+	}
+	return added, nil
+}
+
+func (r *MemoryVoucherRepository) Assign(ctx context.Context, awardID, claimID, userID string, at time.Time) (*models.Voucher, int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	pool, exists := r.pools[awardID]
+	if !exists || len(pool.free) == 0 {
+		return nil, 0, ErrNoVoucherAvailable
+	}
+
+	code := pool.free[0]
+	pool.free = pool.free[1:]
+	v := pool.codes[code]
+	v.ClaimID = claimID
+	v.UserID = userID
+	v.AssignedAt = at
+
+	voucher := *v
+	return &voucher, len(pool.free), nil
+}
+
+func (r *MemoryVoucherRepository) Unassign(ctx context.Context, awardID, claimID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	pool, exists := r.pools[awardID]
+	if !exists {
+		return errVoucherNotFound
+	}
+	for code, v := range pool.codes {
+		if v.ClaimID == claimID {
+			v.ClaimID = ""
+			v.UserID = ""
+			v.AssignedAt = time.Time{}
+			pool.free = append(pool.free, code)
+			return nil
+		}
+	}
+	return errVoucherNotFound
+}
+
+func (r *MemoryVoucherRepository) Available(ctx context.Context, awardID string) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	pool, exists := r.pools[awardID]
+	if !exists {
+		return 0, nil
+	}
+	return len(pool.free), nil
+}
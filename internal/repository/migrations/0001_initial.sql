@@ -0,0 +1,136 @@
+-- Times are stored as Unix nanoseconds, with 0 for an unset time.
+
+CREATE TABLE users (
+    id       TEXT PRIMARY KEY,
+    name     TEXT NOT NULL,
+    phone    TEXT NOT NULL UNIQUE,
+    email    TEXT NOT NULL DEFAULT '',
+    verified BOOLEAN NOT NULL DEFAULT FALSE,
+    blocked  BOOLEAN NOT NULL DEFAULT FALSE,
+    roles    TEXT NOT NULL DEFAULT '[]',
+    version  BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE TABLE otps (
+    phone      TEXT PRIMARY KEY,
+    code_hash  TEXT NOT NULL,
+    attempts   INTEGER NOT NULL DEFAULT 0,
+    expires_at BIGINT NOT NULL,
+    created_at BIGINT NOT NULL
+);
+
+CREATE TABLE sessions (
+    id           TEXT PRIMARY KEY,
+    user_id      TEXT NOT NULL,
+    user_agent   TEXT NOT NULL DEFAULT '',
+    created_at   BIGINT NOT NULL,
+    last_used_at BIGINT NOT NULL,
+    expires_at   BIGINT NOT NULL,
+    revoked      BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+CREATE INDEX sessions_user_id ON sessions (user_id);
+
+CREATE TABLE refresh_tokens (
+    token_hash TEXT PRIMARY KEY,
+    session_id TEXT NOT NULL,
+    user_id    TEXT NOT NULL,
+    issued_at  BIGINT NOT NULL,
+    expires_at BIGINT NOT NULL,
+    rotated    BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+CREATE TABLE questions (
+    id            TEXT PRIMARY KEY,
+    text          TEXT NOT NULL,
+    options       TEXT NOT NULL,
+    correct_index INTEGER NOT NULL,
+    slot          INTEGER NOT NULL,
+    created_by    TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX questions_slot ON questions (slot);
+
+CREATE TABLE answers (
+    user_id         TEXT NOT NULL,
+    question_id     TEXT NOT NULL,
+    selected_index  INTEGER NOT NULL,
+    submitted_at    BIGINT NOT NULL,
+    correct         BOOLEAN NOT NULL,
+    points_awarded  BIGINT NOT NULL,
+    updated_points  BIGINT NOT NULL,
+    idempotency_key TEXT NOT NULL DEFAULT '',
+    PRIMARY KEY (user_id, question_id)
+);
+
+CREATE INDEX answers_idempotency_key ON answers (user_id, idempotency_key);
+
+CREATE TABLE awards (
+    id              TEXT PRIMARY KEY,
+    product         TEXT NOT NULL,
+    point_cost      BIGINT NOT NULL,
+    total_stock     BIGINT NOT NULL,
+    remaining_stock BIGINT NOT NULL,
+    per_user_limit  INTEGER NOT NULL DEFAULT 0,
+    available_from  BIGINT NOT NULL DEFAULT 0,
+    available_until BIGINT NOT NULL DEFAULT 0,
+    active          BOOLEAN NOT NULL,
+    digital         BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+CREATE TABLE award_holds (
+    award_id TEXT NOT NULL,
+    user_id  TEXT NOT NULL,
+    held     INTEGER NOT NULL,
+    PRIMARY KEY (award_id, user_id)
+);
+
+CREATE TABLE claims (
+    id           TEXT PRIMARY KEY,
+    user_id      TEXT NOT NULL,
+    award_id     TEXT NOT NULL,
+    points       BIGINT NOT NULL,
+    claimed_at   BIGINT NOT NULL,
+    status       TEXT NOT NULL,
+    note         TEXT NOT NULL DEFAULT '',
+    updated_at   BIGINT NOT NULL,
+    voucher_code TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX claims_user_id ON claims (user_id, claimed_at);
+CREATE INDEX claims_status ON claims (status, claimed_at);
+
+CREATE TABLE ledger_entries (
+    user_id    TEXT NOT NULL,
+    seq        BIGINT NOT NULL,
+    id         TEXT NOT NULL,
+    kind       TEXT NOT NULL,
+    amount     BIGINT NOT NULL,
+    reason     TEXT NOT NULL,
+    ref_type   TEXT NOT NULL DEFAULT '',
+    ref_id     TEXT NOT NULL DEFAULT '',
+    balance    BIGINT NOT NULL,
+    created_at BIGINT NOT NULL,
+    PRIMARY KEY (user_id, seq)
+);
+
+CREATE TABLE balances (
+    user_id  TEXT PRIMARY KEY,
+    seq      BIGINT NOT NULL,
+    balance  BIGINT NOT NULL,
+    taken_at BIGINT NOT NULL
+);
+
+CREATE TABLE vouchers (
+    award_id    TEXT NOT NULL,
+    code        TEXT NOT NULL,
+    position    BIGINT NOT NULL,
+    claim_id    TEXT NOT NULL DEFAULT '',
+    user_id     TEXT NOT NULL DEFAULT '',
+    uploaded_at BIGINT NOT NULL,
+    assigned_at BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (award_id, code)
+);
+
+CREATE INDEX vouchers_free ON vouchers (award_id, claim_id, position);
+CREATE INDEX vouchers_claim_id ON vouchers (claim_id);
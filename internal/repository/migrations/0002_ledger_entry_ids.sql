@@ -0,0 +1,3 @@
+-- Entry IDs are unique per user so appends can be made idempotent.
+
+CREATE UNIQUE INDEX ledger_entries_user_entry ON ledger_entries (user_id, id);
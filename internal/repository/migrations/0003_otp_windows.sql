@@ -0,0 +1,3 @@
+-- Attempts add up across codes reissued within a window that starts here.
+
+ALTER TABLE otps ADD COLUMN window_start BIGINT NOT NULL DEFAULT 0;
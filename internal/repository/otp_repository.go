@@ -0,0 +1,27 @@
+package repository
+
+import (
+    "context"
+    "errors"
+
+    "github.com/rprajapati0067/quiz-game-backend/internal/models"
+)
+
+var (
+    ErrOTPChanged           = conflict("otp changed concurrently")
+    ErrOTPAttemptsExhausted = errors.New("otp attempts exhausted")
+)
+
+type OTPRepository interface {
+    // Save stores o, replacing the outstanding code for the same phone,
+    // provided that code is still prev as read by GetByPhone, or that there
+    // is none if prev is nil. Otherwise it fails with ErrOTPChanged.
+    Save(ctx context.Context, o, prev *models.OTP) error
+    GetByPhone(ctx context.Context, phone string) (*models.OTP, error)
+    // UseAttempt atomically counts one verification attempt against the
+    // phone's code, provided fewer than max have been made, and returns
+    // the code with the attempt counted. It returns nil if there is no
+    // code and fails with ErrOTPAttemptsExhausted once max were made.
+    UseAttempt(ctx context.Context, phone string, max int32) (*models.OTP, error)
+    Delete(ctx context.Context, phone string) error
+}
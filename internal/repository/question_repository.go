@@ -6,6 +6,8 @@ import (
     "github.com/rprajapati0067/quiz-game-backend/internal/models"
 )
 
+// QuestionRepository reports a missing question with an error matching
+// ErrNotFound and a duplicate ID with one matching ErrConflict.
 type QuestionRepository interface {
     Create(ctx context.Context, q *models.Question) error
     ListBySlot(ctx context.Context, slot int32) ([]*models.Question, error)
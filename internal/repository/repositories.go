@@ -0,0 +1,96 @@
+package repository
+
+import (
+    "context"
+    "database/sql"
+
+    "github.com/aws/aws-sdk-go-v2/aws"
+    "github.com/aws/aws-sdk-go-v2/service/dynamodb"
+)
+
+// Repositories bundles one implementation of every repository so a storage
+// backend can be chosen in one place.
+type Repositories struct {
+    Users     UserRepository
+    Questions QuestionRepository
+    Answers   AnswerRepository
+    Awards    AwardRepository
+    Ledger    LedgerRepository
+    Vouchers  VoucherRepository
+    Sessions  SessionRepository
+    OTPs      OTPRepository
+
+    // ping and close reach the backend's connections, if it holds any.
+    ping  func(ctx context.Context) error
+    close func() error
+}
+
+// Check reports whether the backend is reachable.
+func (r Repositories) Check(ctx context.Context) error {
+    if r.ping == nil {
+        return nil
+    }
+    return r.ping(ctx)
+}
+
+// Close releases the connections behind the repositories once the server
+// has stopped using them. Every write is committed before its call
+// returns, so there is nothing else to flush.
+func (r Repositories) Close() error {
+    if r.close == nil {
+        return nil
+    }
+    return r.close()
+}
+
+// NewMemoryRepositories keeps everything in process memory, which is lost
+// on restart.
+func NewMemoryRepositories() Repositories {
+    ledger := NewMemoryLedgerRepository()
+    awards := NewMemoryAwardRepository(ledger)
+    return Repositories{
+        Users:     NewMemoryUserRepository(),
+        Questions: NewMemoryQuestionRepository(),
+        Answers:   NewMemoryAnswerRepository(),
+        Awards:    awards,
+        Ledger:    ledger,
+        Vouchers:  NewMemoryVoucherRepository(awards),
+        Sessions:  NewMemorySessionRepository(),
+        OTPs:      NewMemoryOTPRepository(),
+    }
+}
+
+func NewDynamoRepositories(client *dynamodb.Client, tables DynamoTables) Repositories {
+    ledger := NewDynamoLedgerRepository(client, tables.Ledger, tables.LedgerIDs, tables.Balances)
+    return Repositories{
+        Users:     NewDynamoUserRepository(client, tables.Users),
+        Questions: NewDynamoQuestionRepository(client, tables.Questions),
+        Answers:   NewDynamoAnswerRepository(client, tables.Answers),
+        Awards:    NewDynamoAwardRepository(client, tables.Awards, tables.AwardHolds, tables.Claims, ledger),
+        Ledger:    ledger,
+        Vouchers:  NewDynamoVoucherRepository(client, tables.Vouchers, tables.Awards),
+        Sessions:  NewDynamoSessionRepository(client, tables.Sessions, tables.RefreshTokens),
+        OTPs:      NewDynamoOTPRepository(client, tables.OTPs),
+        ping: func(ctx context.Context) error {
+            _, err := client.DescribeTable(ctx, &dynamodb.DescribeTableInput{TableName: aws.String(tables.Users)})
+            return err
+        },
+    }
+}
+
+// NewSQLRepositories expects a database opened with OpenSQL and brought up
+// to date with Migrate.
+func NewSQLRepositories(db *sql.DB) Repositories {
+    return Repositories{
+        Users:     NewSQLUserRepository(db),
+        Questions: NewSQLQuestionRepository(db),
+        Answers:   NewSQLAnswerRepository(db),
+        Awards:    NewSQLAwardRepository(db),
+        Ledger:    NewSQLLedgerRepository(db),
+        Vouchers:  NewSQLVoucherRepository(db),
+        Sessions:  NewSQLSessionRepository(db),
+        OTPs:      NewSQLOTPRepository(db),
+        ping:      db.PingContext,
+        close:     db.Close,
+    }
+}
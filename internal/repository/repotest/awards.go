@@ -0,0 +1,363 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/rprajapati0067/quiz-game-backend/internal/models"
+	"github.com/rprajapati0067/quiz-game-backend/internal/repository"
+)
+
+// TestAwardRepository runs the AwardRepository contract, covering awards,
+// stock reservations and claims, against stores made by newRepo. Each
+// subtest gets its own store.
+func TestAwardRepository(t *testing.T, newRepo func(t *testing.T) repository.AwardRepository) {
+	ctx := context.Background()
+	newAward := func(cost, stock int64, limit int32) *models.Award {
+		return &models.Award{
+			ID:             newID(),
+			Product:        "mug",
+			PointCost:      cost,
+			TotalStock:     stock,
+			RemainingStock: stock,
+			PerUserLimit:   limit,
+			AvailableFrom:  at(0),
+			Active:         true,
+		}
+	}
+	newClaim := func(userID string, claimed int) *models.Claim {
+		return &models.Claim{
+			ID:        newID(),
+			UserID:    userID,
+			AwardID:   newID(),
+			Points:    10,
+			ClaimedAt: at(claimed),
+			Status:    models.ClaimPending,
+			UpdatedAt: at(claimed),
+		}
+	}
+
+	t.Run("CreateAndGet", func(t *testing.T) {
+		repo := newRepo(t)
+		a := newAward(10, 3, 1)
+		noErr(t, "create", repo.Create(ctx, a))
+
+		got, err := repo.GetByID(ctx, a.ID)
+		noErr(t, "get", err)
+		sameAward(t, got, a)
+	})
+
+	t.Run("DuplicateID", func(t *testing.T) {
+		repo := newRepo(t)
+		a := newAward(10, 3, 0)
+		noErr(t, "create", repo.Create(ctx, a))
+
+		dup := newAward(20, 1, 0)
+		dup.ID = a.ID
+		wantErr(t, "create duplicate", repo.Create(ctx, dup), repository.ErrConflict)
+
+		c := newClaim(newID(), 0)
+		noErr(t, "create claim", repo.CreateClaim(ctx, c))
+		wantErr(t, "create duplicate claim", repo.CreateClaim(ctx, c), repository.ErrConflict)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		repo := newRepo(t)
+		missing := newAward(10, 1, 0)
+		_, err := repo.GetByID(ctx, missing.ID)
+		wantErr(t, "get", err, repository.ErrNotFound)
+		wantErr(t, "update", repo.Update(ctx, missing), repository.ErrNotFound)
+		_, err = repo.Restock(ctx, missing.ID, 1)
+		wantErr(t, "restock", err, repository.ErrNotFound)
+		wantErr(t, "reserve", repo.Reserve(ctx, missing.ID, newID()), repository.ErrNotFound)
+		wantErr(t, "release", repo.Release(ctx, missing.ID, newID()), repository.ErrNotFound)
+
+		_, err = repo.GetClaim(ctx, newID())
+		wantErr(t, "get claim", err, repository.ErrNotFound)
+		_, err = repo.SetClaimStatus(ctx, newID(), models.ClaimPending, models.ClaimApproved, "", at(1))
+		wantErr(t, "set claim status", err, repository.ErrNotFound)
+	})
+
+	t.Run("CopyOnRead", func(t *testing.T) {
+		repo := newRepo(t)
+		a := newAward(10, 3, 0)
+		noErr(t, "create", repo.Create(ctx, a))
+		a.Product = "changed after create"
+
+		got, err := repo.GetByID(ctx, a.ID)
+		noErr(t, "get", err)
+		got.RemainingStock = 0
+		list, err := repo.List(ctx)
+		noErr(t, "list", err)
+		list[0].Active = false
+
+		again, err := repo.GetByID(ctx, a.ID)
+		noErr(t, "get again", err)
+		if again.Product != "mug" || again.RemainingStock != 3 || !again.Active {
+			t.Fatalf("stored award changed through a shared value: %+v", again)
+		}
+	})
+
+	t.Run("List", func(t *testing.T) {
+		repo := newRepo(t)
+		for _, cost := range []int64{30, 10, 20} {
+			noErr(t, "create", repo.Create(ctx, newAward(cost, 1, 0)))
+		}
+		list, err := repo.List(ctx)
+		noErr(t, "list", err)
+		if len(list) != 3 || list[0].PointCost != 10 || list[1].PointCost != 20 || list[2].PointCost != 30 {
+			t.Fatalf("list not ordered by point cost: %+v", list)
+		}
+	})
+
+	t.Run("UpdateKeepsStockAndStatus", func(t *testing.T) {
+		repo := newRepo(t)
+		a := newAward(10, 3, 0)
+		noErr(t, "create", repo.Create(ctx, a))
+
+		a.Product = "poster"
+		a.PointCost = 15
+		a.PerUserLimit = 2
+		a.AvailableUntil = at(100)
+		a.Active = false
+		a.Digital = true
+		a.TotalStock = 99
+		a.RemainingStock = 99
+		noErr(t, "update", repo.Update(ctx, a))
+
+		got, err := repo.GetByID(ctx, a.ID)
+		noErr(t, "get", err)
+		a.TotalStock = 3
+		a.RemainingStock = 3
+		a.Active = true
+		a.Digital = false
+		sameAward(t, got, a)
+	})
+
+	t.Run("Retire", func(t *testing.T) {
+		repo := newRepo(t)
+		a := newAward(10, 3, 0)
+		noErr(t, "create", repo.Create(ctx, a))
+		stale, err := repo.GetByID(ctx, a.ID)
+		noErr(t, "get", err)
+
+		got, err := repo.Retire(ctx, a.ID)
+		noErr(t, "retire", err)
+		if got.Active || got.ID != a.ID || got.RemainingStock != 3 {
+			t.Fatalf("retire returned %+v, want the inactive award", got)
+		}
+		// An update made from a read before Retire must not reactivate it.
+		stale.PointCost = 20
+		noErr(t, "update", repo.Update(ctx, stale))
+		got, err = repo.Retire(ctx, a.ID)
+		noErr(t, "retire again", err)
+		if got.Active || got.PointCost != 20 {
+			t.Fatalf("after a stale update: %+v, want retired at cost 20", got)
+		}
+
+		_, err = repo.Retire(ctx, newID())
+		wantErr(t, "retire missing", err, repository.ErrNotFound)
+	})
+
+	t.Run("Restock", func(t *testing.T) {
+		repo := newRepo(t)
+		a := newAward(10, 2, 0)
+		noErr(t, "create", repo.Create(ctx, a))
+		noErr(t, "reserve", repo.Reserve(ctx, a.ID, newID()))
+
+		got, err := repo.Restock(ctx, a.ID, 5)
+		noErr(t, "restock", err)
+		if got.TotalStock != 7 || got.RemainingStock != 6 {
+			t.Fatalf("after restock: total %d, remaining %d; want 7 and 6", got.TotalStock, got.RemainingStock)
+		}
+	})
+
+	t.Run("ReserveAndRelease", func(t *testing.T) {
+		repo := newRepo(t)
+		a := newAward(10, 1, 0)
+		noErr(t, "create", repo.Create(ctx, a))
+		user := newID()
+
+		noErr(t, "reserve", repo.Reserve(ctx, a.ID, user))
+		wantErr(t, "reserve without stock", repo.Reserve(ctx, a.ID, newID()), repository.ErrOutOfStock)
+		noErr(t, "release", repo.Release(ctx, a.ID, user))
+		noErr(t, "reserve released unit", repo.Reserve(ctx, a.ID, newID()))
+	})
+
+	t.Run("ReleaseWithoutReservation", func(t *testing.T) {
+		repo := newRepo(t)
+		a := newAward(10, 2, 0)
+		noErr(t, "create", repo.Create(ctx, a))
+		user := newID()
+
+		wantErr(t, "release never reserved", repo.Release(ctx, a.ID, user), repository.ErrNoReservation)
+		noErr(t, "reserve", repo.Reserve(ctx, a.ID, user))
+		wantErr(t, "release by another user", repo.Release(ctx, a.ID, newID()), repository.ErrNoReservation)
+		noErr(t, "release", repo.Release(ctx, a.ID, user))
+		wantErr(t, "release twice", repo.Release(ctx, a.ID, user), repository.ErrNoReservation)
+
+		got, err := repo.GetByID(ctx, a.ID)
+		noErr(t, "get", err)
+		if got.TotalStock != 2 || got.RemainingStock != 2 {
+			t.Fatalf("after releases: total %d, remaining %d; want 2 and 2", got.TotalStock, got.RemainingStock)
+		}
+	})
+
+	t.Run("PerUserLimit", func(t *testing.T) {
+		repo := newRepo(t)
+		a := newAward(10, 10, 2)
+		noErr(t, "create", repo.Create(ctx, a))
+		user := newID()
+
+		noErr(t, "first reserve", repo.Reserve(ctx, a.ID, user))
+		noErr(t, "second reserve", repo.Reserve(ctx, a.ID, user))
+		wantErr(t, "third reserve", repo.Reserve(ctx, a.ID, user), repository.ErrClaimLimitReached)
+		noErr(t, "other user", repo.Reserve(ctx, a.ID, newID()))
+
+		got, err := repo.GetByID(ctx, a.ID)
+		noErr(t, "get", err)
+		if got.RemainingStock != 7 {
+			t.Fatalf("remaining stock %d, want 7; a refused reservation took stock", got.RemainingStock)
+		}
+
+		noErr(t, "release", repo.Release(ctx, a.ID, user))
+		noErr(t, "reserve after release", repo.Reserve(ctx, a.ID, user))
+	})
+
+	t.Run("ConcurrentReserve", func(t *testing.T) {
+		repo := newRepo(t)
+		const stock = 5
+		a := newAward(10, stock, 0)
+		noErr(t, "create", repo.Create(ctx, a))
+
+		var reserved int
+		var mu sync.Mutex
+		var wg sync.WaitGroup
+		for i := 0; i < concurrency; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				err := repo.Reserve(ctx, a.ID, newID())
+				mu.Lock()
+				defer mu.Unlock()
+				switch {
+				case err == nil:
+					reserved++
+				case !errors.Is(err, repository.ErrOutOfStock):
+					t.Errorf("reserve: %v", err)
+				}
+			}()
+		}
+		wg.Wait()
+
+		got, err := repo.GetByID(ctx, a.ID)
+		noErr(t, "get", err)
+		if reserved != stock || got.RemainingStock != 0 {
+			t.Fatalf("%d reservations left %d in stock; want %d and 0", reserved, got.RemainingStock, stock)
+		}
+	})
+
+	t.Run("Claims", func(t *testing.T) {
+		repo := newRepo(t)
+		user := newID()
+		older := newClaim(user, 1)
+		newer := newClaim(user, 2)
+		other := newClaim(newID(), 0)
+		for _, c := range []*models.Claim{older, newer, other} {
+			noErr(t, "create claim", repo.CreateClaim(ctx, c))
+		}
+
+		got, err := repo.GetClaim(ctx, older.ID)
+		noErr(t, "get claim", err)
+		sameClaim(t, got, older)
+
+		mine, err := repo.ListClaimsByUser(ctx, user)
+		noErr(t, "list by user", err)
+		if len(mine) != 2 || mine[0].ID != newer.ID || mine[1].ID != older.ID {
+			t.Fatalf("claims by user not newest first: %+v", mine)
+		}
+		pending, err := repo.ListClaimsByStatus(ctx, models.ClaimPending)
+		noErr(t, "list by status", err)
+		if len(pending) != 3 || pending[0].ID != other.ID || pending[2].ID != newer.ID {
+			t.Fatalf("pending claims not oldest first: %+v", pending)
+		}
+	})
+
+	t.Run("SetClaimStatus", func(t *testing.T) {
+		repo := newRepo(t)
+		c := newClaim(newID(), 0)
+		noErr(t, "create claim", repo.CreateClaim(ctx, c))
+
+		got, err := repo.SetClaimStatus(ctx, c.ID, models.ClaimPending, models.ClaimApproved, "ok", at(5))
+		noErr(t, "approve", err)
+		if got.Status != models.ClaimApproved || got.Note != "ok" {
+			t.Fatalf("approve returned %+v", got)
+		}
+		sameTime(t, "updated at", got.UpdatedAt, at(5))
+
+		_, err = repo.SetClaimStatus(ctx, c.ID, models.ClaimPending, models.ClaimRejected, "", at(6))
+		wantErr(t, "reject from stale status", err, repository.ErrConflict)
+		wantErr(t, "reject from stale status", err, repository.ErrClaimStatusChanged)
+
+		approved, err := repo.ListClaimsByStatus(ctx, models.ClaimApproved)
+		noErr(t, "list approved", err)
+		pending, err := repo.ListClaimsByStatus(ctx, models.ClaimPending)
+		noErr(t, "list pending", err)
+		if len(approved) != 1 || len(pending) != 0 {
+			t.Fatalf("status lists not updated: %d approved, %d pending", len(approved), len(pending))
+		}
+	})
+
+	t.Run("ConcurrentClaimTransitions", func(t *testing.T) {
+		repo := newRepo(t)
+		c := newClaim(newID(), 0)
+		noErr(t, "create claim", repo.CreateClaim(ctx, c))
+
+		var moved int
+		var mu sync.Mutex
+		var wg sync.WaitGroup
+		for i := 0; i < concurrency; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := repo.SetClaimStatus(ctx, c.ID, models.ClaimPending, models.ClaimApproved, "", at(1))
+				mu.Lock()
+				defer mu.Unlock()
+				switch {
+				case err == nil:
+					moved++
+				case !errors.Is(err, repository.ErrConflict):
+					t.Errorf("approve: %v", err)
+				}
+			}()
+		}
+		wg.Wait()
+		if moved != 1 {
+			t.Fatalf("%d concurrent approvals succeeded, want 1", moved)
+		}
+	})
+}
+
+func sameAward(t *testing.T, got, want *models.Award) {
+	t.Helper()
+	g, w := *got, *want
+	sameTime(t, "available from", g.AvailableFrom, w.AvailableFrom)
+	sameTime(t, "available until", g.AvailableUntil, w.AvailableUntil)
+	g.AvailableFrom, g.AvailableUntil = w.AvailableFrom, w.AvailableUntil
+	if g != w {
+		t.Fatalf("got award %+v, want %+v", g, w)
+	}
+}
+
+func sameClaim(t *testing.T, got, want *models.Claim) {
+	t.Helper()
+	g, w := *got, *want
+	sameTime(t, "claimed at", g.ClaimedAt, w.ClaimedAt)
+	sameTime(t, "updated at", g.UpdatedAt, w.UpdatedAt)
+	g.ClaimedAt, g.UpdatedAt = w.ClaimedAt, w.UpdatedAt
+	if g != w {
+		t.Fatalf("got claim %+v, want %+v", g, w)
+	}
+}
@@ -0,0 +1,224 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/rprajapati0067/quiz-game-backend/internal/models"
+	"github.com/rprajapati0067/quiz-game-backend/internal/repository"
+)
+
+// TestPlaceClaim runs the AwardRepository.PlaceClaim contract against
+// backends made by newRepos. A placed claim spends points, so the awards
+// are tested together with the ledger of the same backend. Each subtest
+// gets its own backend.
+func TestPlaceClaim(t *testing.T, newRepos func(t *testing.T) repository.Repositories) {
+	ctx := context.Background()
+	const cost = 30
+	newAward := func(t *testing.T, repos repository.Repositories, stock int64, limit int32) *models.Award {
+		t.Helper()
+		a := &models.Award{
+			ID:             newID(),
+			Product:        "mug",
+			PointCost:      cost,
+			TotalStock:     stock,
+			RemainingStock: stock,
+			PerUserLimit:   limit,
+			AvailableFrom:  at(0),
+			Active:         true,
+		}
+		noErr(t, "create award", repos.Awards.Create(ctx, a))
+		return a
+	}
+	newUser := func(t *testing.T, repos repository.Repositories, points int64) string {
+		t.Helper()
+		user := newID()
+		noErr(t, "seed points", repos.Ledger.Append(ctx, &models.LedgerEntry{
+			ID:        newID(),
+			UserID:    user,
+			Kind:      models.LedgerCredit,
+			Amount:    points,
+			Reason:    models.ReasonCorrectAnswer,
+			CreatedAt: at(0),
+		}))
+		return user
+	}
+	newClaim := func(a *models.Award, user string) (*models.Claim, *models.LedgerEntry) {
+		c := &models.Claim{
+			ID:        newID(),
+			UserID:    user,
+			AwardID:   a.ID,
+			Points:    a.PointCost,
+			ClaimedAt: at(1),
+			Status:    models.ClaimPending,
+			UpdatedAt: at(1),
+		}
+		debit := &models.LedgerEntry{
+			ID:        newID(),
+			UserID:    user,
+			Kind:      models.LedgerDebit,
+			Amount:    a.PointCost,
+			Reason:    models.ReasonAwardClaim,
+			RefType:   repository.RefClaim,
+			RefID:     c.ID,
+			CreatedAt: at(1),
+		}
+		return c, debit
+	}
+	// want checks the user's balance and the award's remaining stock.
+	want := func(t *testing.T, repos repository.Repositories, user string, balance int64, a *models.Award, stock int64) {
+		t.Helper()
+		gotBalance, err := repos.Ledger.Balance(ctx, user)
+		noErr(t, "balance", err)
+		got, err := repos.Awards.GetByID(ctx, a.ID)
+		noErr(t, "get award", err)
+		if gotBalance != balance || got.RemainingStock != stock {
+			t.Fatalf("balance %d with %d in stock, want %d with %d", gotBalance, got.RemainingStock, balance, stock)
+		}
+	}
+	wantNoClaim := func(t *testing.T, repos repository.Repositories, c *models.Claim) {
+		t.Helper()
+		_, err := repos.Awards.GetClaim(ctx, c.ID)
+		wantErr(t, "get refused claim", err, repository.ErrNotFound)
+	}
+
+	t.Run("Place", func(t *testing.T) {
+		repos := newRepos(t)
+		a := newAward(t, repos, 3, 0)
+		user := newUser(t, repos, 100)
+		c, debit := newClaim(a, user)
+		noErr(t, "place", repos.Awards.PlaceClaim(ctx, c, debit))
+
+		if debit.Balance != 100-cost || debit.Seq != 2 {
+			t.Fatalf("debit recorded at seq %d with balance %d, want 2 and %d", debit.Seq, debit.Balance, 100-cost)
+		}
+		want(t, repos, user, 100-cost, a, 2)
+		got, err := repos.Awards.GetClaim(ctx, c.ID)
+		noErr(t, "get claim", err)
+		if got.UserID != user || got.Status != models.ClaimPending {
+			t.Fatalf("got claim %+v, want %+v", got, c)
+		}
+		noErr(t, "release", repos.Awards.Release(ctx, a.ID, user))
+	})
+
+	t.Run("InsufficientBalance", func(t *testing.T) {
+		repos := newRepos(t)
+		a := newAward(t, repos, 1, 1)
+		user := newUser(t, repos, cost-1)
+		c, debit := newClaim(a, user)
+		wantErr(t, "place", repos.Awards.PlaceClaim(ctx, c, debit), repository.ErrInsufficientBalance)
+		want(t, repos, user, cost-1, a, 1)
+		wantNoClaim(t, repos, c)
+		wantErr(t, "release", repos.Awards.Release(ctx, a.ID, user), repository.ErrNoReservation)
+	})
+
+	t.Run("OutOfStock", func(t *testing.T) {
+		repos := newRepos(t)
+		a := newAward(t, repos, 0, 0)
+		user := newUser(t, repos, 100)
+		c, debit := newClaim(a, user)
+		wantErr(t, "place", repos.Awards.PlaceClaim(ctx, c, debit), repository.ErrOutOfStock)
+		want(t, repos, user, 100, a, 0)
+		wantNoClaim(t, repos, c)
+	})
+
+	t.Run("PerUserLimit", func(t *testing.T) {
+		repos := newRepos(t)
+		a := newAward(t, repos, 5, 1)
+		user := newUser(t, repos, 100)
+		first, firstDebit := newClaim(a, user)
+		noErr(t, "first place", repos.Awards.PlaceClaim(ctx, first, firstDebit))
+		c, debit := newClaim(a, user)
+		wantErr(t, "second place", repos.Awards.PlaceClaim(ctx, c, debit), repository.ErrClaimLimitReached)
+		want(t, repos, user, 100-cost, a, 4)
+		wantNoClaim(t, repos, c)
+	})
+
+	t.Run("Duplicate", func(t *testing.T) {
+		repos := newRepos(t)
+		a := newAward(t, repos, 5, 0)
+		user := newUser(t, repos, 100)
+		c, debit := newClaim(a, user)
+		noErr(t, "place", repos.Awards.PlaceClaim(ctx, c, debit))
+
+		again := *debit
+		again.ID = newID()
+		wantErr(t, "place same claim", repos.Awards.PlaceClaim(ctx, c, &again), repository.ErrConflict)
+		other, _ := newClaim(a, user)
+		again = *debit
+		wantErr(t, "place same debit", repos.Awards.PlaceClaim(ctx, other, &again), repository.ErrEntryExists)
+		want(t, repos, user, 100-cost, a, 4)
+		wantNoClaim(t, repos, other)
+	})
+
+	t.Run("ConcurrentLastUnit", func(t *testing.T) {
+		repos := newRepos(t)
+		a := newAward(t, repos, 1, 0)
+		users := make([]string, concurrency)
+		for i := range users {
+			users[i] = newUser(t, repos, 100)
+		}
+
+		errs := make([]error, concurrency)
+		var wg sync.WaitGroup
+		for i, user := range users {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				c, debit := newClaim(a, user)
+				errs[i] = repos.Awards.PlaceClaim(ctx, c, debit)
+			}()
+		}
+		wg.Wait()
+
+		placed := 0
+		for i, err := range errs {
+			switch {
+			case err == nil:
+				placed++
+				want(t, repos, users[i], 100-cost, a, 0)
+			case errors.Is(err, repository.ErrOutOfStock):
+				want(t, repos, users[i], 100, a, 0)
+			default:
+				t.Fatalf("place: %v", err)
+			}
+		}
+		if placed != 1 {
+			t.Fatalf("%d claims placed on the last unit, want 1", placed)
+		}
+	})
+
+	t.Run("ConcurrentSpend", func(t *testing.T) {
+		repos := newRepos(t)
+		a := newAward(t, repos, concurrency, 0)
+		user := newUser(t, repos, cost+cost/2)
+
+		errs := make([]error, concurrency)
+		var wg sync.WaitGroup
+		for i := range errs {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				c, debit := newClaim(a, user)
+				errs[i] = repos.Awards.PlaceClaim(ctx, c, debit)
+			}()
+		}
+		wg.Wait()
+
+		placed := 0
+		for _, err := range errs {
+			switch {
+			case err == nil:
+				placed++
+			case !errors.Is(err, repository.ErrInsufficientBalance):
+				t.Fatalf("place: %v", err)
+			}
+		}
+		if placed != 1 {
+			t.Fatalf("%d claims paid from points for one, want 1", placed)
+		}
+		want(t, repos, user, cost/2, a, concurrency-1)
+	})
+}
@@ -0,0 +1,211 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/rprajapati0067/quiz-game-backend/internal/models"
+	"github.com/rprajapati0067/quiz-game-backend/internal/repository"
+)
+
+// TestLedgerRepository runs the LedgerRepository contract against stores
+// made by newRepo. Each subtest gets its own store.
+func TestLedgerRepository(t *testing.T, newRepo func(t *testing.T) repository.LedgerRepository) {
+	ctx := context.Background()
+	entry := func(userID string, kind models.LedgerKind, amount int64) *models.LedgerEntry {
+		return &models.LedgerEntry{
+			ID:        newID(),
+			UserID:    userID,
+			Kind:      kind,
+			Amount:    amount,
+			Reason:    models.ReasonCorrectAnswer,
+			RefType:   repository.RefAnswer,
+			RefID:     newID(),
+			CreatedAt: at(0),
+		}
+	}
+
+	t.Run("AppendAndBalance", func(t *testing.T) {
+		repo := newRepo(t)
+		user := newID()
+		balance, err := repo.Balance(ctx, user)
+		noErr(t, "balance of new user", err)
+		if balance != 0 {
+			t.Fatalf("new user has balance %d", balance)
+		}
+
+		credit := entry(user, models.LedgerCredit, 30)
+		noErr(t, "credit", repo.Append(ctx, credit))
+		debit := entry(user, models.LedgerDebit, 12)
+		noErr(t, "debit", repo.Append(ctx, debit))
+		if credit.Seq != 1 || credit.Balance != 30 || debit.Seq != 2 || debit.Balance != 18 {
+			t.Fatalf("entries filled in as seq %d balance %d, seq %d balance %d",
+				credit.Seq, credit.Balance, debit.Seq, debit.Balance)
+		}
+		noErr(t, "other user", repo.Append(ctx, entry(newID(), models.LedgerCredit, 100)))
+
+		balance, err = repo.Balance(ctx, user)
+		noErr(t, "balance", err)
+		if balance != 18 {
+			t.Fatalf("balance %d, want 18", balance)
+		}
+	})
+
+	t.Run("InsufficientBalance", func(t *testing.T) {
+		repo := newRepo(t)
+		user := newID()
+		noErr(t, "credit", repo.Append(ctx, entry(user, models.LedgerCredit, 5)))
+		wantErr(t, "overdraw", repo.Append(ctx, entry(user, models.LedgerDebit, 6)), repository.ErrInsufficientBalance)
+
+		balance, err := repo.Balance(ctx, user)
+		noErr(t, "balance", err)
+		list, err := repo.List(ctx, user, 0, 10)
+		noErr(t, "list", err)
+		if balance != 5 || len(list) != 1 {
+			t.Fatalf("refused debit was recorded: balance %d, %d entries", balance, len(list))
+		}
+	})
+
+	t.Run("Pagination", func(t *testing.T) {
+		repo := newRepo(t)
+		user := newID()
+		const total, page = 25, 10
+		for i := 0; i < total; i++ {
+			noErr(t, "credit", repo.Append(ctx, entry(user, models.LedgerCredit, 1)))
+		}
+
+		var seen []int64
+		var before int64
+		for {
+			list, err := repo.List(ctx, user, before, page)
+			noErr(t, "list", err)
+			if len(list) > page {
+				t.Fatalf("page of %d entries, limit %d", len(list), page)
+			}
+			if len(list) == 0 {
+				break
+			}
+			for _, e := range list {
+				seen = append(seen, e.Seq)
+			}
+			before = list[len(list)-1].Seq
+		}
+		if len(seen) != total {
+			t.Fatalf("paged through %d entries, want %d", len(seen), total)
+		}
+		for i, seq := range seen {
+			if seq != int64(total-i) {
+				t.Fatalf("entry %d has seq %d; pages are not newest first without gaps", i, seq)
+			}
+		}
+	})
+
+	t.Run("DuplicateID", func(t *testing.T) {
+		repo := newRepo(t)
+		user := newID()
+		first := entry(user, models.LedgerCredit, 10)
+		noErr(t, "credit", repo.Append(ctx, first))
+		noErr(t, "credit", repo.Append(ctx, entry(user, models.LedgerCredit, 5)))
+
+		again := entry(user, models.LedgerCredit, 10)
+		again.ID = first.ID
+		err := repo.Append(ctx, again)
+		wantErr(t, "append same id", err, repository.ErrEntryExists)
+		wantErr(t, "append same id", err, repository.ErrConflict)
+		if again.Seq != first.Seq || again.Balance != first.Balance {
+			t.Fatalf("duplicate filled in as seq %d balance %d, want seq %d balance %d",
+				again.Seq, again.Balance, first.Seq, first.Balance)
+		}
+
+		overdraw := entry(user, models.LedgerDebit, 1000)
+		overdraw.ID = first.ID
+		wantErr(t, "overdraw with same id", repo.Append(ctx, overdraw), repository.ErrEntryExists)
+
+		other := entry(newID(), models.LedgerCredit, 3)
+		other.ID = first.ID
+		noErr(t, "same id for another user", repo.Append(ctx, other))
+
+		balance, err := repo.Balance(ctx, user)
+		noErr(t, "balance", err)
+		list, err := repo.List(ctx, user, 0, 10)
+		noErr(t, "list", err)
+		if balance != 15 || len(list) != 2 {
+			t.Fatalf("duplicate was recorded: balance %d, %d entries", balance, len(list))
+		}
+	})
+
+	t.Run("ConcurrentDuplicateID", func(t *testing.T) {
+		repo := newRepo(t)
+		user := newID()
+		id := newID()
+		entries := make([]*models.LedgerEntry, concurrency)
+		errs := make([]error, concurrency)
+		var wg sync.WaitGroup
+		for i := range entries {
+			entries[i] = entry(user, models.LedgerCredit, 7)
+			entries[i].ID = id
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				errs[i] = repo.Append(ctx, entries[i])
+			}()
+		}
+		wg.Wait()
+
+		won := 0
+		for i, err := range errs {
+			switch {
+			case err == nil:
+				won++
+			case errors.Is(err, repository.ErrEntryExists):
+			default:
+				t.Fatalf("credit: %v", err)
+			}
+			if entries[i].Seq != 1 || entries[i].Balance != 7 {
+				t.Fatalf("append %d filled in as seq %d balance %d", i, entries[i].Seq, entries[i].Balance)
+			}
+		}
+		if won != 1 {
+			t.Fatalf("%d appends of one entry succeeded, want 1", won)
+		}
+		balance, err := repo.Balance(ctx, user)
+		noErr(t, "balance", err)
+		if balance != 7 {
+			t.Fatalf("balance %d, want 7", balance)
+		}
+	})
+
+	t.Run("ConcurrentAppends", func(t *testing.T) {
+		repo := newRepo(t)
+		user := newID()
+		var wg sync.WaitGroup
+		for i := 0; i < concurrency; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if err := repo.Append(ctx, entry(user, models.LedgerCredit, 2)); err != nil {
+					t.Errorf("credit: %v", err)
+				}
+			}()
+		}
+		wg.Wait()
+
+		balance, err := repo.Balance(ctx, user)
+		noErr(t, "balance", err)
+		if balance != 2*concurrency {
+			t.Fatalf("balance %d after %d concurrent credits of 2", balance, concurrency)
+		}
+		list, err := repo.List(ctx, user, 0, 2*concurrency)
+		noErr(t, "list", err)
+		if len(list) != concurrency {
+			t.Fatalf("%d entries recorded, want %d", len(list), concurrency)
+		}
+		for i, e := range list {
+			if e.Seq != int64(concurrency-i) || e.Balance != 2*e.Seq {
+				t.Fatalf("entry %+v breaks the running total", e)
+			}
+		}
+	})
+}
@@ -0,0 +1,139 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/rprajapati0067/quiz-game-backend/internal/models"
+	"github.com/rprajapati0067/quiz-game-backend/internal/repository"
+)
+
+// TestOTPRepository runs the OTPRepository contract, centred on the
+// conditional writes that keep attempt counts exact, against stores made by
+// newRepo. Each subtest gets its own store.
+func TestOTPRepository(t *testing.T, newRepo func(t *testing.T) repository.OTPRepository) {
+	ctx := context.Background()
+	newOTP := func(phone string, attempts int32) *models.OTP {
+		return &models.OTP{
+			Phone:       phone,
+			CodeHash:    newID(),
+			Attempts:    attempts,
+			ExpiresAt:   at(300),
+			CreatedAt:   at(0),
+			WindowStart: at(0),
+		}
+	}
+
+	t.Run("SaveAndGet", func(t *testing.T) {
+		repo := newRepo(t)
+		phone := newPhone()
+		got, err := repo.GetByPhone(ctx, phone)
+		noErr(t, "get missing", err)
+		if got != nil {
+			t.Fatalf("got %+v for a phone without a code", got)
+		}
+
+		o := newOTP(phone, 2)
+		noErr(t, "save", repo.Save(ctx, o, nil))
+		got, err = repo.GetByPhone(ctx, phone)
+		noErr(t, "get", err)
+		if got == nil || got.CodeHash != o.CodeHash || got.Attempts != 2 {
+			t.Fatalf("got %+v, want %+v", got, o)
+		}
+		sameTime(t, "expires at", got.ExpiresAt, o.ExpiresAt)
+		sameTime(t, "window start", got.WindowStart, o.WindowStart)
+
+		noErr(t, "delete", repo.Delete(ctx, phone))
+		got, err = repo.GetByPhone(ctx, phone)
+		noErr(t, "get deleted", err)
+		if got != nil {
+			t.Fatalf("deleted code still stored: %+v", got)
+		}
+	})
+
+	t.Run("SaveReplacesOnlyWhatWasRead", func(t *testing.T) {
+		repo := newRepo(t)
+		phone := newPhone()
+		first := newOTP(phone, 0)
+		noErr(t, "save", repo.Save(ctx, first, nil))
+		wantErr(t, "save over an unread code", repo.Save(ctx, newOTP(phone, 0), nil), repository.ErrOTPChanged)
+
+		read, err := repo.GetByPhone(ctx, phone)
+		noErr(t, "get", err)
+		_, err = repo.UseAttempt(ctx, phone, 5)
+		noErr(t, "use attempt", err)
+		err = repo.Save(ctx, newOTP(phone, read.Attempts), read)
+		wantErr(t, "save over a code used since it was read", err, repository.ErrOTPChanged)
+		wantErr(t, "save over a code used since it was read", err, repository.ErrConflict)
+
+		read, err = repo.GetByPhone(ctx, phone)
+		noErr(t, "get again", err)
+		next := newOTP(phone, read.Attempts)
+		noErr(t, "save over the code read", repo.Save(ctx, next, read))
+		got, err := repo.GetByPhone(ctx, phone)
+		noErr(t, "get replaced", err)
+		if got.CodeHash != next.CodeHash || got.Attempts != 1 {
+			t.Fatalf("got %+v, want %+v", got, next)
+		}
+	})
+
+	t.Run("UseAttempt", func(t *testing.T) {
+		repo := newRepo(t)
+		phone := newPhone()
+		got, err := repo.UseAttempt(ctx, phone, 3)
+		noErr(t, "use attempt without a code", err)
+		if got != nil {
+			t.Fatalf("got %+v for a phone without a code", got)
+		}
+
+		o := newOTP(phone, 0)
+		noErr(t, "save", repo.Save(ctx, o, nil))
+		for want := int32(1); want <= 3; want++ {
+			got, err := repo.UseAttempt(ctx, phone, 3)
+			noErr(t, "use attempt", err)
+			if got.Attempts != want || got.CodeHash != o.CodeHash {
+				t.Fatalf("attempt %d returned %+v", want, got)
+			}
+		}
+		_, err = repo.UseAttempt(ctx, phone, 3)
+		wantErr(t, "use attempt past max", err, repository.ErrOTPAttemptsExhausted)
+		got, err = repo.GetByPhone(ctx, phone)
+		noErr(t, "get", err)
+		if got.Attempts != 3 {
+			t.Fatalf("%d attempts recorded, want 3", got.Attempts)
+		}
+	})
+
+	t.Run("ConcurrentUseAttempt", func(t *testing.T) {
+		repo := newRepo(t)
+		phone := newPhone()
+		noErr(t, "save", repo.Save(ctx, newOTP(phone, 0), nil))
+
+		const max = 3
+		errs := make([]error, concurrency)
+		var wg sync.WaitGroup
+		for i := range errs {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, errs[i] = repo.UseAttempt(ctx, phone, max)
+			}()
+		}
+		wg.Wait()
+
+		used := 0
+		for _, err := range errs {
+			switch {
+			case err == nil:
+				used++
+			case !errors.Is(err, repository.ErrOTPAttemptsExhausted):
+				t.Fatalf("use attempt: %v", err)
+			}
+		}
+		if used != max {
+			t.Fatalf("%d of %d concurrent attempts counted, want %d", used, concurrency, max)
+		}
+	})
+}
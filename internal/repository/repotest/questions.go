@@ -0,0 +1,134 @@
+package repotest
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/rprajapati0067/quiz-game-backend/internal/models"
+	"github.com/rprajapati0067/quiz-game-backend/internal/repository"
+)
+
+// TestQuestionRepository runs the QuestionRepository contract against
+// stores made by newRepo. Each subtest gets its own store.
+func TestQuestionRepository(t *testing.T, newRepo func(t *testing.T) repository.QuestionRepository) {
+	ctx := context.Background()
+	newQuestion := func(slot int32) *models.Question {
+		return &models.Question{
+			ID:           newID(),
+			Text:         "Which planet is largest?",
+			Options:      []string{"Mars", "Jupiter", "Venus"},
+			CorrectIndex: 1,
+			Slot:         slot,
+			CreatedBy:    newID(),
+		}
+	}
+
+	t.Run("CreateAndGet", func(t *testing.T) {
+		repo := newRepo(t)
+		q := newQuestion(1)
+		noErr(t, "create", repo.Create(ctx, q))
+
+		got, err := repo.GetByID(ctx, q.ID)
+		noErr(t, "get", err)
+		if !reflect.DeepEqual(got, q) {
+			t.Fatalf("got %+v, want %+v", got, q)
+		}
+	})
+
+	t.Run("DuplicateID", func(t *testing.T) {
+		repo := newRepo(t)
+		q := newQuestion(1)
+		noErr(t, "create", repo.Create(ctx, q))
+
+		dup := newQuestion(2)
+		dup.ID = q.ID
+		wantErr(t, "create duplicate", repo.Create(ctx, dup), repository.ErrConflict)
+		got, err := repo.GetByID(ctx, q.ID)
+		noErr(t, "get", err)
+		if got.Slot != q.Slot {
+			t.Fatalf("duplicate create overwrote the question")
+		}
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		repo := newRepo(t)
+		_, err := repo.GetByID(ctx, newID())
+		wantErr(t, "get", err, repository.ErrNotFound)
+
+		list, err := repo.ListBySlot(ctx, 7)
+		noErr(t, "list empty slot", err)
+		if len(list) != 0 {
+			t.Fatalf("empty slot listed %d questions", len(list))
+		}
+	})
+
+	t.Run("CopyOnRead", func(t *testing.T) {
+		repo := newRepo(t)
+		q := newQuestion(1)
+		noErr(t, "create", repo.Create(ctx, q))
+		q.Options[0] = "changed after create"
+
+		got, err := repo.GetByID(ctx, q.ID)
+		noErr(t, "get", err)
+		got.Options[1] = "changed after get"
+		list, err := repo.ListBySlot(ctx, 1)
+		noErr(t, "list", err)
+		list[0].Options[2] = "changed after list"
+
+		again, err := repo.GetByID(ctx, q.ID)
+		noErr(t, "get again", err)
+		if !reflect.DeepEqual(again.Options, []string{"Mars", "Jupiter", "Venus"}) {
+			t.Fatalf("stored options changed through a shared value: %v", again.Options)
+		}
+	})
+
+	t.Run("ListBySlot", func(t *testing.T) {
+		repo := newRepo(t)
+		var want []string
+		for i := 0; i < 5; i++ {
+			q := newQuestion(3)
+			noErr(t, "create", repo.Create(ctx, q))
+			want = append(want, q.ID)
+			noErr(t, "create other slot", repo.Create(ctx, newQuestion(4)))
+		}
+
+		list, err := repo.ListBySlot(ctx, 3)
+		noErr(t, "list", err)
+		var got []string
+		for _, q := range list {
+			if q.Slot != 3 {
+				t.Fatalf("slot 3 listed a question from slot %d", q.Slot)
+			}
+			got = append(got, q.ID)
+		}
+		sort.Strings(got)
+		sort.Strings(want)
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("listed %v, want %v", got, want)
+		}
+	})
+
+	t.Run("ConcurrentCreates", func(t *testing.T) {
+		repo := newRepo(t)
+		var wg sync.WaitGroup
+		for i := 0; i < concurrency; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if err := repo.Create(ctx, newQuestion(5)); err != nil {
+					t.Errorf("create: %v", err)
+				}
+			}()
+		}
+		wg.Wait()
+
+		list, err := repo.ListBySlot(ctx, 5)
+		noErr(t, "list", err)
+		if len(list) != concurrency {
+			t.Fatalf("listed %d questions after %d creates", len(list), concurrency)
+		}
+	})
+}
@@ -0,0 +1,169 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/rprajapati0067/quiz-game-backend/internal/models"
+	"github.com/rprajapati0067/quiz-game-backend/internal/repository"
+)
+
+// TestSessionRepository runs the SessionRepository contract, centred on
+// refresh token rotation and reuse detection, against stores made by
+// newRepo. Each subtest gets its own store.
+func TestSessionRepository(t *testing.T, newRepo func(t *testing.T) repository.SessionRepository) {
+	ctx := context.Background()
+	newSession := func(t *testing.T, repo repository.SessionRepository) (*models.Session, *models.RefreshToken) {
+		t.Helper()
+		s := &models.Session{
+			ID:         newID(),
+			UserID:     newID(),
+			UserAgent:  "repotest",
+			CreatedAt:  at(0),
+			LastUsedAt: at(0),
+			ExpiresAt:  at(1000),
+		}
+		first := &models.RefreshToken{
+			Hash:      newID(),
+			SessionID: s.ID,
+			UserID:    s.UserID,
+			IssuedAt:  at(0),
+			ExpiresAt: s.ExpiresAt,
+		}
+		noErr(t, "create session", repo.Create(ctx, s, first))
+		return s, first
+	}
+	nextToken := func(s *models.Session, issued int) *models.RefreshToken {
+		return &models.RefreshToken{
+			Hash:      newID(),
+			SessionID: s.ID,
+			UserID:    s.UserID,
+			IssuedAt:  at(issued),
+			ExpiresAt: s.ExpiresAt,
+		}
+	}
+
+	t.Run("CreateAndGet", func(t *testing.T) {
+		repo := newRepo(t)
+		s, first := newSession(t, repo)
+
+		got, err := repo.GetByID(ctx, s.ID)
+		noErr(t, "get session", err)
+		if got == nil || got.UserID != s.UserID || got.Revoked {
+			t.Fatalf("got session %+v, want %+v", got, s)
+		}
+		token, err := repo.GetRefreshToken(ctx, first.Hash)
+		noErr(t, "get token", err)
+		if token == nil || token.SessionID != s.ID || token.Rotated {
+			t.Fatalf("got token %+v, want %+v", token, first)
+		}
+		list, err := repo.ListByUser(ctx, s.UserID)
+		noErr(t, "list by user", err)
+		if len(list) != 1 || list[0].ID != s.ID {
+			t.Fatalf("sessions by user: %+v", list)
+		}
+	})
+
+	t.Run("DuplicateID", func(t *testing.T) {
+		repo := newRepo(t)
+		s, _ := newSession(t, repo)
+		wantErr(t, "create duplicate", repo.Create(ctx, s, nextToken(s, 1)), repository.ErrConflict)
+	})
+
+	t.Run("Rotate", func(t *testing.T) {
+		repo := newRepo(t)
+		s, first := newSession(t, repo)
+		next := nextToken(s, 5)
+		noErr(t, "rotate", repo.Rotate(ctx, first.Hash, next))
+
+		old, err := repo.GetRefreshToken(ctx, first.Hash)
+		noErr(t, "get old token", err)
+		if !old.Rotated {
+			t.Fatal("rotated token not marked rotated")
+		}
+		stored, err := repo.GetRefreshToken(ctx, next.Hash)
+		noErr(t, "get next token", err)
+		if stored == nil || stored.Rotated {
+			t.Fatalf("next token stored as %+v", stored)
+		}
+		got, err := repo.GetByID(ctx, s.ID)
+		noErr(t, "get session", err)
+		sameTime(t, "last used at", got.LastUsedAt, next.IssuedAt)
+
+		noErr(t, "rotate next", repo.Rotate(ctx, next.Hash, nextToken(s, 6)))
+	})
+
+	t.Run("RotateReuse", func(t *testing.T) {
+		repo := newRepo(t)
+		s, first := newSession(t, repo)
+		noErr(t, "rotate", repo.Rotate(ctx, first.Hash, nextToken(s, 1)))
+
+		replay := nextToken(s, 2)
+		err := repo.Rotate(ctx, first.Hash, replay)
+		wantErr(t, "rotate used token", err, repository.ErrRefreshTokenReused)
+		wantErr(t, "rotate used token", err, repository.ErrConflict)
+		stored, err := repo.GetRefreshToken(ctx, replay.Hash)
+		noErr(t, "get refused token", err)
+		if stored != nil {
+			t.Fatal("token from a refused rotation was stored")
+		}
+	})
+
+	t.Run("ConcurrentRotate", func(t *testing.T) {
+		repo := newRepo(t)
+		s, first := newSession(t, repo)
+
+		nexts := make([]*models.RefreshToken, concurrency)
+		errs := make([]error, concurrency)
+		var wg sync.WaitGroup
+		for i := range nexts {
+			nexts[i] = nextToken(s, i+1)
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				errs[i] = repo.Rotate(ctx, first.Hash, nexts[i])
+			}()
+		}
+		wg.Wait()
+
+		won := 0
+		for i, err := range errs {
+			switch {
+			case err == nil:
+				won++
+			case errors.Is(err, repository.ErrRefreshTokenReused):
+				stored, err := repo.GetRefreshToken(ctx, nexts[i].Hash)
+				noErr(t, "get losing token", err)
+				if stored != nil {
+					t.Fatalf("losing rotation %d stored its token", i)
+				}
+			default:
+				t.Fatalf("rotate: %v", err)
+			}
+		}
+		if won != 1 {
+			t.Fatalf("%d concurrent rotations succeeded, want 1", won)
+		}
+	})
+
+	t.Run("Revoke", func(t *testing.T) {
+		repo := newRepo(t)
+		s, _ := newSession(t, repo)
+		noErr(t, "revoke", repo.Revoke(ctx, s.ID))
+
+		got, err := repo.GetByID(ctx, s.ID)
+		noErr(t, "get session", err)
+		if !got.Revoked {
+			t.Fatal("revoked session not marked revoked")
+		}
+	})
+
+	t.Run("Missing", func(t *testing.T) {
+		repo := newRepo(t)
+		wantErr(t, "revoke missing session", repo.Revoke(ctx, newID()), repository.ErrNotFound)
+		s := &models.Session{ID: newID(), UserID: newID()}
+		wantErr(t, "rotate missing token", repo.Rotate(ctx, newID(), nextToken(s, 1)), repository.ErrNotFound)
+	})
+}
@@ -0,0 +1,209 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/rprajapati0067/quiz-game-backend/internal/models"
+	"github.com/rprajapati0067/quiz-game-backend/internal/repository"
+)
+
+// TestUserRepository runs the UserRepository contract against stores made
+// by newRepo. Each subtest gets its own store.
+func TestUserRepository(t *testing.T, newRepo func(t *testing.T) repository.UserRepository) {
+	ctx := context.Background()
+	newUser := func() *models.User {
+		return &models.User{
+			ID:    newID(),
+			Name:  "player",
+			Phone: newPhone(),
+			Email: "player@example.com",
+			Roles: []models.Role{models.RolePlayer},
+		}
+	}
+
+	t.Run("CreateAndGet", func(t *testing.T) {
+		repo := newRepo(t)
+		u := newUser()
+		noErr(t, "create", repo.CreateUser(ctx, u))
+
+		byID, err := repo.GetByID(ctx, u.ID)
+		noErr(t, "get by id", err)
+		if !reflect.DeepEqual(byID, u) {
+			t.Fatalf("get by id: got %+v, want %+v", byID, u)
+		}
+		byPhone, err := repo.GetByPhone(ctx, u.Phone)
+		noErr(t, "get by phone", err)
+		if !reflect.DeepEqual(byPhone, u) {
+			t.Fatalf("get by phone: got %+v, want %+v", byPhone, u)
+		}
+	})
+
+	t.Run("DuplicateID", func(t *testing.T) {
+		repo := newRepo(t)
+		u := newUser()
+		noErr(t, "create", repo.CreateUser(ctx, u))
+
+		dup := newUser()
+		dup.ID = u.ID
+		wantErr(t, "create duplicate id", repo.CreateUser(ctx, dup), repository.ErrConflict)
+	})
+
+	t.Run("UniquePhone", func(t *testing.T) {
+		repo := newRepo(t)
+		u := newUser()
+		noErr(t, "create", repo.CreateUser(ctx, u))
+
+		dup := newUser()
+		dup.Phone = u.Phone
+		wantErr(t, "create duplicate phone", repo.CreateUser(ctx, dup), repository.ErrConflict)
+		_, err := repo.GetByID(ctx, dup.ID)
+		wantErr(t, "get rejected user", err, repository.ErrNotFound)
+		got, err := repo.GetByPhone(ctx, u.Phone)
+		noErr(t, "get by phone", err)
+		if got.ID != u.ID {
+			t.Fatalf("phone now belongs to %s, want %s", got.ID, u.ID)
+		}
+	})
+
+	t.Run("UniquePhoneConcurrent", func(t *testing.T) {
+		repo := newRepo(t)
+		phone := newPhone()
+		var created int
+		var mu sync.Mutex
+		var wg sync.WaitGroup
+		for i := 0; i < concurrency; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				u := newUser()
+				u.Phone = phone
+				err := repo.CreateUser(ctx, u)
+				mu.Lock()
+				defer mu.Unlock()
+				switch {
+				case err == nil:
+					created++
+				case !errors.Is(err, repository.ErrConflict):
+					t.Errorf("create: %v", err)
+				}
+			}()
+		}
+		wg.Wait()
+		if created != 1 {
+			t.Fatalf("%d users created with one phone, want 1", created)
+		}
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		repo := newRepo(t)
+		_, err := repo.GetByID(ctx, newID())
+		wantErr(t, "get by id", err, repository.ErrNotFound)
+		_, err = repo.GetByPhone(ctx, newPhone())
+		wantErr(t, "get by phone", err, repository.ErrNotFound)
+		wantErr(t, "update", repo.Update(ctx, newUser()), repository.ErrNotFound)
+	})
+
+	t.Run("CopyOnRead", func(t *testing.T) {
+		repo := newRepo(t)
+		u := newUser()
+		noErr(t, "create", repo.CreateUser(ctx, u))
+		u.Name = "changed after create"
+		u.Roles[0] = models.RoleAdmin
+
+		got, err := repo.GetByID(ctx, u.ID)
+		noErr(t, "get", err)
+		got.Name = "changed after get"
+		got.Roles[0] = models.RoleAdmin
+
+		again, err := repo.GetByID(ctx, u.ID)
+		noErr(t, "get again", err)
+		if again.Name != "player" || !reflect.DeepEqual(again.Roles, []models.Role{models.RolePlayer}) {
+			t.Fatalf("stored user changed through a shared value: %+v", again)
+		}
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		repo := newRepo(t)
+		u := newUser()
+		noErr(t, "create", repo.CreateUser(ctx, u))
+
+		u.Verified = true
+		u.Roles = append(u.Roles, models.RoleEditor)
+		before := u.Version
+		noErr(t, "update", repo.Update(ctx, u))
+		if u.Version <= before {
+			t.Fatalf("version not advanced: %d after %d", u.Version, before)
+		}
+		got, err := repo.GetByID(ctx, u.ID)
+		noErr(t, "get", err)
+		if !reflect.DeepEqual(got, u) {
+			t.Fatalf("got %+v, want %+v", got, u)
+		}
+	})
+
+	t.Run("VersionConflict", func(t *testing.T) {
+		repo := newRepo(t)
+		u := newUser()
+		noErr(t, "create", repo.CreateUser(ctx, u))
+
+		first, err := repo.GetByID(ctx, u.ID)
+		noErr(t, "get first", err)
+		second, err := repo.GetByID(ctx, u.ID)
+		noErr(t, "get second", err)
+
+		first.Name = "first"
+		noErr(t, "update first", repo.Update(ctx, first))
+		second.Name = "second"
+		err = repo.Update(ctx, second)
+		wantErr(t, "update stale", err, repository.ErrConflict)
+		wantErr(t, "update stale", err, repository.ErrVersionConflict)
+
+		got, err := repo.GetByID(ctx, u.ID)
+		noErr(t, "get", err)
+		if got.Name != "first" {
+			t.Fatalf("stale update was saved: name %q", got.Name)
+		}
+	})
+
+	t.Run("ConcurrentUpdates", func(t *testing.T) {
+		repo := newRepo(t)
+		u := newUser()
+		noErr(t, "create", repo.CreateUser(ctx, u))
+
+		var wg sync.WaitGroup
+		for i := 0; i < concurrency; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				for {
+					cur, err := repo.GetByID(ctx, u.ID)
+					if err != nil {
+						t.Errorf("get: %v", err)
+						return
+					}
+					cur.Name = fmt.Sprintf("writer %d", i)
+					err = repo.Update(ctx, cur)
+					if err == nil {
+						return
+					}
+					if !errors.Is(err, repository.ErrConflict) {
+						t.Errorf("update: %v", err)
+						return
+					}
+				}
+			}(i)
+		}
+		wg.Wait()
+
+		got, err := repo.GetByID(ctx, u.ID)
+		noErr(t, "get", err)
+		if got.Version != u.Version+concurrency {
+			t.Fatalf("version %d after %d updates from %d; updates were lost", got.Version, concurrency, u.Version)
+		}
+	})
+}
@@ -0,0 +1,134 @@
+package repotest
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/rprajapati0067/quiz-game-backend/internal/models"
+	"github.com/rprajapati0067/quiz-game-backend/internal/repository"
+)
+
+// TestVoucherRepository runs the VoucherRepository contract against
+// backends made by newRepos. Adding codes restocks the award, so the
+// vouchers are tested together with the awards of the same backend. Each
+// subtest gets its own backend.
+func TestVoucherRepository(t *testing.T, newRepos func(t *testing.T) repository.Repositories) {
+	ctx := context.Background()
+	newAward := func(t *testing.T, repos repository.Repositories) *models.Award {
+		t.Helper()
+		a := &models.Award{
+			ID:            newID(),
+			Product:       "gift card",
+			PointCost:     10,
+			AvailableFrom: at(0),
+			Active:        true,
+			Digital:       true,
+		}
+		noErr(t, "create award", repos.Awards.Create(ctx, a))
+		return a
+	}
+	wantStock := func(t *testing.T, repos repository.Repositories, awardID string, stock int64) {
+		t.Helper()
+		a, err := repos.Awards.GetByID(ctx, awardID)
+		noErr(t, "get award", err)
+		if a.TotalStock != stock || a.RemainingStock != stock {
+			t.Fatalf("stock %d of %d, want %d of %d", a.RemainingStock, a.TotalStock, stock, stock)
+		}
+		free, err := repos.Vouchers.Available(ctx, awardID)
+		noErr(t, "available", err)
+		if int64(free) != stock {
+			t.Fatalf("%d codes available, want %d", free, stock)
+		}
+	}
+
+	t.Run("AddRestocks", func(t *testing.T) {
+		repos := newRepos(t)
+		a := newAward(t, repos)
+
+		added, err := repos.Vouchers.Add(ctx, a.ID, []string{"A", "B", "C", "A"}, at(0))
+		noErr(t, "add", err)
+		if added != 3 {
+			t.Fatalf("added %d codes, want 3", added)
+		}
+		wantStock(t, repos, a.ID, 3)
+
+		added, err = repos.Vouchers.Add(ctx, a.ID, []string{"C", "D"}, at(1))
+		noErr(t, "add again", err)
+		if added != 1 {
+			t.Fatalf("added %d codes, want 1", added)
+		}
+		wantStock(t, repos, a.ID, 4)
+	})
+
+	t.Run("AddManyCodes", func(t *testing.T) {
+		repos := newRepos(t)
+		a := newAward(t, repos)
+		codes := make([]string, 250)
+		for i := range codes {
+			codes[i] = fmt.Sprintf("CODE-%03d", i)
+		}
+
+		added, err := repos.Vouchers.Add(ctx, a.ID, codes, at(0))
+		noErr(t, "add", err)
+		if added != len(codes) {
+			t.Fatalf("added %d codes, want %d", added, len(codes))
+		}
+		wantStock(t, repos, a.ID, int64(len(codes)))
+	})
+
+	t.Run("AddToMissingAward", func(t *testing.T) {
+		repos := newRepos(t)
+		awardID := newID()
+		_, err := repos.Vouchers.Add(ctx, awardID, []string{"A"}, at(0))
+		wantErr(t, "add to missing award", err, repository.ErrNotFound)
+
+		free, err := repos.Vouchers.Available(ctx, awardID)
+		noErr(t, "available", err)
+		if free != 0 {
+			t.Fatalf("%d codes stored for a missing award", free)
+		}
+	})
+
+	t.Run("AssignAndUnassign", func(t *testing.T) {
+		repos := newRepos(t)
+		a := newAward(t, repos)
+		_, err := repos.Vouchers.Add(ctx, a.ID, []string{"A", "B"}, at(0))
+		noErr(t, "add", err)
+
+		first, left, err := repos.Vouchers.Assign(ctx, a.ID, "claim-1", "user-1", at(1))
+		noErr(t, "assign", err)
+		if first.ClaimID != "claim-1" || first.UserID != "user-1" || left != 1 {
+			t.Fatalf("assigned %+v with %d left", first, left)
+		}
+		second, left, err := repos.Vouchers.Assign(ctx, a.ID, "claim-2", "user-2", at(2))
+		noErr(t, "assign", err)
+		if second.Code == first.Code || left != 0 {
+			t.Fatalf("assigned %s then %s with %d left", first.Code, second.Code, left)
+		}
+		_, _, err = repos.Vouchers.Assign(ctx, a.ID, "claim-3", "user-3", at(3))
+		wantErr(t, "assign from an empty pool", err, repository.ErrNoVoucherAvailable)
+
+		noErr(t, "unassign", repos.Vouchers.Unassign(ctx, a.ID, "claim-1"))
+		again, _, err := repos.Vouchers.Assign(ctx, a.ID, "claim-3", "user-3", at(4))
+		noErr(t, "assign returned code", err)
+		if again.Code != first.Code {
+			t.Fatalf("assigned %s, want the returned code %s", again.Code, first.Code)
+		}
+	})
+
+	t.Run("UnassignMissing", func(t *testing.T) {
+		repos := newRepos(t)
+		a := newAward(t, repos)
+		_, err := repos.Vouchers.Add(ctx, a.ID, []string{"A"}, at(0))
+		noErr(t, "add", err)
+		_, _, err = repos.Vouchers.Assign(ctx, a.ID, "claim-1", "user-1", at(1))
+		noErr(t, "assign", err)
+
+		wantErr(t, "unassign from a missing award", repos.Vouchers.Unassign(ctx, newID(), "claim-1"), repository.ErrNotFound)
+		wantErr(t, "unassign an unknown claim", repos.Vouchers.Unassign(ctx, a.ID, "claim-2"), repository.ErrNotFound)
+		noErr(t, "unassign", repos.Vouchers.Unassign(ctx, a.ID, "claim-1"))
+		wantErr(t, "unassign twice", repos.Vouchers.Unassign(ctx, a.ID, "claim-1"), repository.ErrNotFound)
+		wantStock(t, repos, a.ID, 1)
+	})
+}
@@ -0,0 +1,21 @@
+package repository
+
+import (
+    "context"
+
+    "github.com/rprajapati0067/quiz-game-backend/internal/models"
+)
+
+var ErrRefreshTokenReused = conflict("refresh token already rotated")
+
+type SessionRepository interface {
+    Create(ctx context.Context, s *models.Session, t *models.RefreshToken) error
+    GetByID(ctx context.Context, id string) (*models.Session, error)
+    ListByUser(ctx context.Context, userID string) ([]*models.Session, error)
+    Revoke(ctx context.Context, id string) error
+    GetRefreshToken(ctx context.Context, hash string) (*models.RefreshToken, error)
+    // Rotate marks the token identified by oldHash as used and stores next
+    // in its place. It fails with ErrRefreshTokenReused when oldHash was
+    // already rotated, which is how concurrent reuse is detected.
+    Rotate(ctx context.Context, oldHash string, next *models.RefreshToken) error
+}
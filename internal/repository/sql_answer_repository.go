@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/rprajapati0067/quiz-game-backend/internal/models"
+)
+
+const answerColumns = `user_id, question_id, selected_index, submitted_at, correct,
+	points_awarded, updated_points, idempotency_key`
+
+type SQLAnswerRepository struct {
+	db *sql.DB
+}
+
+func NewSQLAnswerRepository(db *sql.DB) *SQLAnswerRepository {
+	return &SQLAnswerRepository{db: db}
+}
+
+func (r *SQLAnswerRepository) Create(ctx context.Context, a *models.Answer) error {
+	created, err := execAffected(ctx, r.db,
+		`INSERT INTO answers (`+answerColumns+`) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT DO NOTHING`,
+		a.UserID, a.QuestionID, a.SelectedIndex, sqlTime(a.SubmittedAt), a.Correct,
+		a.PointsAwarded, a.UpdatedPoints, a.IdempotencyKey)
+	if err != nil {
+		return err
+	}
+	if !created {
+		return ErrAnswerExists
+	}
+	return nil
+}
+
+func (r *SQLAnswerRepository) Update(ctx context.Context, a *models.Answer) error {
+	updated, err := execAffected(ctx, r.db,
+		`UPDATE answers SET selected_index = $1, submitted_at = $2, correct = $3,
+			points_awarded = $4, updated_points = $5, idempotency_key = $6
+		WHERE user_id = $7 AND question_id = $8`,
+		a.SelectedIndex, sqlTime(a.SubmittedAt), a.Correct,
+		a.PointsAwarded, a.UpdatedPoints, a.IdempotencyKey, a.UserID, a.QuestionID)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		return errAnswerNotFound
+	}
+	return nil
+}
+
+func (r *SQLAnswerRepository) GetByUserAndQuestion(ctx context.Context, userID, questionID string) (*models.Answer, error) {
+	return r.get(ctx, `SELECT `+answerColumns+` FROM answers WHERE user_id = $1 AND question_id = $2`,
+		userID, questionID)
+}
+
+func (r *SQLAnswerRepository) GetByIdempotencyKey(ctx context.Context, userID, key string) (*models.Answer, error) {
+	if key == "" {
+		// Answers submitted without a key are stored with an empty one.
+		return nil, nil
+	}
+	return r.get(ctx, `SELECT `+answerColumns+` FROM answers WHERE user_id = $1 AND idempotency_key = $2`,
+		userID, key)
+}
+
+func (r *SQLAnswerRepository) get(ctx context.Context, query string, args ...interface{}) (*models.Answer, error) {
+	a, err := scanAnswer(r.db.QueryRowContext(ctx, query, args...))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return a, err
+}
+
+func (r *SQLAnswerRepository) ListByUser(ctx context.Context, userID string) ([]*models.Answer, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT `+answerColumns+` FROM answers WHERE user_id = $1 ORDER BY submitted_at`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []*models.Answer{}
+	for rows.Next() {
+		a, err := scanAnswer(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, a)
+	}
+	return result, rows.Err()
+}
+
+func scanAnswer(row rowScanner) (*models.Answer, error) {
+	var a models.Answer
+	var submittedAt int64
+	err := row.Scan(&a.UserID, &a.QuestionID, &a.SelectedIndex, &submittedAt, &a.Correct,
+		&a.PointsAwarded, &a.UpdatedPoints, &a.IdempotencyKey)
+	if err != nil {
+		return nil, err
+	}
+	a.SubmittedAt = fromSQLTime(submittedAt)
+	return &a, nil
+}
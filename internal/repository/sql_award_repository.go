@@ -0,0 +1,290 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/rprajapati0067/quiz-game-backend/internal/models"
+)
+
+const (
+	awardColumns = `id, product, point_cost, total_stock, remaining_stock, per_user_limit,
+	available_from, available_until, active, digital`
+	claimColumns = `id, user_id, award_id, points, claimed_at, status, note, updated_at, voucher_code`
+)
+
+// SQLAwardRepository keeps each user's open reservations and claims per
+// award in award_holds, next to the stock it enforces limits against.
+// PlaceClaim writes the ledger tables of the same database.
+type SQLAwardRepository struct {
+	db     *sql.DB
+	ledger *SQLLedgerRepository
+}
+
+func NewSQLAwardRepository(db *sql.DB) *SQLAwardRepository {
+	return &SQLAwardRepository{db: db, ledger: NewSQLLedgerRepository(db)}
+}
+
+func (r *SQLAwardRepository) List(ctx context.Context) ([]*models.Award, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT `+awardColumns+` FROM awards ORDER BY point_cost, id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []*models.Award{}
+	for rows.Next() {
+		a, err := scanAward(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, a)
+	}
+	return result, rows.Err()
+}
+
+func (r *SQLAwardRepository) GetByID(ctx context.Context, id string) (*models.Award, error) {
+	a, err := scanAward(r.db.QueryRowContext(ctx, `SELECT `+awardColumns+` FROM awards WHERE id = $1`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errAwardNotFound
+	}
+	return a, err
+}
+
+func (r *SQLAwardRepository) Create(ctx context.Context, a *models.Award) error {
+	created, err := execAffected(ctx, r.db,
+		`INSERT INTO awards (`+awardColumns+`) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT DO NOTHING`,
+		a.ID, a.Product, a.PointCost, a.TotalStock, a.RemainingStock, a.PerUserLimit,
+		sqlTime(a.AvailableFrom), sqlTime(a.AvailableUntil), a.Active, a.Digital)
+	if err != nil {
+		return err
+	}
+	if !created {
+		return errAwardExists
+	}
+	return nil
+}
+
+func (r *SQLAwardRepository) Update(ctx context.Context, a *models.Award) error {
+	updated, err := execAffected(ctx, r.db,
+		`UPDATE awards SET product = $1, point_cost = $2, per_user_limit = $3,
+			available_from = $4, available_until = $5
+		WHERE id = $6`,
+		a.Product, a.PointCost, a.PerUserLimit,
+		sqlTime(a.AvailableFrom), sqlTime(a.AvailableUntil), a.ID)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		return errAwardNotFound
+	}
+	return nil
+}
+
+func (r *SQLAwardRepository) Restock(ctx context.Context, id string, quantity int64) (*models.Award, error) {
+	a, err := scanAward(r.db.QueryRowContext(ctx,
+		`UPDATE awards SET total_stock = total_stock + $1, remaining_stock = remaining_stock + $1
+		WHERE id = $2 RETURNING `+awardColumns,
+		quantity, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errAwardNotFound
+	}
+	return a, err
+}
+
+func (r *SQLAwardRepository) Retire(ctx context.Context, id string) (*models.Award, error) {
+	a, err := scanAward(r.db.QueryRowContext(ctx,
+		`UPDATE awards SET active = FALSE WHERE id = $1 RETURNING `+awardColumns, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errAwardNotFound
+	}
+	return a, err
+}
+
+func (r *SQLAwardRepository) Reserve(ctx context.Context, awardID, userID string) error {
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		return r.reserve(ctx, tx, awardID, userID)
+	})
+}
+
+func (r *SQLAwardRepository) reserve(ctx context.Context, tx *sql.Tx, awardID, userID string) error {
+	var limit int32
+	err := tx.QueryRowContext(ctx,
+		`UPDATE awards SET remaining_stock = remaining_stock - 1
+		WHERE id = $1 AND remaining_stock > 0 RETURNING per_user_limit`,
+		awardID).Scan(&limit)
+	if errors.Is(err, sql.ErrNoRows) {
+		var exists int
+		err = tx.QueryRowContext(ctx, `SELECT 1 FROM awards WHERE id = $1`, awardID).Scan(&exists)
+		if errors.Is(err, sql.ErrNoRows) {
+			return errAwardNotFound
+		}
+		if err != nil {
+			return err
+		}
+		return ErrOutOfStock
+	}
+	if err != nil {
+		return err
+	}
+
+	query := `INSERT INTO award_holds (award_id, user_id, held) VALUES ($1, $2, 1)
+	ON CONFLICT (award_id, user_id) DO UPDATE SET held = award_holds.held + 1`
+	args := []interface{}{awardID, userID}
+	if limit > 0 {
+		query += ` WHERE award_holds.held < $3`
+		args = append(args, limit)
+	}
+	held, err := execAffected(ctx, tx, query, args...)
+	if err != nil {
+		return err
+	}
+	if !held {
+		return ErrClaimLimitReached
+	}
+	return nil
+}
+
+func (r *SQLAwardRepository) PlaceClaim(ctx context.Context, c *models.Claim, debit *models.LedgerEntry) error {
+	for attempt := 1; ; attempt++ {
+		err := withTx(ctx, r.db, func(tx *sql.Tx) error {
+			if err := r.createClaim(ctx, tx, c); err != nil {
+				return err
+			}
+			if err := r.reserve(ctx, tx, c.AwardID, c.UserID); err != nil {
+				return err
+			}
+			return r.ledger.append(ctx, tx, debit)
+		})
+		if !errors.Is(err, errLedgerRace) || attempt == ledgerAppendAttempts {
+			return err
+		}
+		if err := pause(ctx, attempt); err != nil {
+			return err
+		}
+	}
+}
+
+func (r *SQLAwardRepository) Release(ctx context.Context, awardID, userID string) error {
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		restored, err := execAffected(ctx, tx,
+			`UPDATE awards SET remaining_stock = remaining_stock + 1 WHERE id = $1`, awardID)
+		if err != nil {
+			return err
+		}
+		if !restored {
+			return errAwardNotFound
+		}
+		unheld, err := execAffected(ctx, tx,
+			`UPDATE award_holds SET held = held - 1 WHERE award_id = $1 AND user_id = $2 AND held > 0`,
+			awardID, userID)
+		if err != nil {
+			return err
+		}
+		if !unheld {
+			return ErrNoReservation
+		}
+		return nil
+	})
+}
+
+func (r *SQLAwardRepository) CreateClaim(ctx context.Context, c *models.Claim) error {
+	return r.createClaim(ctx, r.db, c)
+}
+
+func (r *SQLAwardRepository) createClaim(ctx context.Context, db sqlExecer, c *models.Claim) error {
+	created, err := execAffected(ctx, db,
+		`INSERT INTO claims (`+claimColumns+`) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT DO NOTHING`,
+		c.ID, c.UserID, c.AwardID, c.Points, sqlTime(c.ClaimedAt), string(c.Status), c.Note,
+		sqlTime(c.UpdatedAt), c.VoucherCode)
+	if err != nil {
+		return err
+	}
+	if !created {
+		return errClaimExists
+	}
+	return nil
+}
+
+func (r *SQLAwardRepository) GetClaim(ctx context.Context, id string) (*models.Claim, error) {
+	c, err := scanClaim(r.db.QueryRowContext(ctx, `SELECT `+claimColumns+` FROM claims WHERE id = $1`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errClaimNotFound
+	}
+	return c, err
+}
+
+func (r *SQLAwardRepository) ListClaimsByUser(ctx context.Context, userID string) ([]*models.Claim, error) {
+	return r.listClaims(ctx,
+		`SELECT `+claimColumns+` FROM claims WHERE user_id = $1 ORDER BY claimed_at DESC`, userID)
+}
+
+func (r *SQLAwardRepository) ListClaimsByStatus(ctx context.Context, status models.ClaimStatus) ([]*models.Claim, error) {
+	return r.listClaims(ctx,
+		`SELECT `+claimColumns+` FROM claims WHERE status = $1 ORDER BY claimed_at`, string(status))
+}
+
+func (r *SQLAwardRepository) listClaims(ctx context.Context, query string, args ...interface{}) ([]*models.Claim, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []*models.Claim
+	for rows.Next() {
+		c, err := scanClaim(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, c)
+	}
+	return result, rows.Err()
+}
+
+func (r *SQLAwardRepository) SetClaimStatus(ctx context.Context, id string, from, to models.ClaimStatus, note string, at time.Time) (*models.Claim, error) {
+	c, err := scanClaim(r.db.QueryRowContext(ctx,
+		`UPDATE claims SET status = $1, note = $2, updated_at = $3
+		WHERE id = $4 AND status = $5 RETURNING `+claimColumns,
+		string(to), note, sqlTime(at), id, string(from)))
+	if !errors.Is(err, sql.ErrNoRows) {
+		return c, err
+	}
+
+	if _, err := r.GetClaim(ctx, id); err != nil {
+		return nil, err
+	}
+	return nil, ErrClaimStatusChanged
+}
+
+func scanAward(row rowScanner) (*models.Award, error) {
+	var a models.Award
+	var from, until int64
+	err := row.Scan(&a.ID, &a.Product, &a.PointCost, &a.TotalStock, &a.RemainingStock, &a.PerUserLimit,
+		&from, &until, &a.Active, &a.Digital)
+	if err != nil {
+		return nil, err
+	}
+	a.AvailableFrom = fromSQLTime(from)
+	a.AvailableUntil = fromSQLTime(until)
+	return &a, nil
+}
+
+func scanClaim(row rowScanner) (*models.Claim, error) {
+	var c models.Claim
+	var status string
+	var claimedAt, updatedAt int64
+	err := row.Scan(&c.ID, &c.UserID, &c.AwardID, &c.Points, &claimedAt, &status, &c.Note,
+		&updatedAt, &c.VoucherCode)
+	if err != nil {
+		return nil, err
+	}
+	c.Status = models.ClaimStatus(status)
+	c.ClaimedAt = fromSQLTime(claimedAt)
+	c.UpdatedAt = fromSQLTime(updatedAt)
+	return &c, nil
+}
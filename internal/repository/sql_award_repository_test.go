@@ -0,0 +1,111 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/rprajapati0067/quiz-game-backend/internal/models"
+	"github.com/rprajapati0067/quiz-game-backend/internal/repository"
+	"github.com/rprajapati0067/quiz-game-backend/internal/repository/repotest"
+)
+
+func TestSQLAwardRepository(t *testing.T) {
+	repotest.TestAwardRepository(t, func(t *testing.T) repository.AwardRepository {
+		return repository.NewSQLAwardRepository(repotest.SQLite(t))
+	})
+}
+
+func TestSQLPlaceClaim(t *testing.T) {
+	repotest.TestPlaceClaim(t, func(t *testing.T) repository.Repositories {
+		return repository.NewSQLRepositories(repotest.SQLite(t))
+	})
+}
+
+func TestSQLReserveRollsBackStockWhenHoldFails(t *testing.T) {
+	ctx := context.Background()
+	db := repotest.SQLite(t)
+	repo := repository.NewSQLAwardRepository(db)
+	a := sqlAward(3, 0)
+	if err := repo.Create(ctx, a); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	// Recording the hold is the second write of the transaction.
+	if _, err := db.Exec(`ALTER TABLE award_holds RENAME TO award_holds_gone`); err != nil {
+		t.Fatalf("rename: %v", err)
+	}
+	if err := repo.Reserve(ctx, a.ID, "u1"); err == nil {
+		t.Fatal("reserve succeeded without award_holds")
+	}
+	if _, err := db.Exec(`ALTER TABLE award_holds_gone RENAME TO award_holds`); err != nil {
+		t.Fatalf("rename back: %v", err)
+	}
+
+	got, err := repo.GetByID(ctx, a.ID)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if got.RemainingStock != 3 {
+		t.Fatalf("remaining stock %d after a failed reserve, want 3", got.RemainingStock)
+	}
+}
+
+func TestSQLConcurrentReserveKeepsStockAndHoldsInStep(t *testing.T) {
+	ctx := context.Background()
+	db := repotest.SQLite(t)
+	repo := repository.NewSQLAwardRepository(db)
+	const limit = 3
+	a := sqlAward(10, limit)
+	if err := repo.Create(ctx, a); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, 20)
+	for i := range errs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = repo.Reserve(ctx, a.ID, "u1")
+		}()
+	}
+	wg.Wait()
+
+	reserved := 0
+	for _, err := range errs {
+		switch {
+		case err == nil:
+			reserved++
+		case !errors.Is(err, repository.ErrClaimLimitReached):
+			t.Fatalf("reserve: %v", err)
+		}
+	}
+	var held int
+	if err := db.QueryRow(`SELECT held FROM award_holds WHERE award_id = $1 AND user_id = $2`, a.ID, "u1").Scan(&held); err != nil {
+		t.Fatalf("read hold: %v", err)
+	}
+	got, err := repo.GetByID(ctx, a.ID)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if reserved != limit || held != limit || got.RemainingStock != 10-limit {
+		t.Fatalf("%d reservations, %d held, %d remaining; want %d, %d and %d",
+			reserved, held, got.RemainingStock, limit, limit, 10-limit)
+	}
+}
+
+func sqlAward(stock int64, limit int32) *models.Award {
+	return &models.Award{
+		ID:             uuid.NewString(),
+		Product:        "mug",
+		PointCost:      10,
+		TotalStock:     stock,
+		RemainingStock: stock,
+		PerUserLimit:   limit,
+		Active:         true,
+	}
+}
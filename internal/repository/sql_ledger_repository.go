@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/rprajapati0067/quiz-game-backend/internal/models"
+)
+
+const ledgerColumns = `user_id, seq, id, kind, amount, reason, ref_type, ref_id, balance, created_at`
+
+// errLedgerRace reports that another append for the same user committed
+// between reading and writing the balance.
+var errLedgerRace = errors.New("ledger appended concurrently")
+
+// SQLLedgerRepository keeps each user's latest balance in the balances
+// table and advances it together with every append, guarded by its seq.
+type SQLLedgerRepository struct {
+	db *sql.DB
+	// beforeAdvance, if set, runs in every append attempt between reading
+	// the balance and advancing it. Tests use it to write in between, since
+	// SQLite never interleaves two appends on its own.
+	beforeAdvance func(ctx context.Context, tx *sql.Tx) error
+}
+
+func NewSQLLedgerRepository(db *sql.DB) *SQLLedgerRepository {
+	return &SQLLedgerRepository{db: db}
+}
+
+func (r *SQLLedgerRepository) Append(ctx context.Context, e *models.LedgerEntry) error {
+	for attempt := 1; ; attempt++ {
+		err := withTx(ctx, r.db, func(tx *sql.Tx) error {
+			return r.append(ctx, tx, e)
+		})
+		if !errors.Is(err, errLedgerRace) || attempt == ledgerAppendAttempts {
+			return err
+		}
+		if err := pause(ctx, attempt); err != nil {
+			return err
+		}
+	}
+}
+
+func (r *SQLLedgerRepository) append(ctx context.Context, tx *sql.Tx, e *models.LedgerEntry) error {
+	var seq, balance int64
+	err := tx.QueryRowContext(ctx,
+		`SELECT seq, balance FROM ledger_entries WHERE user_id = $1 AND id = $2`, e.UserID, e.ID).Scan(&seq, &balance)
+	if err == nil {
+		e.Seq = seq
+		e.Balance = balance
+		return ErrEntryExists
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	err = tx.QueryRowContext(ctx,
+		`SELECT seq, balance FROM balances WHERE user_id = $1`, e.UserID).Scan(&seq, &balance)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	exists := err == nil
+
+	next := balance + e.Signed()
+	if next < 0 {
+		return ErrInsufficientBalance
+	}
+
+	if r.beforeAdvance != nil {
+		if err := r.beforeAdvance(ctx, tx); err != nil {
+			return err
+		}
+	}
+
+	now := sqlTime(time.Now())
+	var advanced bool
+	if exists {
+		advanced, err = execAffected(ctx, tx,
+			`UPDATE balances SET seq = $1, balance = $2, taken_at = $3 WHERE user_id = $4 AND seq = $5`,
+			seq+1, next, now, e.UserID, seq)
+	} else {
+		advanced, err = execAffected(ctx, tx,
+			`INSERT INTO balances (user_id, seq, balance, taken_at) VALUES ($1, $2, $3, $4)
+			ON CONFLICT DO NOTHING`,
+			e.UserID, seq+1, next, now)
+	}
+	if err != nil {
+		return err
+	}
+	if !advanced {
+		return errLedgerRace
+	}
+
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO ledger_entries (`+ledgerColumns+`) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+		e.UserID, seq+1, e.ID, string(e.Kind), e.Amount, string(e.Reason), e.RefType, e.RefID,
+		next, sqlTime(e.CreatedAt))
+	if err != nil {
+		return err
+	}
+
+	e.Seq = seq + 1
+	e.Balance = next
+	return nil
+}
+
+func (r *SQLLedgerRepository) Balance(ctx context.Context, userID string) (int64, error) {
+	var balance int64
+	err := r.db.QueryRowContext(ctx,
+		`SELECT balance FROM balances WHERE user_id = $1`, userID).Scan(&balance)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	return balance, err
+}
+
+func (r *SQLLedgerRepository) List(ctx context.Context, userID string, beforeSeq int64, limit int) ([]*models.LedgerEntry, error) {
+	query := `SELECT ` + ledgerColumns + ` FROM ledger_entries WHERE user_id = $1`
+	args := []interface{}{userID}
+	if beforeSeq > 0 {
+		query += ` AND seq < $2`
+		args = append(args, beforeSeq)
+	}
+	query += ` ORDER BY seq DESC LIMIT ` + strconv.Itoa(limit)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]*models.LedgerEntry, 0, limit)
+	for rows.Next() {
+		var e models.LedgerEntry
+		var kind, reason string
+		var createdAt int64
+		err := rows.Scan(&e.UserID, &e.Seq, &e.ID, &kind, &e.Amount, &reason, &e.RefType, &e.RefID,
+			&e.Balance, &createdAt)
+		if err != nil {
+			return nil, err
+		}
+		e.Kind = models.LedgerKind(kind)
+		e.Reason = models.LedgerReason(reason)
+		e.CreatedAt = fromSQLTime(createdAt)
+		result = append(result, &e)
+	}
+	return result, rows.Err()
+}
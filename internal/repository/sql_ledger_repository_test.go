@@ -0,0 +1,134 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/rprajapati0067/quiz-game-backend/internal/models"
+	"github.com/rprajapati0067/quiz-game-backend/internal/repository"
+	"github.com/rprajapati0067/quiz-game-backend/internal/repository/repotest"
+)
+
+func TestSQLLedgerRepository(t *testing.T) {
+	repotest.TestLedgerRepository(t, func(t *testing.T) repository.LedgerRepository {
+		return repository.NewSQLLedgerRepository(repotest.SQLite(t))
+	})
+}
+
+// The seq guard only matters when another append commits between reading
+// the balance and advancing it. SQLite runs one transaction at a time, so
+// these tests make that competing write from the append's own transaction.
+
+func TestSQLLedgerAppendRetriesLostRace(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewSQLLedgerRepository(repotest.SQLite(t))
+	const user = "u1"
+	if err := repo.Append(ctx, ledgerCredit(user, 10)); err != nil {
+		t.Fatalf("first append: %v", err)
+	}
+
+	attempts := 0
+	repo.SetBeforeAdvance(func(ctx context.Context, tx *sql.Tx) error {
+		attempts++
+		if attempts > 1 {
+			return nil
+		}
+		_, err := tx.ExecContext(ctx, `UPDATE balances SET seq = seq + 1, balance = balance + 100 WHERE user_id = $1`, user)
+		return err
+	})
+	e := ledgerCredit(user, 5)
+	if err := repo.Append(ctx, e); err != nil {
+		t.Fatalf("append after a lost race: %v", err)
+	}
+	if attempts != 2 {
+		t.Fatalf("append made %d attempts, want 2", attempts)
+	}
+	// The losing attempt rolled back, taking the competing write with it.
+	if e.Seq != 2 || e.Balance != 15 {
+		t.Fatalf("entry written as seq %d balance %d, want 2 and 15", e.Seq, e.Balance)
+	}
+	entries, err := repo.List(ctx, user, 0, 10)
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("ledger has %d entries, want 2", len(entries))
+	}
+}
+
+func TestSQLLedgerAppendRetriesLostFirstInsert(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewSQLLedgerRepository(repotest.SQLite(t))
+	const user = "u1"
+
+	attempts := 0
+	repo.SetBeforeAdvance(func(ctx context.Context, tx *sql.Tx) error {
+		attempts++
+		if attempts > 1 {
+			return nil
+		}
+		_, err := tx.ExecContext(ctx, `INSERT INTO balances (user_id, seq, balance, taken_at) VALUES ($1, 1, 50, 0)`, user)
+		return err
+	})
+	e := ledgerCredit(user, 5)
+	if err := repo.Append(ctx, e); err != nil {
+		t.Fatalf("append: %v", err)
+	}
+	if attempts != 2 || e.Seq != 1 || e.Balance != 5 {
+		t.Fatalf("%d attempts wrote seq %d balance %d; want 2 attempts, seq 1, balance 5", attempts, e.Seq, e.Balance)
+	}
+}
+
+func TestSQLLedgerAppendGivesUpAfterRepeatedRaces(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewSQLLedgerRepository(repotest.SQLite(t))
+	const user = "u1"
+	if err := repo.Append(ctx, ledgerCredit(user, 10)); err != nil {
+		t.Fatalf("first append: %v", err)
+	}
+
+	attempts := 0
+	repo.SetBeforeAdvance(func(ctx context.Context, tx *sql.Tx) error {
+		attempts++
+		_, err := tx.ExecContext(ctx, `UPDATE balances SET seq = seq + 1 WHERE user_id = $1`, user)
+		return err
+	})
+	err := repo.Append(ctx, ledgerCredit(user, 5))
+	if !errors.Is(err, repository.ErrLedgerRace) {
+		t.Fatalf("got error %v, want %v", err, repository.ErrLedgerRace)
+	}
+	if attempts != repository.LedgerAppendAttempts {
+		t.Fatalf("append made %d attempts, want %d", attempts, repository.LedgerAppendAttempts)
+	}
+
+	repo.SetBeforeAdvance(nil)
+	balance, err := repo.Balance(ctx, user)
+	if err != nil {
+		t.Fatalf("balance: %v", err)
+	}
+	entries, err := repo.List(ctx, user, 0, 10)
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if balance != 10 || len(entries) != 1 {
+		t.Fatalf("failed append left balance %d and %d entries, want 10 and 1", balance, len(entries))
+	}
+}
+
+func ledgerCredit(userID string, amount int64) *models.LedgerEntry {
+	return &models.LedgerEntry{
+		ID:        uuid.NewString(),
+		UserID:    userID,
+		Kind:      models.LedgerCredit,
+		Amount:    amount,
+		Reason:    models.ReasonCorrectAnswer,
+		RefType:   repository.RefAnswer,
+		RefID:     uuid.NewString(),
+		CreatedAt: time.Unix(0, 0),
+	}
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/rprajapati0067/quiz-game-backend/internal/models"
+)
+
+type SQLOTPRepository struct {
+	db *sql.DB
+}
+
+func NewSQLOTPRepository(db *sql.DB) *SQLOTPRepository {
+	return &SQLOTPRepository{db: db}
+}
+
+func (r *SQLOTPRepository) Save(ctx context.Context, o, prev *models.OTP) error {
+	var saved bool
+	var err error
+	if prev == nil {
+		saved, err = execAffected(ctx, r.db,
+			`INSERT INTO otps (phone, code_hash, attempts, expires_at, created_at, window_start)
+			VALUES ($1, $2, $3, $4, $5, $6) ON CONFLICT (phone) DO NOTHING`,
+			o.Phone, o.CodeHash, o.Attempts, sqlTime(o.ExpiresAt), sqlTime(o.CreatedAt), sqlTime(o.WindowStart))
+	} else {
+		saved, err = execAffected(ctx, r.db,
+			`UPDATE otps SET code_hash = $1, attempts = $2, expires_at = $3, created_at = $4, window_start = $5
+			WHERE phone = $6 AND code_hash = $7 AND attempts = $8`,
+			o.CodeHash, o.Attempts, sqlTime(o.ExpiresAt), sqlTime(o.CreatedAt), sqlTime(o.WindowStart),
+			o.Phone, prev.CodeHash, prev.Attempts)
+	}
+	if err != nil {
+		return err
+	}
+	if !saved {
+		return ErrOTPChanged
+	}
+	return nil
+}
+
+func (r *SQLOTPRepository) GetByPhone(ctx context.Context, phone string) (*models.OTP, error) {
+	o, err := scanOTP(r.db.QueryRowContext(ctx,
+		`SELECT `+otpColumns+` FROM otps WHERE phone = $1`, phone))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return o, err
+}
+
+func (r *SQLOTPRepository) UseAttempt(ctx context.Context, phone string, max int32) (*models.OTP, error) {
+	o, err := scanOTP(r.db.QueryRowContext(ctx,
+		`UPDATE otps SET attempts = attempts + 1 WHERE phone = $1 AND attempts < $2 RETURNING `+otpColumns,
+		phone, max))
+	if !errors.Is(err, sql.ErrNoRows) {
+		return o, err
+	}
+	var exists int
+	err = r.db.QueryRowContext(ctx, `SELECT 1 FROM otps WHERE phone = $1`, phone).Scan(&exists)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return nil, ErrOTPAttemptsExhausted
+}
+
+func (r *SQLOTPRepository) Delete(ctx context.Context, phone string) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM otps WHERE phone = $1`, phone)
+	return err
+}
+
+const otpColumns = `phone, code_hash, attempts, expires_at, created_at, window_start`
+
+func scanOTP(row rowScanner) (*models.OTP, error) {
+	var o models.OTP
+	var expiresAt, createdAt, windowStart int64
+	if err := row.Scan(&o.Phone, &o.CodeHash, &o.Attempts, &expiresAt, &createdAt, &windowStart); err != nil {
+		return nil, err
+	}
+	o.ExpiresAt = fromSQLTime(expiresAt)
+	o.CreatedAt = fromSQLTime(createdAt)
+	o.WindowStart = fromSQLTime(windowStart)
+	return &o, nil
+}
@@ -0,0 +1,14 @@
+package repository_test
+
+import (
+	"testing"
+
+	"github.com/rprajapati0067/quiz-game-backend/internal/repository"
+	"github.com/rprajapati0067/quiz-game-backend/internal/repository/repotest"
+)
+
+func TestSQLOTPRepository(t *testing.T) {
+	repotest.TestOTPRepository(t, func(t *testing.T) repository.OTPRepository {
+		return repository.NewSQLOTPRepository(repotest.SQLite(t))
+	})
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	"github.com/rprajapati0067/quiz-game-backend/internal/models"
+)
+
+const questionColumns = `id, text, options, correct_index, slot, created_by`
+
+type SQLQuestionRepository struct {
+	db *sql.DB
+}
+
+func NewSQLQuestionRepository(db *sql.DB) *SQLQuestionRepository {
+	return &SQLQuestionRepository{db: db}
+}
+
+func (r *SQLQuestionRepository) Create(ctx context.Context, q *models.Question) error {
+	options, err := json.Marshal(q.Options)
+	if err != nil {
+		return err
+	}
+	created, err := execAffected(ctx, r.db,
+		`INSERT INTO questions (`+questionColumns+`) VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT DO NOTHING`,
+		q.ID, q.Text, string(options), q.CorrectIndex, q.Slot, q.CreatedBy)
+	if err != nil {
+		return err
+	}
+	if !created {
+		return errQuestionExists
+	}
+	return nil
+}
+
+func (r *SQLQuestionRepository) ListBySlot(ctx context.Context, slot int32) ([]*models.Question, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT `+questionColumns+` FROM questions WHERE slot = $1 ORDER BY id`, slot)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []*models.Question
+	for rows.Next() {
+		q, err := scanQuestion(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, q)
+	}
+	return result, rows.Err()
+}
+
+func (r *SQLQuestionRepository) GetByID(ctx context.Context, id string) (*models.Question, error) {
+	q, err := scanQuestion(r.db.QueryRowContext(ctx,
+		`SELECT `+questionColumns+` FROM questions WHERE id = $1`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errQuestionNotFound
+	}
+	return q, err
+}
+
+func scanQuestion(row rowScanner) (*models.Question, error) {
+	var q models.Question
+	var options string
+	if err := row.Scan(&q.ID, &q.Text, &options, &q.CorrectIndex, &q.Slot, &q.CreatedBy); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(options), &q.Options); err != nil {
+		return nil, err
+	}
+	return &q, nil
+}
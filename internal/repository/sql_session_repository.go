@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/rprajapati0067/quiz-game-backend/internal/models"
+)
+
+const (
+	sessionColumns      = `id, user_id, user_agent, created_at, last_used_at, expires_at, revoked`
+	refreshTokenColumns = `token_hash, session_id, user_id, issued_at, expires_at, rotated`
+)
+
+type SQLSessionRepository struct {
+	db *sql.DB
+}
+
+func NewSQLSessionRepository(db *sql.DB) *SQLSessionRepository {
+	return &SQLSessionRepository{db: db}
+}
+
+func (r *SQLSessionRepository) Create(ctx context.Context, s *models.Session, t *models.RefreshToken) error {
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		created, err := execAffected(ctx, tx,
+			`INSERT INTO sessions (`+sessionColumns+`) VALUES ($1, $2, $3, $4, $5, $6, $7)
+			ON CONFLICT DO NOTHING`,
+			s.ID, s.UserID, s.UserAgent, sqlTime(s.CreatedAt), sqlTime(s.LastUsedAt), sqlTime(s.ExpiresAt), s.Revoked)
+		if err != nil {
+			return err
+		}
+		if !created {
+			return errSessionExists
+		}
+		return insertRefreshToken(ctx, tx, t)
+	})
+}
+
+func (r *SQLSessionRepository) GetByID(ctx context.Context, id string) (*models.Session, error) {
+	s, err := scanSession(r.db.QueryRowContext(ctx, `SELECT `+sessionColumns+` FROM sessions WHERE id = $1`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return s, err
+}
+
+func (r *SQLSessionRepository) ListByUser(ctx context.Context, userID string) ([]*models.Session, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT `+sessionColumns+` FROM sessions WHERE user_id = $1 ORDER BY created_at`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []*models.Session
+	for rows.Next() {
+		s, err := scanSession(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, s)
+	}
+	return result, rows.Err()
+}
+
+func (r *SQLSessionRepository) Revoke(ctx context.Context, id string) error {
+	revoked, err := execAffected(ctx, r.db, `UPDATE sessions SET revoked = TRUE WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return errSessionNotFound
+	}
+	return nil
+}
+
+func (r *SQLSessionRepository) GetRefreshToken(ctx context.Context, hash string) (*models.RefreshToken, error) {
+	var t models.RefreshToken
+	var issuedAt, expiresAt int64
+	err := r.db.QueryRowContext(ctx,
+		`SELECT `+refreshTokenColumns+` FROM refresh_tokens WHERE token_hash = $1`, hash).Scan(
+		&t.Hash, &t.SessionID, &t.UserID, &issuedAt, &expiresAt, &t.Rotated)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	t.IssuedAt = fromSQLTime(issuedAt)
+	t.ExpiresAt = fromSQLTime(expiresAt)
+	return &t, nil
+}
+
+func (r *SQLSessionRepository) Rotate(ctx context.Context, oldHash string, next *models.RefreshToken) error {
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		rotated, err := execAffected(ctx, tx,
+			`UPDATE refresh_tokens SET rotated = TRUE WHERE token_hash = $1 AND rotated = FALSE`, oldHash)
+		if err != nil {
+			return err
+		}
+		if !rotated {
+			var exists int
+			err := tx.QueryRowContext(ctx,
+				`SELECT 1 FROM refresh_tokens WHERE token_hash = $1`, oldHash).Scan(&exists)
+			if errors.Is(err, sql.ErrNoRows) {
+				return errTokenNotFound
+			}
+			if err != nil {
+				return err
+			}
+			return ErrRefreshTokenReused
+		}
+
+		if err := insertRefreshToken(ctx, tx, next); err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx,
+			`UPDATE sessions SET last_used_at = $1 WHERE id = $2`, sqlTime(next.IssuedAt), next.SessionID)
+		return err
+	})
+}
+
+func insertRefreshToken(ctx context.Context, tx *sql.Tx, t *models.RefreshToken) error {
+	_, err := tx.ExecContext(ctx,
+		`INSERT INTO refresh_tokens (`+refreshTokenColumns+`) VALUES ($1, $2, $3, $4, $5, $6)`,
+		t.Hash, t.SessionID, t.UserID, sqlTime(t.IssuedAt), sqlTime(t.ExpiresAt), t.Rotated)
+	return err
+}
+
+func scanSession(row rowScanner) (*models.Session, error) {
+	var s models.Session
+	var createdAt, lastUsedAt, expiresAt int64
+	err := row.Scan(&s.ID, &s.UserID, &s.UserAgent, &createdAt, &lastUsedAt, &expiresAt, &s.Revoked)
+	if err != nil {
+		return nil, err
+	}
+	s.CreatedAt = fromSQLTime(createdAt)
+	s.LastUsedAt = fromSQLTime(lastUsedAt)
+	s.ExpiresAt = fromSQLTime(expiresAt)
+	return &s, nil
+}
@@ -0,0 +1,69 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/rprajapati0067/quiz-game-backend/internal/models"
+	"github.com/rprajapati0067/quiz-game-backend/internal/repository"
+	"github.com/rprajapati0067/quiz-game-backend/internal/repository/repotest"
+)
+
+func TestSQLSessionRepository(t *testing.T) {
+	repotest.TestSessionRepository(t, func(t *testing.T) repository.SessionRepository {
+		return repository.NewSQLSessionRepository(repotest.SQLite(t))
+	})
+}
+
+func TestSQLRotateRollsBackWhenNextTokenFails(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewSQLSessionRepository(repotest.SQLite(t))
+	s, first := newSQLSession(t, repo)
+
+	// Reusing the current hash for the next token violates the primary key
+	// after the old token has already been marked rotated.
+	clash := sqlRefreshToken(s, 2)
+	clash.Hash = first.Hash
+	if err := repo.Rotate(ctx, first.Hash, clash); err == nil {
+		t.Fatal("rotate to a duplicate hash succeeded")
+	}
+	got, err := repo.GetRefreshToken(ctx, first.Hash)
+	if err != nil {
+		t.Fatalf("get token: %v", err)
+	}
+	if got.Rotated {
+		t.Fatal("token marked rotated by a rotation that failed")
+	}
+	if err := repo.Rotate(ctx, first.Hash, sqlRefreshToken(s, 3)); err != nil {
+		t.Fatalf("retry rotate: %v", err)
+	}
+}
+
+func newSQLSession(t *testing.T, repo *repository.SQLSessionRepository) (*models.Session, *models.RefreshToken) {
+	t.Helper()
+	s := &models.Session{
+		ID:         uuid.NewString(),
+		UserID:     uuid.NewString(),
+		CreatedAt:  time.Unix(1, 0),
+		LastUsedAt: time.Unix(1, 0),
+		ExpiresAt:  time.Unix(1000, 0),
+	}
+	first := sqlRefreshToken(s, 1)
+	if err := repo.Create(context.Background(), s, first); err != nil {
+		t.Fatalf("create session: %v", err)
+	}
+	return s, first
+}
+
+func sqlRefreshToken(s *models.Session, issued int) *models.RefreshToken {
+	return &models.RefreshToken{
+		Hash:      uuid.NewString(),
+		SessionID: s.ID,
+		UserID:    s.UserID,
+		IssuedAt:  time.Unix(int64(issued), 0),
+		ExpiresAt: s.ExpiresAt,
+	}
+}
@@ -0,0 +1,77 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rprajapati0067/quiz-game-backend/internal/models"
+	"github.com/rprajapati0067/quiz-game-backend/internal/repository"
+)
+
+func TestMigrations(t *testing.T) {
+	all, err := repository.Migrations()
+	if err != nil {
+		t.Fatalf("migrations: %v", err)
+	}
+	if len(all) == 0 {
+		t.Fatal("no migrations embedded")
+	}
+	for i, m := range all {
+		if m.Version != i+1 {
+			t.Fatalf("migration %d_%s: want version %d; versions must be contiguous", m.Version, m.Name, i+1)
+		}
+	}
+}
+
+func TestMigrateIsIdempotent(t *testing.T) {
+	ctx := context.Background()
+	db, err := repository.OpenSQL("sqlite", t.TempDir()+"/migrate.db")
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer db.Close()
+
+	all, err := repository.Migrations()
+	if err != nil {
+		t.Fatalf("migrations: %v", err)
+	}
+	applied, err := repository.Migrate(ctx, db)
+	if err != nil {
+		t.Fatalf("first migrate: %v", err)
+	}
+	if len(applied) != len(all) {
+		t.Fatalf("first migrate applied %d migrations, want %d", len(applied), len(all))
+	}
+
+	users := repository.NewSQLUserRepository(db)
+	u := &models.User{ID: "u1", Name: "Asha", Phone: "+14155550100", Roles: []models.Role{models.RolePlayer}}
+	if err := users.CreateUser(ctx, u); err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+
+	applied, err = repository.Migrate(ctx, db)
+	if err != nil {
+		t.Fatalf("second migrate: %v", err)
+	}
+	if len(applied) != 0 {
+		t.Fatalf("second migrate applied %d migrations, want none", len(applied))
+	}
+	pending, err := repository.PendingMigrations(ctx, db)
+	if err != nil {
+		t.Fatalf("pending: %v", err)
+	}
+	if len(pending) != 0 {
+		t.Fatalf("%d migrations still pending", len(pending))
+	}
+
+	var recorded int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM schema_migrations`).Scan(&recorded); err != nil {
+		t.Fatalf("count schema_migrations: %v", err)
+	}
+	if recorded != len(all) {
+		t.Fatalf("schema_migrations has %d rows, want %d", recorded, len(all))
+	}
+	if _, err := users.GetByID(ctx, u.ID); err != nil {
+		t.Fatalf("user lost by the second migrate: %v", err)
+	}
+}
@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	"github.com/rprajapati0067/quiz-game-backend/internal/models"
+)
+
+const userColumns = `id, name, phone, email, verified, blocked, roles, version`
+
+type SQLUserRepository struct {
+	db *sql.DB
+}
+
+func NewSQLUserRepository(db *sql.DB) *SQLUserRepository {
+	return &SQLUserRepository{db: db}
+}
+
+func (r *SQLUserRepository) CreateUser(ctx context.Context, u *models.User) error {
+	roles, err := json.Marshal(u.Roles)
+	if err != nil {
+		return err
+	}
+	created, err := execAffected(ctx, r.db,
+		`INSERT INTO users (`+userColumns+`) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT DO NOTHING`,
+		u.ID, u.Name, u.Phone, u.Email, u.Verified, u.Blocked, string(roles), u.Version)
+	if err != nil || created {
+		return err
+	}
+
+	_, err = r.GetByID(ctx, u.ID)
+	switch {
+	case err == nil:
+		return errUserExists
+	case errors.Is(err, ErrNotFound):
+		return errPhoneTaken
+	}
+	return err
+}
+
+func (r *SQLUserRepository) GetByPhone(ctx context.Context, phone string) (*models.User, error) {
+	return r.get(ctx, `SELECT `+userColumns+` FROM users WHERE phone = $1`, phone)
+}
+
+func (r *SQLUserRepository) GetByID(ctx context.Context, id string) (*models.User, error) {
+	return r.get(ctx, `SELECT `+userColumns+` FROM users WHERE id = $1`, id)
+}
+
+func (r *SQLUserRepository) get(ctx context.Context, query string, args ...interface{}) (*models.User, error) {
+	var u models.User
+	var roles string
+	err := r.db.QueryRowContext(ctx, query, args...).Scan(
+		&u.ID, &u.Name, &u.Phone, &u.Email, &u.Verified, &u.Blocked, &roles, &u.Version)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(roles), &u.Roles); err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
+
+func (r *SQLUserRepository) Update(ctx context.Context, u *models.User) error {
+	roles, err := json.Marshal(u.Roles)
+	if err != nil {
+		return err
+	}
+	updated, err := execAffected(ctx, r.db,
+		`UPDATE users SET name = $1, phone = $2, email = $3, verified = $4, blocked = $5, roles = $6,
+			version = version + 1
+		WHERE id = $7 AND version = $8`,
+		u.Name, u.Phone, u.Email, u.Verified, u.Blocked, string(roles), u.ID, u.Version)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		if _, err := r.GetByID(ctx, u.ID); err != nil {
+			return err
+		}
+		return ErrVersionConflict
+	}
+	u.Version++
+	return nil
+}
@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/rprajapati0067/quiz-game-backend/internal/models"
+)
+
+// SQLVoucherRepository hands out unassigned codes in position order.
+// Positions follow upload order, and a code put back by Unassign moves to
+// the end of the pool.
+type SQLVoucherRepository struct {
+	db *sql.DB
+
+	// beforeAssign, when set, runs between picking a free code and taking
+	// it, so tests can make Assign lose the race.
+	beforeAssign func(ctx context.Context, code string) error
+}
+
+func NewSQLVoucherRepository(db *sql.DB) *SQLVoucherRepository {
+	return &SQLVoucherRepository{db: db}
+}
+
+func (r *SQLVoucherRepository) Add(ctx context.Context, awardID string, codes []string, at time.Time) (int, error) {
+	added := 0
+	err := withTx(ctx, r.db, func(tx *sql.Tx) error {
+		var position int64
+		err := tx.QueryRowContext(ctx,
+			`SELECT COALESCE(MAX(position), 0) FROM vouchers WHERE award_id = $1`, awardID).Scan(&position)
+		if err != nil {
+			return err
+		}
+
+		for _, code := range codes {
+			inserted, err := execAffected(ctx, tx,
+				`INSERT INTO vouchers (award_id, code, position, uploaded_at) VALUES ($1, $2, $3, $4)
+				ON CONFLICT DO NOTHING`,
+				awardID, code, position+1, sqlTime(at))
+			if err != nil {
+				return err
+			}
+			if inserted {
+				position++
+				added++
+			}
+		}
+		restocked, err := execAffected(ctx, tx,
+			`UPDATE awards SET total_stock = total_stock + $1, remaining_stock = remaining_stock + $1
+			WHERE id = $2`,
+			added, awardID)
+		if err != nil {
+			return err
+		}
+		if !restocked {
+			return errAwardNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return added, nil
+}
+
+func (r *SQLVoucherRepository) Assign(ctx context.Context, awardID, claimID, userID string, at time.Time) (*models.Voucher, int, error) {
+	for round := 0; round < voucherAssignRounds; round++ {
+		var code string
+		err := r.db.QueryRowContext(ctx,
+			`SELECT code FROM vouchers WHERE award_id = $1 AND claim_id = '' ORDER BY position LIMIT 1`,
+			awardID).Scan(&code)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, 0, ErrNoVoucherAvailable
+		}
+		if err != nil {
+			return nil, 0, err
+		}
+		if r.beforeAssign != nil {
+			if err := r.beforeAssign(ctx, code); err != nil {
+				return nil, 0, err
+			}
+		}
+
+		assigned, err := execAffected(ctx, r.db,
+			`UPDATE vouchers SET claim_id = $1, user_id = $2, assigned_at = $3
+			WHERE award_id = $4 AND code = $5 AND claim_id = ''`,
+			claimID, userID, sqlTime(at), awardID, code)
+		if err != nil {
+			return nil, 0, err
+		}
+		if !assigned {
+			// Another claim took this code first.
+			continue
+		}
+
+		v, err := r.get(ctx, awardID, code)
+		if err != nil {
+			return nil, 0, err
+		}
+		left, err := r.Available(ctx, awardID)
+		if err != nil {
+			return nil, 0, err
+		}
+		return v, left, nil
+	}
+	return nil, 0, ErrVoucherContention
+}
+
+func (r *SQLVoucherRepository) Unassign(ctx context.Context, awardID, claimID string) error {
+	released, err := execAffected(ctx, r.db,
+		`UPDATE vouchers SET claim_id = '', user_id = '', assigned_at = 0,
+			position = (SELECT COALESCE(MAX(position), 0) + 1 FROM vouchers WHERE award_id = $1)
+		WHERE award_id = $1 AND claim_id = $2`,
+		awardID, claimID)
+	if err != nil {
+		return err
+	}
+	if !released {
+		return errVoucherNotFound
+	}
+	return nil
+}
+
+func (r *SQLVoucherRepository) Available(ctx context.Context, awardID string) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM vouchers WHERE award_id = $1 AND claim_id = ''`, awardID).Scan(&count)
+	return count, err
+}
+
+func (r *SQLVoucherRepository) get(ctx context.Context, awardID, code string) (*models.Voucher, error) {
+	var v models.Voucher
+	var uploadedAt, assignedAt int64
+	err := r.db.QueryRowContext(ctx,
+		`SELECT award_id, code, claim_id, user_id, uploaded_at, assigned_at
+		FROM vouchers WHERE award_id = $1 AND code = $2`,
+		awardID, code).Scan(&v.AwardID, &v.Code, &v.ClaimID, &v.UserID, &uploadedAt, &assignedAt)
+	if err != nil {
+		return nil, err
+	}
+	v.UploadedAt = fromSQLTime(uploadedAt)
+	v.AssignedAt = fromSQLTime(assignedAt)
+	return &v, nil
+}
@@ -0,0 +1,91 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/rprajapati0067/quiz-game-backend/internal/repository"
+	"github.com/rprajapati0067/quiz-game-backend/internal/repository/repotest"
+)
+
+func TestSQLVoucherRepository(t *testing.T) {
+	repotest.TestVoucherRepository(t, func(t *testing.T) repository.Repositories {
+		return repository.NewSQLRepositories(repotest.SQLite(t))
+	})
+}
+
+// newSQLVoucherPool stores a digital award with n codes.
+func newSQLVoucherPool(t *testing.T, db *sql.DB, n int) (*repository.SQLVoucherRepository, string) {
+	t.Helper()
+	ctx := context.Background()
+	a := sqlAward(0, 0)
+	a.Digital = true
+	if err := repository.NewSQLAwardRepository(db).Create(ctx, a); err != nil {
+		t.Fatalf("create award: %v", err)
+	}
+	repo := repository.NewSQLVoucherRepository(db)
+	codes := make([]string, n)
+	for i := range codes {
+		codes[i] = fmt.Sprintf("CODE-%d", i)
+	}
+	if _, err := repo.Add(ctx, a.ID, codes, time.Unix(0, 0)); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	return repo, a.ID
+}
+
+// stealCode assigns code to another claim, as a concurrent Assign would.
+func stealCode(db *sql.DB, awardID string) func(ctx context.Context, code string) error {
+	return func(ctx context.Context, code string) error {
+		_, err := db.ExecContext(ctx,
+			`UPDATE vouchers SET claim_id = 'rival', user_id = 'rival' WHERE award_id = $1 AND code = $2`,
+			awardID, code)
+		return err
+	}
+}
+
+func TestSQLAssignRetriesLostRace(t *testing.T) {
+	db := repotest.SQLite(t)
+	repo, awardID := newSQLVoucherPool(t, db, 3)
+	steal := stealCode(db, awardID)
+	lost := false
+	repo.SetBeforeAssign(func(ctx context.Context, code string) error {
+		if lost {
+			return nil
+		}
+		lost = true
+		return steal(ctx, code)
+	})
+
+	v, left, err := repo.Assign(context.Background(), awardID, "claim", "user", time.Unix(1, 0))
+	if err != nil {
+		t.Fatalf("assign: %v", err)
+	}
+	if v.ClaimID != "claim" || left != 1 {
+		t.Fatalf("assigned %+v with %d left, want the next code with 1 left", v, left)
+	}
+}
+
+func TestSQLAssignReportsContentionAfterRepeatedRaces(t *testing.T) {
+	db := repotest.SQLite(t)
+	repo, awardID := newSQLVoucherPool(t, db, repository.VoucherAssignRounds+1)
+	repo.SetBeforeAssign(stealCode(db, awardID))
+
+	_, _, err := repo.Assign(context.Background(), awardID, "claim", "user", time.Unix(1, 0))
+	if !errors.Is(err, repository.ErrVoucherContention) || !errors.Is(err, repository.ErrConflict) {
+		t.Fatalf("assign: got %v, want ErrVoucherContention", err)
+	}
+
+	repo.SetBeforeAssign(nil)
+	v, left, err := repo.Assign(context.Background(), awardID, "claim", "user", time.Unix(1, 0))
+	if err != nil {
+		t.Fatalf("assign after the rivals finished: %v", err)
+	}
+	if v.ClaimID != "claim" || left != 0 {
+		t.Fatalf("assigned %+v with %d left, want the last code", v, left)
+	}
+}
@@ -6,9 +6,17 @@ import (
     "github.com/rprajapati0067/quiz-game-backend/internal/models"
 )
 
+var ErrVersionConflict = conflict("user was modified concurrently")
+
+// UserRepository reports a missing user with an error matching ErrNotFound
+// and a duplicate ID or phone with one matching ErrConflict. Users passed
+// in or returned are never shared with the store.
 type UserRepository interface {
     CreateUser(ctx context.Context, u *models.User) error
     GetByPhone(ctx context.Context, phone string) (*models.User, error)
     GetByID(ctx context.Context, id string) (*models.User, error)
+    // Update saves u only if the stored version still matches u.Version and
+    // returns ErrVersionConflict, which matches ErrConflict, otherwise. On
+    // success u.Version is advanced.
     Update(ctx context.Context, u *models.User) error
 }
@@ -0,0 +1,30 @@
+package repository
+
+import (
+    "context"
+    "errors"
+    "time"
+
+    "github.com/rprajapati0067/quiz-game-backend/internal/models"
+)
+
+var (
+    ErrNoVoucherAvailable = errors.New("no voucher codes left")
+    // ErrVoucherContention means Assign kept losing the codes it found to
+    // concurrent claims. Codes may be left, so the claim can be retried.
+    ErrVoucherContention = conflict("lost every race for a voucher code")
+)
+
+type VoucherRepository interface {
+    // Add stores codes in the award's pool, skipping codes already in it,
+    // and raises the award's stock by the number that were new in the same
+    // transaction, so stock never counts codes that were not stored. It
+    // returns that number.
+    Add(ctx context.Context, awardID string, codes []string, at time.Time) (int, error)
+    // Assign atomically hands one unassigned code to the claim and returns
+    // it together with the number of codes still unassigned.
+    Assign(ctx context.Context, awardID, claimID, userID string, at time.Time) (*models.Voucher, int, error)
+    // Unassign puts the claim's code back in the pool.
+    Unassign(ctx context.Context, awardID, claimID string) error
+    Available(ctx context.Context, awardID string) (int, error)
+}
@@ -2,27 +2,110 @@ package service
 
 import (
     "context"
+    "crypto/rand"
+    "crypto/sha256"
+    "encoding/base64"
+    "encoding/hex"
+    "errors"
+    "fmt"
+    "strings"
+    "time"
+    "unicode/utf8"
 
     "github.com/google/uuid"
 
+    "github.com/rprajapati0067/quiz-game-backend/internal/apperr"
+    "github.com/rprajapati0067/quiz-game-backend/internal/logging"
+    "github.com/rprajapati0067/quiz-game-backend/internal/metrics"
     "github.com/rprajapati0067/quiz-game-backend/internal/models"
     "github.com/rprajapati0067/quiz-game-backend/internal/repository"
+    "github.com/rprajapati0067/quiz-game-backend/internal/token"
+    "github.com/rprajapati0067/quiz-game-backend/internal/validate"
 )
 
+var (
+    ErrUserNotFound        = apperr.New(apperr.NotFound, "USER_NOT_FOUND", "user not found")
+    ErrUserBlocked         = apperr.New(apperr.PermissionDenied, "USER_BLOCKED", "user is blocked")
+    ErrInvalidRefreshToken = apperr.New(apperr.Unauthenticated, "INVALID_REFRESH_TOKEN", "invalid refresh token")
+    ErrSessionNotFound     = apperr.New(apperr.NotFound, "SESSION_NOT_FOUND", "session not found")
+    ErrSessionRevoked      = apperr.New(apperr.Unauthenticated, "SESSION_REVOKED", "session revoked")
+    ErrPhoneRegistered     = apperr.New(apperr.AlreadyExists, "PHONE_REGISTERED", "phone number already registered")
+)
+
+// maxNameLength caps display names, counted in characters.
+const maxNameLength = 100
+
+// sessionTTL is the absolute lifetime of a login. Refreshing rotates the
+// refresh token but never extends the session past this.
+const sessionTTL = 30 * 24 * time.Hour
+
+// LoginResult carries either the issued tokens or, when the caller did not
+// supply an OTP yet, OTPSent to tell them to check their phone.
+type LoginResult struct {
+    User         *models.User
+    SessionID    string
+    AccessToken  string
+    RefreshToken string
+    ExpiresAt    time.Time
+    OTPSent      bool
+}
+
 type AuthService interface {
     Signup(ctx context.Context, name, phone, email string) (*models.User, error)
-    Login(ctx context.Context, phone string) (*models.User, error)
+    Login(ctx context.Context, phone, otp, userAgent string) (*LoginResult, error)
+    VerifyPhone(ctx context.Context, phone, code string) (*models.User, error)
+    Refresh(ctx context.Context, refreshToken string) (*LoginResult, error)
+    Logout(ctx context.Context, refreshToken string) error
+    Authenticate(ctx context.Context, accessToken string) (*token.Claims, error)
+    ListSessions(ctx context.Context) ([]*models.Session, error)
+    RevokeSession(ctx context.Context, sessionID string) error
 }
 
 type authService struct {
-    users repository.UserRepository
+    users       repository.UserRepository
+    sessions    repository.SessionRepository
+    otp         OTPService
+    tokens      *token.Manager
+    adminPhones map[string]bool
+    now         func() time.Time
 }
 
-func NewAuthService(users repository.UserRepository) AuthService {
-    return &authService{users: users}
+// NewAuthService creates the auth service. Users signing up with one of
+// adminPhones are made admins, which is how the first admin is bootstrapped.
+func NewAuthService(users repository.UserRepository, sessions repository.SessionRepository, otp OTPService, tokens *token.Manager, adminPhones []string) AuthService {
+    s := &authService{
+        users:       users,
+        sessions:    sessions,
+        otp:         otp,
+        tokens:      tokens,
+        adminPhones: make(map[string]bool),
+        now:         time.Now,
+    }
+    for _, phone := range adminPhones {
+        if normalized, ok := validate.Phone(phone); ok {
+            phone = normalized
+        }
+        s.adminPhones[phone] = true
+    }
+    return s
 }
 
+// Signup creates an unverified player and sends a code to the phone. The
+// account is kept even if the code cannot be sent; the caller can get a
+// new one from Login.
 func (s *authService) Signup(ctx context.Context, name, phone, email string) (*models.User, error) {
+    var v validate.Violations
+    name = strings.TrimSpace(name)
+    v.Check(name != "", "name", "is required")
+    v.Check(utf8.RuneCountInString(name) <= maxNameLength, "name", "must be at most %d characters", maxNameLength)
+    phone = v.Phone("phone", phone)
+    if email != "" {
+        email = v.Email("email", email)
+    }
+    if err := v.Err(); err != nil {
+        return nil, err
+    }
+
     u := &models.User{
         ID:       uuid.NewString(),
         Name:     name,
@@ -31,14 +114,273 @@ func (s *authService) Signup(ctx context.Context, name, phone, email string) (*m
         Verified: false,
         Blocked:  false,
         Points:   0,
+        Roles:    []models.Role{models.RolePlayer},
+    }
+    if s.adminPhones[phone] {
+        u.Roles = append(u.Roles, models.RoleAdmin)
     }
-    if err := s.users.CreateUser(ctx, u); err != nil {
+    err := s.users.CreateUser(ctx, u)
+    if errors.Is(err, repository.ErrConflict) {
+        return nil, ErrPhoneRegistered
+    }
+    if err != nil {
         return nil, err
     }
+    metrics.Signup()
+    if err := s.otp.Issue(ctx, u.Phone); err != nil {
+        logging.Error(fmt.Sprintf("sending the signup code of user %s failed: %v", u.ID, err))
+    }
     return u, nil
 }
 
-func (s *authService) Login(ctx context.Context, phone string) (*models.User, error) {
-    // TODO: implement GetByPhone properly in repo
-    return nil, nil
+func (s *authService) Login(ctx context.Context, phone, otp, userAgent string) (*LoginResult, error) {
+    phone, err := normalizePhone(phone)
+    if err != nil {
+        return nil, err
+    }
+    u, err := s.users.GetByPhone(ctx, phone)
+    if errors.Is(err, repository.ErrNotFound) {
+        return nil, ErrUserNotFound
+    }
+    if err != nil {
+        return nil, err
+    }
+    if u.Blocked {
+        return nil, ErrUserBlocked
+    }
+
+    if otp == "" {
+        if err := s.otp.Issue(ctx, u.Phone); err != nil {
+            return nil, err
+        }
+        return &LoginResult{User: u, OTPSent: true}, nil
+    }
+
+    if err := s.otp.Verify(ctx, u.Phone, otp); err != nil {
+        return nil, err
+    }
+    // A correct code proves ownership of the phone just like VerifyPhone.
+    if !u.Verified {
+        if u, err = modifyUser(ctx, s.users, u.ID, markVerified); err != nil {
+            return nil, err
+        }
+    }
+
+    now := s.now()
+    session := &models.Session{
+        ID:         uuid.NewString(),
+        UserID:     u.ID,
+        UserAgent:  userAgent,
+        CreatedAt:  now,
+        LastUsedAt: now,
+        ExpiresAt:  now.Add(sessionTTL),
+    }
+    refreshToken, rt, err := s.newRefreshToken(session, now)
+    if err != nil {
+        return nil, err
+    }
+    if err := s.sessions.Create(ctx, session, rt); err != nil {
+        return nil, err
+    }
+    return s.issue(u, session.ID, refreshToken)
+}
+
+func (s *authService) VerifyPhone(ctx context.Context, phone, code string) (*models.User, error) {
+    phone, err := normalizePhone(phone)
+    if err != nil {
+        return nil, err
+    }
+    u, err := s.users.GetByPhone(ctx, phone)
+    if errors.Is(err, repository.ErrNotFound) {
+        return nil, ErrUserNotFound
+    }
+    if err != nil {
+        return nil, err
+    }
+    if err := s.otp.Verify(ctx, phone, code); err != nil {
+        return nil, err
+    }
+    if u.Verified {
+        return u, nil
+    }
+    return modifyUser(ctx, s.users, u.ID, markVerified)
+}
+
+// normalizePhone puts a phone number given at login into the form it was
+// stored in at signup.
+func normalizePhone(raw string) (string, error) {
+    var v validate.Violations
+    phone := v.Phone("phone", raw)
+    return phone, v.Err()
+}
+
+func markVerified(u *models.User) bool {
+    if u.Verified {
+        return false
+    }
+    u.Verified = true
+    return true
+}
+
+func (s *authService) Refresh(ctx context.Context, refreshToken string) (*LoginResult, error) {
+    hash := hashRefreshToken(refreshToken)
+    rt, err := s.sessions.GetRefreshToken(ctx, hash)
+    if err != nil {
+        return nil, err
+    }
+    if rt == nil {
+        return nil, ErrInvalidRefreshToken
+    }
+    session, err := s.sessions.GetByID(ctx, rt.SessionID)
+    if err != nil {
+        return nil, err
+    }
+    now := s.now()
+    if session == nil || session.Revoked || !now.Before(rt.ExpiresAt) {
+        return nil, ErrInvalidRefreshToken
+    }
+    // Presenting an already rotated token means it leaked: whoever holds
+    // the newer one may be an attacker, so the whole family is revoked.
+    if rt.Rotated {
+        return nil, s.revokeReused(ctx, session.ID)
+    }
+
+    u, err := s.users.GetByID(ctx, rt.UserID)
+    if errors.Is(err, repository.ErrNotFound) {
+        return nil, ErrUserNotFound
+    }
+    if err != nil {
+        return nil, err
+    }
+    if u.Blocked {
+        if err := s.sessions.Revoke(ctx, session.ID); err != nil {
+            return nil, err
+        }
+        return nil, ErrUserBlocked
+    }
+
+    next, nextRT, err := s.newRefreshToken(session, now)
+    if err != nil {
+        return nil, err
+    }
+    if err := s.sessions.Rotate(ctx, hash, nextRT); err != nil {
+        if errors.Is(err, repository.ErrRefreshTokenReused) {
+            return nil, s.revokeReused(ctx, session.ID)
+        }
+        return nil, err
+    }
+    return s.issue(u, session.ID, next)
+}
+
+func (s *authService) Logout(ctx context.Context, refreshToken string) error {
+    rt, err := s.sessions.GetRefreshToken(ctx, hashRefreshToken(refreshToken))
+    if err != nil {
+        return err
+    }
+    if rt == nil {
+        return nil
+    }
+    return s.sessions.Revoke(ctx, rt.SessionID)
+}
+
+// Authenticate validates an access token and rejects it once the session
+// it was issued for has been revoked. Every access token names its session,
+// so one without a sid was not issued by Login and is rejected too.
+func (s *authService) Authenticate(ctx context.Context, accessToken string) (*token.Claims, error) {
+    claims, err := s.tokens.Validate(accessToken)
+    if err != nil {
+        return nil, err
+    }
+    if claims.SessionID == "" {
+        return nil, fmt.Errorf("%w: missing session", token.ErrInvalidToken)
+    }
+    session, err := s.sessions.GetByID(ctx, claims.SessionID)
+    if err != nil {
+        return nil, err
+    }
+    if session == nil || session.Revoked {
+        return nil, ErrSessionRevoked
+    }
+    return claims, nil
+}
+
+func (s *authService) ListSessions(ctx context.Context) ([]*models.Session, error) {
+    p, err := caller(ctx)
+    if err != nil {
+        return nil, err
+    }
+    sessions, err := s.sessions.ListByUser(ctx, p.UserID)
+    if err != nil {
+        return nil, err
+    }
+    now := s.now()
+    active := sessions[:0]
+    for _, session := range sessions {
+        if !session.Revoked && now.Before(session.ExpiresAt) {
+            active = append(active, session)
+        }
+    }
+    return active, nil
+}
+
+func (s *authService) RevokeSession(ctx context.Context, sessionID string) error {
+    p, err := caller(ctx)
+    if err != nil {
+        return err
+    }
+    session, err := s.sessions.GetByID(ctx, sessionID)
+    if err != nil {
+        return err
+    }
+    if session == nil || session.UserID != p.UserID {
+        return ErrSessionNotFound
+    }
+    return s.sessions.Revoke(ctx, sessionID)
+}
+
+func (s *authService) issue(u *models.User, sessionID, refreshToken string) (*LoginResult, error) {
+    roles := make([]string, 0, len(u.Roles))
+    for _, r := range u.Roles {
+        roles = append(roles, string(r))
+    }
+    accessToken, expiresAt, err := s.tokens.Issue(u.ID, sessionID, roles)
+    if err != nil {
+        return nil, err
+    }
+    return &LoginResult{
+        User:         u,
+        SessionID:    sessionID,
+        AccessToken:  accessToken,
+        RefreshToken: refreshToken,
+        ExpiresAt:    expiresAt,
+    }, nil
+}
+
+func (s *authService) revokeReused(ctx context.Context, sessionID string) error {
+    if err := s.sessions.Revoke(ctx, sessionID); err != nil {
+        return err
+    }
+    return ErrInvalidRefreshToken
+}
+
+func (s *authService) newRefreshToken(session *models.Session, now time.Time) (string, *models.RefreshToken, error) {
+    raw := make([]byte, 32)
+    if _, err := rand.Read(raw); err != nil {
+        return "", nil, err
+    }
+    refreshToken := base64.RawURLEncoding.EncodeToString(raw)
+    return refreshToken, &models.RefreshToken{
+        Hash:      hashRefreshToken(refreshToken),
+        SessionID: session.ID,
+        UserID:    session.UserID,
+        IssuedAt:  now,
+        ExpiresAt: session.ExpiresAt,
+    }, nil
+}
+
+// Refresh tokens are stored hashed so a leaked session table cannot be
+// replayed; they carry 256 bits of entropy so no salt is needed.
+func hashRefreshToken(refreshToken string) string {
+    sum := sha256.Sum256([]byte(refreshToken))
+    return hex.EncodeToString(sum[:])
 }
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rprajapati0067/quiz-game-backend/internal/models"
+	"github.com/rprajapati0067/quiz-game-backend/internal/repository"
+	"github.com/rprajapati0067/quiz-game-backend/internal/token"
+)
+
+func newTestTokens(t *testing.T) *token.Manager {
+	t.Helper()
+	tokens, err := token.NewManager(token.Config{Issuer: "quiz", AccessTTL: time.Hour}, token.NewHMACKey("k1", []byte("auth-test-secret")))
+	if err != nil {
+		t.Fatalf("token manager: %v", err)
+	}
+	return tokens
+}
+
+// downSender fails every send while down is set.
+type downSender struct {
+	lastCodeSender
+	down bool
+}
+
+func (s *downSender) Send(ctx context.Context, phone, code string) error {
+	if s.down {
+		return errInjected
+	}
+	return s.lastCodeSender.Send(ctx, phone, code)
+}
+
+func TestSignupKeepsAccountWhenCodeIsNotSent(t *testing.T) {
+	ctx := context.Background()
+	sender := &downSender{down: true}
+	users := repository.NewMemoryUserRepository()
+	cfg := DefaultOTPConfig()
+	cfg.ResendCooldown = 0
+	otp := NewOTPService(repository.NewMemoryOTPRepository(), sender, cfg)
+	svc := NewAuthService(users, repository.NewMemorySessionRepository(), otp, newTestTokens(t), nil)
+
+	u, err := svc.Signup(ctx, "Ada", otpTestPhone, "")
+	if err != nil {
+		t.Fatalf("signup: %v", err)
+	}
+	if _, err := users.GetByPhone(ctx, otpTestPhone); err != nil {
+		t.Fatalf("get user: %v", err)
+	}
+	if _, err := svc.Signup(ctx, "Ada", otpTestPhone, ""); !errors.Is(err, ErrPhoneRegistered) {
+		t.Fatalf("signup again: got %v, want ErrPhoneRegistered", err)
+	}
+
+	sender.down = false
+	res, err := svc.Login(ctx, otpTestPhone, "", "test")
+	if err != nil || !res.OTPSent {
+		t.Fatalf("ask for a code: %+v, %v", res, err)
+	}
+	res, err = svc.Login(ctx, otpTestPhone, sender.code(otpTestPhone), "test")
+	if err != nil {
+		t.Fatalf("login: %v", err)
+	}
+	if res.User.ID != u.ID || !res.User.Verified || res.AccessToken == "" {
+		t.Fatalf("login got %+v, want a verified session for %s", res, u.ID)
+	}
+}
+
+func TestAuthenticateChecksSession(t *testing.T) {
+	ctx := context.Background()
+	tokens := newTestTokens(t)
+	sessions := repository.NewMemorySessionRepository()
+	svc := NewAuthService(repository.NewMemoryUserRepository(), sessions, nil, tokens, nil)
+	now := time.Now()
+	for _, id := range []string{"live", "revoked"} {
+		s := &models.Session{ID: id, UserID: "u1", CreatedAt: now, LastUsedAt: now, ExpiresAt: now.Add(time.Hour)}
+		rt := &models.RefreshToken{Hash: "hash-" + id, SessionID: id, UserID: "u1", IssuedAt: now, ExpiresAt: s.ExpiresAt}
+		if err := sessions.Create(ctx, s, rt); err != nil {
+			t.Fatalf("create session: %v", err)
+		}
+	}
+	if err := sessions.Revoke(ctx, "revoked"); err != nil {
+		t.Fatalf("revoke: %v", err)
+	}
+
+	for _, tc := range []struct {
+		session string
+		wantErr error
+	}{
+		{session: "live"},
+		{session: "revoked", wantErr: ErrSessionRevoked},
+		{session: "gone", wantErr: ErrSessionRevoked},
+		{session: "", wantErr: token.ErrInvalidToken},
+	} {
+		raw, _, err := tokens.Issue("u1", tc.session, nil)
+		if err != nil {
+			t.Fatalf("issue: %v", err)
+		}
+		claims, err := svc.Authenticate(ctx, raw)
+		if !errors.Is(err, tc.wantErr) || (err == nil && claims.SessionID != tc.session) {
+			t.Fatalf("session %q: got %+v, %v; want error %v", tc.session, claims, err, tc.wantErr)
+		}
+	}
+}
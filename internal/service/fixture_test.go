@@ -0,0 +1,210 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/rprajapati0067/quiz-game-backend/internal/access"
+	"github.com/rprajapati0067/quiz-game-backend/internal/models"
+	"github.com/rprajapati0067/quiz-game-backend/internal/repository"
+)
+
+var errInjected = errors.New("injected failure")
+
+// lastCodeSender keeps the last code sent to each phone.
+type lastCodeSender struct {
+	mu    sync.Mutex
+	codes map[string]string
+}
+
+func (s *lastCodeSender) Send(ctx context.Context, phone, code string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.codes == nil {
+		s.codes = make(map[string]string)
+	}
+	s.codes[phone] = code
+	return nil
+}
+
+func (s *lastCodeSender) code(phone string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.codes[phone]
+}
+
+// flakyAnswers fails the next Update, as if the process died after the
+// credit was recorded.
+type flakyAnswers struct {
+	*repository.MemoryAnswerRepository
+	failUpdate bool
+}
+
+func (r *flakyAnswers) Update(ctx context.Context, a *models.Answer) error {
+	if r.failUpdate {
+		r.failUpdate = false
+		return errInjected
+	}
+	return r.MemoryAnswerRepository.Update(ctx, a)
+}
+
+// flakyLedger fails the next Append of an entry for failReason, as if the
+// process died just before writing it.
+type flakyLedger struct {
+	*repository.MemoryLedgerRepository
+	failReason models.LedgerReason
+}
+
+func (r *flakyLedger) Append(ctx context.Context, e *models.LedgerEntry) error {
+	if r.failReason != "" && e.Reason == r.failReason {
+		r.failReason = ""
+		return errInjected
+	}
+	return r.MemoryLedgerRepository.Append(ctx, e)
+}
+
+// flakyAwards fails the next PlaceClaim, either before writing anything or,
+// as when a commit's reply is lost, after the claim was stored.
+type flakyAwards struct {
+	*repository.MemoryAwardRepository
+	failPlace      bool
+	failAfterPlace bool
+}
+
+func (r *flakyAwards) PlaceClaim(ctx context.Context, c *models.Claim, debit *models.LedgerEntry) error {
+	if r.failPlace {
+		r.failPlace = false
+		return errInjected
+	}
+	if err := r.MemoryAwardRepository.PlaceClaim(ctx, c, debit); err != nil {
+		return err
+	}
+	if r.failAfterPlace {
+		r.failAfterPlace = false
+		return errInjected
+	}
+	return nil
+}
+
+// fixture runs every service on one set of memory repositories and one
+// clock the test can move. ctx is a player's, and the store holds a
+// question whose answer is option 1 and a physical award in stock.
+type fixture struct {
+	ctx   context.Context
+	user  string
+	clock time.Time
+
+	sender   *lastCodeSender
+	answers  *flakyAnswers
+	ledger   *flakyLedger
+	awards   *flakyAwards
+	vouchers repository.VoucherRepository
+
+	otp       *otpService
+	questions *questionService
+	rewards   *rewardService
+
+	question *models.Question
+	award    *models.Award
+}
+
+const (
+	otpTestPhone = "+15550100"
+
+	// startingPoints is what tests give a player before spending.
+	startingPoints = 100
+
+	awardCost  = 30
+	awardStock = 5
+)
+
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+	ledger := repository.NewMemoryLedgerRepository()
+	awards := repository.NewMemoryAwardRepository(ledger)
+	f := &fixture{
+		user:     uuid.NewString(),
+		clock:    time.Unix(1_700_000_000, 0),
+		sender:   &lastCodeSender{},
+		answers:  &flakyAnswers{MemoryAnswerRepository: repository.NewMemoryAnswerRepository()},
+		ledger:   &flakyLedger{MemoryLedgerRepository: ledger},
+		awards:   &flakyAwards{MemoryAwardRepository: awards},
+		vouchers: repository.NewMemoryVoucherRepository(awards),
+	}
+	f.ctx = f.as(f.user, models.RolePlayer)
+	now := func() time.Time { return f.clock }
+
+	f.otp = NewOTPService(repository.NewMemoryOTPRepository(), f.sender, DefaultOTPConfig()).(*otpService)
+	f.otp.now = now
+	f.questions = NewQuestionService(repository.NewMemoryQuestionRepository(), f.answers, f.ledger, QuestionConfig{}).(*questionService)
+	f.questions.now = now
+	f.rewards = NewRewardService(f.awards, f.ledger, f.vouchers, DefaultRewardConfig()).(*rewardService)
+	f.rewards.now = now
+
+	admin := f.as(uuid.NewString(), models.RoleAdmin)
+	q, err := f.questions.Create(admin, "2 + 2?", []string{"3", "4"}, 1, 1)
+	if err != nil {
+		t.Fatalf("create question: %v", err)
+	}
+	f.question = q
+	award, err := f.rewards.CreateAward(admin, AwardSpec{Product: "mug", PointCost: awardCost}, awardStock)
+	if err != nil {
+		t.Fatalf("create award: %v", err)
+	}
+	f.award = award
+	return f
+}
+
+// as returns a context whose caller is userID holding roles.
+func (f *fixture) as(userID string, roles ...models.Role) context.Context {
+	return access.WithPrincipal(context.Background(), &access.Principal{UserID: userID, Roles: roles})
+}
+
+// give credits userID with points, as if earned by answering.
+func (f *fixture) give(t *testing.T, userID string, points int64) {
+	t.Helper()
+	err := f.ledger.Append(context.Background(), &models.LedgerEntry{
+		ID:     uuid.NewString(),
+		UserID: userID,
+		Kind:   models.LedgerCredit,
+		Amount: points,
+		Reason: models.ReasonCorrectAnswer,
+	})
+	if err != nil {
+		t.Fatalf("give points: %v", err)
+	}
+}
+
+// wantBalance checks userID's balance and the number of ledger entries
+// behind it.
+func (f *fixture) wantBalance(t *testing.T, userID string, balance int64, entries int) {
+	t.Helper()
+	got, err := f.ledger.Balance(context.Background(), userID)
+	if err != nil {
+		t.Fatalf("balance: %v", err)
+	}
+	list, err := f.ledger.List(context.Background(), userID, 0, 100)
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if got != balance || len(list) != entries {
+		t.Fatalf("balance %d from %d entries, want %d from %d", got, len(list), balance, entries)
+	}
+}
+
+// wantStock checks the award's remaining stock.
+func (f *fixture) wantStock(t *testing.T, awardID string, stock int64) {
+	t.Helper()
+	award, err := f.awards.GetByID(context.Background(), awardID)
+	if err != nil {
+		t.Fatalf("get award: %v", err)
+	}
+	if award.RemainingStock != stock {
+		t.Fatalf("%d in stock, want %d", award.RemainingStock, stock)
+	}
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+    "context"
+    "fmt"
+    "os"
+    "sync"
+    "time"
+
+    "github.com/rprajapati0067/quiz-game-backend/internal/logging"
+)
+
+// OTPSender delivers one-time codes to a phone number. Production senders
+// (SMS gateways) implement this; LogOTPSender and FileOTPSender are meant
+// for local development and tests.
+type OTPSender interface {
+    Send(ctx context.Context, phone, code string) error
+}
+
+type LogOTPSender struct{}
+
+func NewLogOTPSender() *LogOTPSender {
+    return &LogOTPSender{}
+}
+
+func (s *LogOTPSender) Send(ctx context.Context, phone, code string) error {
+    logging.Info(fmt.Sprintf("OTP for %s: %s", phone, code))
+    return nil
+}
+
+// FileOTPSender appends every code to a file so tests and local tooling can
+// read it back.
+type FileOTPSender struct {
+    mu   sync.Mutex
+    path string
+}
+
+func NewFileOTPSender(path string) *FileOTPSender {
+    return &FileOTPSender{path: path}
+}
+
+// Check reports whether the outbox file can be opened for appending.
+func (s *FileOTPSender) Check(ctx context.Context) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    f, err := os.OpenFile(s.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+    if err != nil {
+        return err
+    }
+    return f.Close()
+}
+
+func (s *FileOTPSender) Send(ctx context.Context, phone, code string) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    f, err := os.OpenFile(s.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+    if err != nil {
+        return err
+    }
+    defer f.Close()
+
+    _, err = fmt.Fprintf(f, "%s\t%s\t%s\n", time.Now().UTC().Format(time.RFC3339), phone, code)
+    return err
+}
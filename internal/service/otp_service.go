@@ -0,0 +1,155 @@
+package service
+
+import (
+    "context"
+    "crypto/rand"
+    "crypto/sha256"
+    "crypto/subtle"
+    "encoding/hex"
+    "errors"
+    "math/big"
+    "time"
+
+    "github.com/rprajapati0067/quiz-game-backend/internal/apperr"
+    "github.com/rprajapati0067/quiz-game-backend/internal/metrics"
+    "github.com/rprajapati0067/quiz-game-backend/internal/models"
+    "github.com/rprajapati0067/quiz-game-backend/internal/repository"
+)
+
+var (
+    ErrInvalidOTP          = apperr.New(apperr.Unauthenticated, "INVALID_OTP", "invalid or expired otp")
+    ErrOTPAttemptsExceeded = apperr.New(apperr.ResourceExhausted, "OTP_ATTEMPTS_EXCEEDED", "too many otp attempts")
+    ErrOTPResendTooSoon    = apperr.New(apperr.ResourceExhausted, "OTP_RESEND_TOO_SOON", "wait before requesting another otp")
+)
+
+type OTPConfig struct {
+    Length      int
+    TTL         time.Duration
+    MaxAttempts int32
+    // Window is how long attempts add up across reissued codes, counted
+    // from the first code. A phone that has used them all gets no new code
+    // until the window ends.
+    Window time.Duration
+    // ResendCooldown is the least time between two codes for one phone.
+    ResendCooldown time.Duration
+}
+
+func DefaultOTPConfig() OTPConfig {
+    return OTPConfig{
+        Length:         6,
+        TTL:            5 * time.Minute,
+        MaxAttempts:    5,
+        Window:         15 * time.Minute,
+        ResendCooldown: 30 * time.Second,
+    }
+}
+
+// otpSaveAttempts bounds how often Issue starts over when the stored code
+// changed between reading and replacing it.
+const otpSaveAttempts = 3
+
+type OTPService interface {
+    Issue(ctx context.Context, phone string) error
+    Verify(ctx context.Context, phone, code string) error
+}
+
+type otpService struct {
+    repo   repository.OTPRepository
+    sender OTPSender
+    cfg    OTPConfig
+    now    func() time.Time
+}
+
+func NewOTPService(repo repository.OTPRepository, sender OTPSender, cfg OTPConfig) OTPService {
+    return &otpService{repo: repo, sender: sender, cfg: cfg, now: time.Now}
+}
+
+func (s *otpService) Issue(ctx context.Context, phone string) error {
+    code, err := generateOTP(s.cfg.Length)
+    if err != nil {
+        return err
+    }
+    for attempt := 1; ; attempt++ {
+        err = s.save(ctx, phone, code)
+        if !errors.Is(err, repository.ErrOTPChanged) || attempt == otpSaveAttempts {
+            break
+        }
+    }
+    if err != nil {
+        return err
+    }
+    err = s.sender.Send(ctx, phone, code)
+    metrics.OTPSent(err)
+    return err
+}
+
+// save replaces the phone's code with code. Within the window of the
+// previous code its attempt count carries over, so asking for a new code
+// does not buy more guesses. Save fails if the previous code changed in
+// the meantime, which keeps concurrent requests from undercounting.
+func (s *otpService) save(ctx context.Context, phone, code string) error {
+    prev, err := s.repo.GetByPhone(ctx, phone)
+    if err != nil {
+        return err
+    }
+    now := s.now()
+    o := &models.OTP{
+        Phone:       phone,
+        CodeHash:    hashOTP(phone, code),
+        ExpiresAt:   now.Add(s.cfg.TTL),
+        CreatedAt:   now,
+        WindowStart: now,
+    }
+    if prev != nil {
+        if now.Before(prev.CreatedAt.Add(s.cfg.ResendCooldown)) {
+            return ErrOTPResendTooSoon
+        }
+        if now.Before(prev.WindowStart.Add(s.cfg.Window)) {
+            if prev.Attempts >= s.cfg.MaxAttempts {
+                return ErrOTPAttemptsExceeded
+            }
+            o.Attempts = prev.Attempts
+            o.WindowStart = prev.WindowStart
+        }
+    }
+    return s.repo.Save(ctx, o, prev)
+}
+
+// Verify counts the attempt before comparing the code, in one conditional
+// write, so concurrent guesses cannot exceed MaxAttempts. Expired and
+// exhausted codes are kept until the next Issue so their count carries.
+func (s *otpService) Verify(ctx context.Context, phone, code string) error {
+    o, err := s.repo.UseAttempt(ctx, phone, s.cfg.MaxAttempts)
+    if errors.Is(err, repository.ErrOTPAttemptsExhausted) {
+        return ErrOTPAttemptsExceeded
+    }
+    if err != nil {
+        return err
+    }
+    if o == nil || !s.now().Before(o.ExpiresAt) {
+        return ErrInvalidOTP
+    }
+    if subtle.ConstantTimeCompare([]byte(hashOTP(phone, code)), []byte(o.CodeHash)) != 1 {
+        return ErrInvalidOTP
+    }
+    return s.repo.Delete(ctx, phone)
+}
+
+func generateOTP(length int) (string, error) {
+    digits := make([]byte, length)
+    for i := range digits {
+        n, err := rand.Int(rand.Reader, big.NewInt(10))
+        if err != nil {
+            return "", err
+        }
+        digits[i] = byte('0' + n.Int64())
+    }
+    return string(digits), nil
+}
+
+// hashOTP salts the code with the phone number so equal codes issued to
+// different users never share a stored hash.
+func hashOTP(phone, code string) string {
+    sum := sha256.Sum256([]byte(phone + ":" + code))
+    return hex.EncodeToString(sum[:])
+}
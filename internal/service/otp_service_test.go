@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// wrongCode returns a code other than the one last sent.
+func (f *fixture) wrongCode() string {
+	if f.sender.code(otpTestPhone) == "000000" {
+		return "111111"
+	}
+	return "000000"
+}
+
+func TestOTPVerify(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture(t)
+	if err := f.otp.Issue(ctx, otpTestPhone); err != nil {
+		t.Fatalf("issue: %v", err)
+	}
+	if err := f.otp.Verify(ctx, otpTestPhone, f.wrongCode()); !errors.Is(err, ErrInvalidOTP) {
+		t.Fatalf("wrong code: got %v, want ErrInvalidOTP", err)
+	}
+	if err := f.otp.Verify(ctx, otpTestPhone, f.sender.code(otpTestPhone)); err != nil {
+		t.Fatalf("right code: %v", err)
+	}
+	if err := f.otp.Verify(ctx, otpTestPhone, f.sender.code(otpTestPhone)); !errors.Is(err, ErrInvalidOTP) {
+		t.Fatalf("used code: got %v, want ErrInvalidOTP", err)
+	}
+}
+
+func TestOTPResendCooldown(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture(t)
+	if err := f.otp.Issue(ctx, otpTestPhone); err != nil {
+		t.Fatalf("issue: %v", err)
+	}
+	f.clock = f.clock.Add(f.otp.cfg.ResendCooldown - time.Second)
+	if err := f.otp.Issue(ctx, otpTestPhone); !errors.Is(err, ErrOTPResendTooSoon) {
+		t.Fatalf("resend within cooldown: got %v, want ErrOTPResendTooSoon", err)
+	}
+	f.clock = f.clock.Add(time.Second)
+	if err := f.otp.Issue(ctx, otpTestPhone); err != nil {
+		t.Fatalf("resend after cooldown: %v", err)
+	}
+}
+
+func TestOTPAttemptsCarryAcrossReissues(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture(t)
+	max := int(f.otp.cfg.MaxAttempts)
+
+	// Spread the guesses over fresh codes; reissuing must not reset them.
+	for i := 0; i < max; i++ {
+		if err := f.otp.Issue(ctx, otpTestPhone); err != nil {
+			t.Fatalf("issue %d: %v", i, err)
+		}
+		if err := f.otp.Verify(ctx, otpTestPhone, f.wrongCode()); !errors.Is(err, ErrInvalidOTP) {
+			t.Fatalf("guess %d: got %v, want ErrInvalidOTP", i, err)
+		}
+		f.clock = f.clock.Add(f.otp.cfg.ResendCooldown)
+	}
+	if err := f.otp.Verify(ctx, otpTestPhone, f.sender.code(otpTestPhone)); !errors.Is(err, ErrOTPAttemptsExceeded) {
+		t.Fatalf("right code after %d guesses: got %v, want ErrOTPAttemptsExceeded", max, err)
+	}
+	if err := f.otp.Issue(ctx, otpTestPhone); !errors.Is(err, ErrOTPAttemptsExceeded) {
+		t.Fatalf("reissue while locked out: got %v, want ErrOTPAttemptsExceeded", err)
+	}
+
+	// A new window starts the count again.
+	f.clock = f.clock.Add(f.otp.cfg.Window)
+	if err := f.otp.Issue(ctx, otpTestPhone); err != nil {
+		t.Fatalf("issue in a new window: %v", err)
+	}
+	if err := f.otp.Verify(ctx, otpTestPhone, f.sender.code(otpTestPhone)); err != nil {
+		t.Fatalf("right code in a new window: %v", err)
+	}
+}
+
+func TestOTPConcurrentGuessesStayWithinMaxAttempts(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture(t)
+	if err := f.otp.Issue(ctx, otpTestPhone); err != nil {
+		t.Fatalf("issue: %v", err)
+	}
+
+	const guesses = 50
+	errs := make([]error, guesses)
+	wrong := f.wrongCode()
+	var wg sync.WaitGroup
+	for i := range errs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = f.otp.Verify(ctx, otpTestPhone, wrong)
+		}()
+	}
+	wg.Wait()
+
+	checked := 0
+	for _, err := range errs {
+		switch {
+		case errors.Is(err, ErrInvalidOTP):
+			checked++
+		case !errors.Is(err, ErrOTPAttemptsExceeded):
+			t.Fatalf("guess: %v", err)
+		}
+	}
+	if checked != int(f.otp.cfg.MaxAttempts) {
+		t.Fatalf("%d of %d concurrent guesses were checked, want %d", checked, guesses, f.otp.cfg.MaxAttempts)
+	}
+}
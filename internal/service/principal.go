@@ -0,0 +1,17 @@
+package service
+
+import (
+    "context"
+
+    "github.com/rprajapati0067/quiz-game-backend/internal/access"
+)
+
+// caller returns the authenticated principal placed in ctx by the HTTP
+// middleware or gRPC interceptors.
+func caller(ctx context.Context) (*access.Principal, error) {
+    p, ok := access.PrincipalFromContext(ctx)
+    if !ok {
+        return nil, access.ErrUnauthenticated
+    }
+    return p, nil
+}
@@ -2,34 +2,93 @@ package service
 
 import (
     "context"
+    "crypto/sha256"
+    "encoding/binary"
+    "errors"
+    "fmt"
+    "math/rand/v2"
+    "strings"
+    "time"
+    "unicode/utf8"
 
     "github.com/google/uuid"
 
+    "github.com/rprajapati0067/quiz-game-backend/internal/access"
+    "github.com/rprajapati0067/quiz-game-backend/internal/apperr"
+    "github.com/rprajapati0067/quiz-game-backend/internal/metrics"
     "github.com/rprajapati0067/quiz-game-backend/internal/models"
     "github.com/rprajapati0067/quiz-game-backend/internal/repository"
+    "github.com/rprajapati0067/quiz-game-backend/internal/validate"
 )
 
+var (
+    ErrQuestionNotFound     = apperr.New(apperr.NotFound, "QUESTION_NOT_FOUND", "question not found")
+    ErrInvalidOption        = apperr.New(apperr.InvalidArgument, "INVALID_OPTION", "selected option out of range")
+    ErrAlreadyAnswered      = apperr.New(apperr.AlreadyExists, "ALREADY_ANSWERED", "question already answered")
+    ErrIdempotencyKeyReused = apperr.New(apperr.FailedPrecondition, "IDEMPOTENCY_KEY_REUSED", "idempotency key already used for another question")
+    ErrInvalidSlot          = apperr.New(apperr.InvalidArgument, "INVALID_SLOT", "slot must be positive")
+    ErrCannotAnswer         = apperr.New(apperr.PermissionDenied, "CANNOT_ANSWER", "question managers see the correct answers and cannot submit answers")
+)
+
+const pointsPerCorrectAnswer int64 = 10
+
+// Limits on new questions. Lengths are counted in characters.
+const (
+    minOptions        = 2
+    maxOptions        = 10
+    maxQuestionLength = 500
+    maxOptionLength   = 200
+)
+
+type QuestionConfig struct {
+    // ShuffleOptions presents options to each player in a different, stable
+    // order so answers cannot be shared as "pick the second one".
+    ShuffleOptions bool
+}
+
+// AnswerResult is the outcome of SubmitAnswer. Replayed is set when the
+// request repeated an earlier idempotency key and nothing was recorded.
+type AnswerResult struct {
+    Answer        *models.Answer
+    UpdatedPoints int64
+    Replayed      bool
+}
+
 type QuestionService interface {
-    Create(ctx context.Context, text string, options []string, correctIndex, slot int32, createdBy string) (*models.Question, error)
+    Create(ctx context.Context, text string, options []string, correctIndex, slot int32) (*models.Question, error)
     ListBySlot(ctx context.Context, slot int32) ([]*models.Question, error)
+    ListForPlayer(ctx context.Context, slot int32) ([]*models.PlayerQuestion, error)
+    SubmitAnswer(ctx context.Context, questionID string, selectedIndex int32, idempotencyKey string) (*AnswerResult, error)
 }
 
 type questionService struct {
-    repo repository.QuestionRepository
+    repo    repository.QuestionRepository
+    answers repository.AnswerRepository
+    ledger  repository.LedgerRepository
+    cfg     QuestionConfig
+    now     func() time.Time
 }
 
-func NewQuestionService(repo repository.QuestionRepository) QuestionService {
-    return &questionService{repo: repo}
+func NewQuestionService(repo repository.QuestionRepository, answers repository.AnswerRepository, ledger repository.LedgerRepository, cfg QuestionConfig) QuestionService {
+    return &questionService{repo: repo, answers: answers, ledger: ledger, cfg: cfg, now: time.Now}
 }
 
-func (s *questionService) Create(ctx context.Context, text string, options []string, correctIndex, slot int32, createdBy string) (*models.Question, error) {
+func (s *questionService) Create(ctx context.Context, text string, options []string, correctIndex, slot int32) (*models.Question, error) {
+    p, err := caller(ctx)
+    if err != nil {
+        return nil, err
+    }
+    text, options, err = questionFields(text, options, correctIndex, slot)
+    if err != nil {
+        return nil, err
+    }
     q := &models.Question{
         ID:           uuid.NewString(),
         Text:         text,
         Options:      options,
         CorrectIndex: correctIndex,
         Slot:         slot,
-        CreatedBy:    createdBy,
+        CreatedBy:    p.UserID,
     }
     if err := s.repo.Create(ctx, q); err != nil {
         return nil, err
@@ -37,6 +96,213 @@ func (s *questionService) Create(ctx context.Context, text string, options []str
     return q, nil
 }
 
+// questionFields validates a new question and returns its text and options
+// with surrounding whitespace trimmed.
+func questionFields(text string, options []string, correctIndex, slot int32) (string, []string, error) {
+    var v validate.Violations
+    text = strings.TrimSpace(text)
+    v.Check(text != "", "text", "is required")
+    v.Check(utf8.RuneCountInString(text) <= maxQuestionLength, "text", "must be at most %d characters", maxQuestionLength)
+
+    v.Check(len(options) >= minOptions && len(options) <= maxOptions, "options", "must have between %d and %d entries", minOptions, maxOptions)
+    trimmed := make([]string, len(options))
+    seen := make(map[string]int, len(options))
+    for i, o := range options {
+        field := fmt.Sprintf("options[%d]", i)
+        o = strings.TrimSpace(o)
+        trimmed[i] = o
+        v.Check(o != "", field, "is required")
+        v.Check(utf8.RuneCountInString(o) <= maxOptionLength, field, "must be at most %d characters", maxOptionLength)
+        key := strings.ToLower(o)
+        if first, dup := seen[key]; dup && o != "" {
+            v.Add(field, "duplicates options[%d]", first)
+        } else {
+            seen[key] = i
+        }
+    }
+
+    v.Check(correctIndex >= 0 && int(correctIndex) < len(options), "correct_index", "must refer to one of the %d options", len(options))
+    v.Check(slot > 0, "slot", "must be positive")
+    return text, trimmed, v.Err()
+}
+
 func (s *questionService) ListBySlot(ctx context.Context, slot int32) ([]*models.Question, error) {
+    if slot <= 0 {
+        return nil, ErrInvalidSlot
+    }
     return s.repo.ListBySlot(ctx, slot)
 }
+
+func (s *questionService) ListForPlayer(ctx context.Context, slot int32) ([]*models.PlayerQuestion, error) {
+    p, err := caller(ctx)
+    if err != nil {
+        return nil, err
+    }
+    if slot <= 0 {
+        return nil, ErrInvalidSlot
+    }
+    qs, err := s.repo.ListBySlot(ctx, slot)
+    if err != nil {
+        return nil, err
+    }
+
+    result := make([]*models.PlayerQuestion, 0, len(qs))
+    for _, q := range qs {
+        options := q.Options
+        if s.cfg.ShuffleOptions {
+            perm := optionOrder(p.UserID, q.ID, len(q.Options))
+            options = make([]string, len(perm))
+            for displayed, original := range perm {
+                options[displayed] = q.Options[original]
+            }
+        }
+        result = append(result, &models.PlayerQuestion{
+            ID:      q.ID,
+            Text:    q.Text,
+            Options: options,
+            Slot:    q.Slot,
+        })
+    }
+    return result, nil
+}
+
+// SubmitAnswer grades selectedIndex, which refers to the option order the
+// caller was shown by ListForPlayer. Callers who can manage questions, and
+// so can see the correct answers, cannot answer.
+func (s *questionService) SubmitAnswer(ctx context.Context, questionID string, selectedIndex int32, idempotencyKey string) (*AnswerResult, error) {
+    p, err := caller(ctx)
+    if err != nil {
+        return nil, err
+    }
+    if p.Can(access.PermManageQuestion) {
+        return nil, ErrCannotAnswer
+    }
+    if idempotencyKey != "" {
+        prev, err := s.answers.GetByIdempotencyKey(ctx, p.UserID, idempotencyKey)
+        if err != nil {
+            return nil, err
+        }
+        if prev != nil {
+            if err := s.settle(ctx, prev); err != nil {
+                return nil, err
+            }
+            return replay(prev, questionID)
+        }
+    }
+
+    q, err := s.repo.GetByID(ctx, questionID)
+    if errors.Is(err, repository.ErrNotFound) {
+        return nil, ErrQuestionNotFound
+    }
+    if err != nil {
+        return nil, err
+    }
+    if selectedIndex < 0 || int(selectedIndex) >= len(q.Options) {
+        return nil, ErrInvalidOption
+    }
+    if s.cfg.ShuffleOptions {
+        selectedIndex = int32(optionOrder(p.UserID, q.ID, len(q.Options))[selectedIndex])
+    }
+
+    a := &models.Answer{
+        UserID:         p.UserID,
+        QuestionID:     q.ID,
+        SelectedIndex:  selectedIndex,
+        SubmittedAt:    s.now(),
+        Correct:        selectedIndex == q.CorrectIndex,
+        IdempotencyKey: idempotencyKey,
+    }
+    if a.Correct {
+        a.PointsAwarded = pointsPerCorrectAnswer
+    } else {
+        // Nothing to credit, so the answer is complete once stored.
+        balance, err := s.ledger.Balance(ctx, p.UserID)
+        if err != nil {
+            return nil, err
+        }
+        a.UpdatedPoints = balance
+    }
+    if err := s.answers.Create(ctx, a); err != nil {
+        if !errors.Is(err, repository.ErrAnswerExists) {
+            return nil, err
+        }
+        // Lost a race against a concurrent retry, or an earlier attempt
+        // stopped before crediting. Either way finish that answer first.
+        prev, getErr := s.answers.GetByUserAndQuestion(ctx, p.UserID, q.ID)
+        if getErr != nil {
+            return nil, getErr
+        }
+        if prev == nil {
+            return nil, ErrAlreadyAnswered
+        }
+        if err := s.settle(ctx, prev); err != nil {
+            return nil, err
+        }
+        if idempotencyKey != "" && prev.IdempotencyKey == idempotencyKey {
+            return replay(prev, questionID)
+        }
+        return nil, ErrAlreadyAnswered
+    }
+    metrics.AnswerSubmitted(q.Slot, a.Correct)
+
+    if err := s.settle(ctx, a); err != nil {
+        return nil, err
+    }
+    return &AnswerResult{Answer: a, UpdatedPoints: a.UpdatedPoints}, nil
+}
+
+// settle credits the points for a stored answer and records the resulting
+// balance on it. A correct answer always leaves a positive balance, so
+// UpdatedPoints of zero means an earlier attempt stopped before finishing.
+// The ledger entry is keyed on the answer, so settling again completes a
+// missing credit or picks up the recorded one rather than paying twice.
+func (s *questionService) settle(ctx context.Context, a *models.Answer) error {
+    if a.PointsAwarded == 0 || a.UpdatedPoints != 0 {
+        return nil
+    }
+    e := &models.LedgerEntry{
+        ID:        entryID("answer", a.UserID, a.QuestionID),
+        UserID:    a.UserID,
+        Kind:      models.LedgerCredit,
+        Amount:    a.PointsAwarded,
+        Reason:    models.ReasonCorrectAnswer,
+        RefType:   repository.RefAnswer,
+        RefID:     a.QuestionID,
+        CreatedAt: a.SubmittedAt,
+    }
+    err := s.ledger.Append(ctx, e)
+    switch {
+    case err == nil:
+        metrics.PointsAwarded(a.PointsAwarded)
+    case !errors.Is(err, repository.ErrEntryExists):
+        return err
+    }
+    a.UpdatedPoints = e.Balance
+    return s.answers.Update(ctx, a)
+}
+
+// ledgerNamespace scopes the ledger entry IDs made by entryID.
+var ledgerNamespace = uuid.MustParse("5d0c6f2e-8a4b-4e1f-9c3d-7b2a1e6f4c80")
+
+// entryID derives a ledger entry ID from the record the entry belongs to,
+// so every attempt at the same write carries the same ID and the ledger
+// records it once.
+func entryID(parts ...string) string {
+    return uuid.NewSHA1(ledgerNamespace, []byte(strings.Join(parts, ":"))).String()
+}
+
+func replay(prev *models.Answer, questionID string) (*AnswerResult, error) {
+    if prev.QuestionID != questionID {
+        return nil, ErrIdempotencyKeyReused
+    }
+    return &AnswerResult{Answer: prev, UpdatedPoints: prev.UpdatedPoints, Replayed: true}, nil
+}
+
+// optionOrder returns the permutation a user sees for a question, where
+// element i is the original index of the option displayed at position i.
+// It is derived from the IDs so it never has to be stored.
+func optionOrder(userID, questionID string, n int) []int {
+    sum := sha256.Sum256([]byte(userID + ":" + questionID))
+    rng := rand.New(rand.NewPCG(binary.BigEndian.Uint64(sum[:8]), binary.BigEndian.Uint64(sum[8:16])))
+    return rng.Perm(n)
+}
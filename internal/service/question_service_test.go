@@ -0,0 +1,301 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/rprajapati0067/quiz-game-backend/internal/models"
+)
+
+// wantCredited checks the caller was paid exactly once for the question.
+func (f *fixture) wantCredited(t *testing.T) {
+	t.Helper()
+	f.wantBalance(t, f.user, pointsPerCorrectAnswer, 1)
+	a, err := f.answers.GetByUserAndQuestion(f.ctx, f.user, f.question.ID)
+	if err != nil {
+		t.Fatalf("get answer: %v", err)
+	}
+	if a.UpdatedPoints != pointsPerCorrectAnswer {
+		t.Fatalf("answer records %d points, want %d", a.UpdatedPoints, pointsPerCorrectAnswer)
+	}
+}
+
+func TestSubmitAnswerReplayFinishesUnrecordedBalance(t *testing.T) {
+	f := newFixture(t)
+	f.answers.failUpdate = true
+	if _, err := f.questions.SubmitAnswer(f.ctx, f.question.ID, 1, "key"); !errors.Is(err, errInjected) {
+		t.Fatalf("submit: got %v, want the injected failure", err)
+	}
+
+	res, err := f.questions.SubmitAnswer(f.ctx, f.question.ID, 1, "key")
+	if err != nil {
+		t.Fatalf("retry: %v", err)
+	}
+	if !res.Replayed || res.UpdatedPoints != pointsPerCorrectAnswer {
+		t.Fatalf("retry got %+v, want a replay reporting %d points", res, pointsPerCorrectAnswer)
+	}
+	f.wantCredited(t)
+}
+
+func TestSubmitAnswerReplayFinishesMissingCredit(t *testing.T) {
+	f := newFixture(t)
+	f.ledger.failReason = models.ReasonCorrectAnswer
+	if _, err := f.questions.SubmitAnswer(f.ctx, f.question.ID, 1, "key"); !errors.Is(err, errInjected) {
+		t.Fatalf("submit: got %v, want the injected failure", err)
+	}
+
+	res, err := f.questions.SubmitAnswer(f.ctx, f.question.ID, 1, "key")
+	if err != nil {
+		t.Fatalf("retry: %v", err)
+	}
+	if !res.Replayed || res.UpdatedPoints != pointsPerCorrectAnswer {
+		t.Fatalf("retry got %+v, want a replay reporting %d points", res, pointsPerCorrectAnswer)
+	}
+	f.wantCredited(t)
+}
+
+func TestSubmitAnswerWithoutKeyFinishesMissingCredit(t *testing.T) {
+	f := newFixture(t)
+	f.ledger.failReason = models.ReasonCorrectAnswer
+	if _, err := f.questions.SubmitAnswer(f.ctx, f.question.ID, 1, ""); !errors.Is(err, errInjected) {
+		t.Fatalf("submit: got %v, want the injected failure", err)
+	}
+
+	if _, err := f.questions.SubmitAnswer(f.ctx, f.question.ID, 1, ""); !errors.Is(err, ErrAlreadyAnswered) {
+		t.Fatalf("resubmit: got %v, want ErrAlreadyAnswered", err)
+	}
+	f.wantCredited(t)
+	if _, err := f.questions.SubmitAnswer(f.ctx, f.question.ID, 1, ""); !errors.Is(err, ErrAlreadyAnswered) {
+		t.Fatalf("resubmit: got %v, want ErrAlreadyAnswered", err)
+	}
+	f.wantCredited(t)
+}
+
+func TestSubmitAnswerRejectsQuestionManagers(t *testing.T) {
+	f := newFixture(t)
+	for _, role := range []models.Role{models.RoleEditor, models.RoleAdmin} {
+		if _, err := f.questions.SubmitAnswer(f.as(uuid.NewString(), role), f.question.ID, 1, ""); !errors.Is(err, ErrCannotAnswer) {
+			t.Fatalf("submit as %s: got %v, want ErrCannotAnswer", role, err)
+		}
+	}
+}
+
+func TestSubmitAnswerScores(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		selected    int32
+		wantCorrect bool
+		wantPoints  int64
+	}{
+		{name: "correct", selected: 1, wantCorrect: true, wantPoints: startingPoints + pointsPerCorrectAnswer},
+		{name: "incorrect", selected: 0, wantPoints: startingPoints},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			f := newFixture(t)
+			f.give(t, f.user, startingPoints)
+			res, err := f.questions.SubmitAnswer(f.ctx, f.question.ID, tc.selected, "")
+			if err != nil {
+				t.Fatalf("submit: %v", err)
+			}
+			a := res.Answer
+			if a.Correct != tc.wantCorrect || a.SelectedIndex != tc.selected || res.UpdatedPoints != tc.wantPoints || res.Replayed {
+				t.Fatalf("got %+v with %d points, want correct=%v and %d points", a, res.UpdatedPoints, tc.wantCorrect, tc.wantPoints)
+			}
+			if !a.SubmittedAt.Equal(f.clock) || a.UserID != f.user {
+				t.Fatalf("answer %+v, want one by %s at %v", a, f.user, f.clock)
+			}
+			entries := 1
+			if tc.wantCorrect {
+				entries = 2
+			}
+			f.wantBalance(t, f.user, tc.wantPoints, entries)
+
+			stored, err := f.answers.GetByUserAndQuestion(f.ctx, f.user, f.question.ID)
+			if err != nil {
+				t.Fatalf("get answer: %v", err)
+			}
+			if stored.Correct != tc.wantCorrect || stored.UpdatedPoints != tc.wantPoints {
+				t.Fatalf("stored %+v, want correct=%v with %d points", stored, tc.wantCorrect, tc.wantPoints)
+			}
+		})
+	}
+}
+
+func TestSubmitAnswerRejectsBadInput(t *testing.T) {
+	f := newFixture(t)
+	for _, tc := range []struct {
+		name     string
+		question string
+		selected int32
+		want     error
+	}{
+		{name: "unknown question", question: uuid.NewString(), selected: 1, want: ErrQuestionNotFound},
+		{name: "negative option", question: f.question.ID, selected: -1, want: ErrInvalidOption},
+		{name: "option past the end", question: f.question.ID, selected: 2, want: ErrInvalidOption},
+	} {
+		if _, err := f.questions.SubmitAnswer(f.ctx, tc.question, tc.selected, ""); !errors.Is(err, tc.want) {
+			t.Fatalf("%s: got %v, want %v", tc.name, err, tc.want)
+		}
+	}
+	f.wantBalance(t, f.user, 0, 0)
+	if _, err := f.questions.SubmitAnswer(f.ctx, f.question.ID, 1, ""); err != nil {
+		t.Fatalf("submit after rejected attempts: %v", err)
+	}
+}
+
+func TestSubmitAnswerOnlyOncePerQuestion(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		first, next string
+	}{
+		{name: "without keys"},
+		{name: "with a new key", first: "k1", next: "k2"},
+		{name: "key after none", next: "k1"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			f := newFixture(t)
+			if _, err := f.questions.SubmitAnswer(f.ctx, f.question.ID, 1, tc.first); err != nil {
+				t.Fatalf("submit: %v", err)
+			}
+			for _, selected := range []int32{1, 0} {
+				if _, err := f.questions.SubmitAnswer(f.ctx, f.question.ID, selected, tc.next); !errors.Is(err, ErrAlreadyAnswered) {
+					t.Fatalf("answer %d again: got %v, want ErrAlreadyAnswered", selected, err)
+				}
+			}
+			f.wantCredited(t)
+		})
+	}
+
+	f := newFixture(t)
+	if _, err := f.questions.SubmitAnswer(f.ctx, f.question.ID, 1, ""); err != nil {
+		t.Fatalf("submit: %v", err)
+	}
+	if _, err := f.questions.SubmitAnswer(f.as(uuid.NewString()), f.question.ID, 1, ""); err != nil {
+		t.Fatalf("another player answering: %v", err)
+	}
+}
+
+func TestSubmitAnswerReplaysKey(t *testing.T) {
+	f := newFixture(t)
+	first, err := f.questions.SubmitAnswer(f.ctx, f.question.ID, 1, "key")
+	if err != nil {
+		t.Fatalf("submit: %v", err)
+	}
+	// A retry replays the first outcome even if it picks another option.
+	res, err := f.questions.SubmitAnswer(f.ctx, f.question.ID, 0, "key")
+	if err != nil {
+		t.Fatalf("retry: %v", err)
+	}
+	if !res.Replayed || !res.Answer.Correct || res.UpdatedPoints != first.UpdatedPoints {
+		t.Fatalf("retry got %+v, want a replay of %+v", res, first)
+	}
+	f.wantCredited(t)
+
+	other, err := f.questions.Create(f.as(uuid.NewString(), models.RoleEditor), "1 + 1?", []string{"2", "3"}, 0, 1)
+	if err != nil {
+		t.Fatalf("create question: %v", err)
+	}
+	if _, err := f.questions.SubmitAnswer(f.ctx, other.ID, 0, "key"); !errors.Is(err, ErrIdempotencyKeyReused) {
+		t.Fatalf("key on another question: got %v, want ErrIdempotencyKeyReused", err)
+	}
+}
+
+func TestConcurrentSubmitsAreCreditedOnce(t *testing.T) {
+	f := newFixture(t)
+	const submits = 20
+	errs := make([]error, submits)
+	var wg sync.WaitGroup
+	for i := range errs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = f.questions.SubmitAnswer(f.ctx, f.question.ID, 1, "")
+		}()
+	}
+	wg.Wait()
+
+	accepted := 0
+	for _, err := range errs {
+		switch {
+		case err == nil:
+			accepted++
+		case !errors.Is(err, ErrAlreadyAnswered):
+			t.Fatalf("submit: %v", err)
+		}
+	}
+	if accepted != 1 {
+		t.Fatalf("%d of %d concurrent submits were accepted, want 1", accepted, submits)
+	}
+	f.wantCredited(t)
+}
+
+func TestListForPlayerShufflesOptionsPerPlayer(t *testing.T) {
+	f := newFixture(t)
+	f.questions.cfg.ShuffleOptions = true
+	options := []string{"red", "green", "blue", "cyan", "magenta", "yellow"}
+	const correct = 4
+	q, err := f.questions.Create(f.as(uuid.NewString(), models.RoleEditor), "Which mixes red and blue?", options, correct, 2)
+	if err != nil {
+		t.Fatalf("create question: %v", err)
+	}
+
+	reordered := false
+	for i := 0; i < 8; i++ {
+		ctx := f.as(uuid.NewString(), models.RolePlayer)
+		shown := f.listOne(t, ctx, 2)
+		if again := f.listOne(t, ctx, 2); !reflect.DeepEqual(again.Options, shown.Options) {
+			t.Fatalf("order changed between listings: %v then %v", shown.Options, again.Options)
+		}
+		sorted := append([]string(nil), shown.Options...)
+		slices.Sort(sorted)
+		want := append([]string(nil), options...)
+		slices.Sort(want)
+		if !reflect.DeepEqual(sorted, want) {
+			t.Fatalf("shown options %v, want a permutation of %v", shown.Options, options)
+		}
+		reordered = reordered || !reflect.DeepEqual(shown.Options, options)
+
+		// The index a player submits is the one they were shown.
+		displayed := slices.Index(shown.Options, options[correct])
+		if i%2 == 1 {
+			displayed = (displayed + 1) % len(options)
+		}
+		res, err := f.questions.SubmitAnswer(ctx, q.ID, int32(displayed), "")
+		if err != nil {
+			t.Fatalf("submit: %v", err)
+		}
+		if res.Answer.Correct != (shown.Options[displayed] == options[correct]) {
+			t.Fatalf("picking %q at %d of %v graded correct=%v", shown.Options[displayed], displayed, shown.Options, res.Answer.Correct)
+		}
+		if got := options[res.Answer.SelectedIndex]; got != shown.Options[displayed] {
+			t.Fatalf("answer stores option %q, want %q", got, shown.Options[displayed])
+		}
+	}
+	if !reordered {
+		t.Fatal("no player saw the options shuffled")
+	}
+
+	f.questions.cfg.ShuffleOptions = false
+	if shown := f.listOne(t, f.ctx, 2); !reflect.DeepEqual(shown.Options, options) {
+		t.Fatalf("without shuffling got %v, want %v", shown.Options, options)
+	}
+}
+
+// listOne returns the only question of slot as the caller of ctx sees it.
+func (f *fixture) listOne(t *testing.T, ctx context.Context, slot int32) *models.PlayerQuestion {
+	t.Helper()
+	qs, err := f.questions.ListForPlayer(ctx, slot)
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(qs) != 1 {
+		t.Fatalf("slot %d has %d questions, want 1", slot, len(qs))
+	}
+	return qs[0]
+}
@@ -0,0 +1,416 @@
+package service
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "io"
+    "time"
+
+    "github.com/google/uuid"
+
+    "github.com/rprajapati0067/quiz-game-backend/internal/apperr"
+    "github.com/rprajapati0067/quiz-game-backend/internal/logging"
+    "github.com/rprajapati0067/quiz-game-backend/internal/metrics"
+    "github.com/rprajapati0067/quiz-game-backend/internal/models"
+    "github.com/rprajapati0067/quiz-game-backend/internal/repository"
+)
+
+var (
+    ErrAwardNotFound      = apperr.New(apperr.NotFound, "AWARD_NOT_FOUND", "award not found")
+    ErrInsufficientPoints = apperr.New(apperr.FailedPrecondition, "INSUFFICIENT_POINTS", "insufficient points")
+    ErrAwardUnavailable   = apperr.New(apperr.FailedPrecondition, "AWARD_UNAVAILABLE", "award is not available")
+    ErrOutOfStock         = apperr.New(apperr.FailedPrecondition, "OUT_OF_STOCK", "award out of stock")
+    ErrClaimLimitReached  = apperr.New(apperr.FailedPrecondition, "CLAIM_LIMIT_REACHED", "claim limit reached for this award")
+    ErrInvalidAward       = apperr.New(apperr.InvalidArgument, "INVALID_AWARD", "invalid award")
+
+    ErrClaimNotFound          = apperr.New(apperr.NotFound, "CLAIM_NOT_FOUND", "claim not found")
+    ErrUnknownClaimStatus     = apperr.New(apperr.InvalidArgument, "UNKNOWN_CLAIM_STATUS", "unknown claim status")
+    ErrInvalidClaimTransition = apperr.New(apperr.FailedPrecondition, "INVALID_CLAIM_TRANSITION", "claim cannot move to that status")
+    ErrClaimChanged           = apperr.New(apperr.Aborted, "CLAIM_CHANGED", "claim was changed concurrently, reload and retry")
+)
+
+// AwardSpec holds the admin-editable attributes of an award. Stock is set
+// on creation and changed only by restocking. Digital is fixed at creation;
+// digital awards start without stock and gain it by uploading voucher codes.
+type AwardSpec struct {
+    Digital        bool
+    Product        string
+    PointCost      int64
+    PerUserLimit   int32
+    AvailableFrom  time.Time
+    AvailableUntil time.Time
+}
+
+func (a AwardSpec) apply(award *models.Award) {
+    award.Product = a.Product
+    award.PointCost = a.PointCost
+    award.PerUserLimit = a.PerUserLimit
+    award.AvailableFrom = a.AvailableFrom
+    award.AvailableUntil = a.AvailableUntil
+}
+
+func (a AwardSpec) validate() error {
+    switch {
+    case a.Product == "":
+        return fmt.Errorf("%w: product is required", ErrInvalidAward)
+    case a.PointCost <= 0:
+        return fmt.Errorf("%w: point cost must be positive", ErrInvalidAward)
+    case a.PerUserLimit < 0:
+        return fmt.Errorf("%w: per-user limit cannot be negative", ErrInvalidAward)
+    case !a.AvailableFrom.IsZero() && !a.AvailableUntil.IsZero() && !a.AvailableUntil.After(a.AvailableFrom):
+        return fmt.Errorf("%w: availability window ends before it starts", ErrInvalidAward)
+    }
+    return nil
+}
+
+type ClaimResult struct {
+    Claim           *models.Claim
+    RemainingPoints int64
+}
+
+type RewardService interface {
+    // ListAwards returns the awards that can be claimed right now.
+    ListAwards(ctx context.Context) ([]*models.Award, error)
+    // ListAllAwards includes retired, scheduled and sold-out awards.
+    ListAllAwards(ctx context.Context) ([]*models.Award, error)
+    ClaimAward(ctx context.Context, awardID string) (*ClaimResult, error)
+    CreateAward(ctx context.Context, spec AwardSpec, stock int64) (*models.Award, error)
+    UpdateAward(ctx context.Context, awardID string, spec AwardSpec) (*models.Award, error)
+    RestockAward(ctx context.Context, awardID string, quantity int64) (*models.Award, error)
+    RetireAward(ctx context.Context, awardID string) (*models.Award, error)
+    // UploadVouchers adds the codes in a CSV file to a digital award's pool
+    // and restocks it by the number of new codes in the same transaction.
+    UploadVouchers(ctx context.Context, awardID string, csv io.Reader) (*VoucherUpload, error)
+
+    // ListMyClaims returns the caller's claims, newest first.
+    ListMyClaims(ctx context.Context) ([]*models.Claim, error)
+    // ListClaims is the admin queue: claims in one status, oldest first.
+    ListClaims(ctx context.Context, status models.ClaimStatus) ([]*models.Claim, error)
+    ApproveClaim(ctx context.Context, claimID, note string) (*models.Claim, error)
+    FulfillClaim(ctx context.Context, claimID, note string) (*models.Claim, error)
+    // RejectClaim returns the points and the unit of stock to the player.
+    RejectClaim(ctx context.Context, claimID, note string) (*models.Claim, error)
+    // RefundClaim retries the refund of a rejected claim whose refund failed.
+    RefundClaim(ctx context.Context, claimID string) (*models.Claim, error)
+}
+
+type RewardConfig struct {
+    // LowVoucherThreshold is how many unassigned codes a digital award may
+    // have left before an alert is logged.
+    LowVoucherThreshold int
+}
+
+func DefaultRewardConfig() RewardConfig {
+    return RewardConfig{LowVoucherThreshold: 10}
+}
+
+type rewardService struct {
+    awards   repository.AwardRepository
+    ledger   repository.LedgerRepository
+    vouchers repository.VoucherRepository
+    cfg      RewardConfig
+    alerts   voucherAlerts
+    now      func() time.Time
+}
+
+func NewRewardService(awards repository.AwardRepository, ledger repository.LedgerRepository, vouchers repository.VoucherRepository, cfg RewardConfig) RewardService {
+    return &rewardService{awards: awards, ledger: ledger, vouchers: vouchers, cfg: cfg, now: time.Now}
+}
+
+func (s *rewardService) ListAwards(ctx context.Context) ([]*models.Award, error) {
+    awards, err := s.awards.List(ctx)
+    if err != nil {
+        return nil, err
+    }
+    now := s.now()
+    result := make([]*models.Award, 0, len(awards))
+    for _, a := range awards {
+        if a.AvailableAt(now) && a.RemainingStock > 0 {
+            result = append(result, a)
+        }
+    }
+    return result, nil
+}
+
+func (s *rewardService) ListAllAwards(ctx context.Context) ([]*models.Award, error) {
+    return s.awards.List(ctx)
+}
+
+func (s *rewardService) ClaimAward(ctx context.Context, awardID string) (*ClaimResult, error) {
+    p, err := caller(ctx)
+    if err != nil {
+        return nil, err
+    }
+    award, err := s.get(ctx, awardID)
+    if err != nil {
+        return nil, err
+    }
+    if !award.AvailableAt(s.now()) {
+        return nil, ErrAwardUnavailable
+    }
+
+    c := &models.Claim{
+        ID:        uuid.NewString(),
+        UserID:    p.UserID,
+        AwardID:   award.ID,
+        Points:    award.PointCost,
+        ClaimedAt: s.now(),
+        Status:    models.ClaimPending,
+    }
+    c.UpdatedAt = c.ClaimedAt
+    // A digital claim takes its code first. The code is held under the
+    // claim's ID, so it is given back if the claim is not placed.
+    if award.Digital {
+        if err := s.assignVoucher(ctx, c); err != nil {
+            return nil, err
+        }
+    }
+    debit := s.entry(c, models.LedgerDebit, models.ReasonAwardClaim)
+    if err := s.place(ctx, c, debit); err != nil {
+        return nil, err
+    }
+    metrics.AwardClaimed(award.Digital)
+    return &ClaimResult{Claim: c, RemainingPoints: debit.Balance}, nil
+}
+
+// place stores the claim with its unit of stock and its debit in one
+// transaction. Stock and the per-user cap are enforced by the reservation
+// and the balance by the ledger, which rejects a debit that would
+// overdraw it, so concurrent claims cannot spend the same points twice.
+// Nothing is written on failure, leaving only the voucher to give back;
+// but an error such as a timeout can follow a commit, so an unexpected
+// failure gives it back only once the claim is known to be missing, and
+// counts as placed if the claim is there.
+func (s *rewardService) place(ctx context.Context, c *models.Claim, debit *models.LedgerEntry) error {
+    err := s.awards.PlaceClaim(ctx, c, debit)
+    switch {
+    case err == nil:
+        return nil
+    case errors.Is(err, repository.ErrOutOfStock):
+        err = ErrOutOfStock
+    case errors.Is(err, repository.ErrClaimLimitReached):
+        err = ErrClaimLimitReached
+    case errors.Is(err, repository.ErrInsufficientBalance):
+        err = ErrInsufficientPoints
+    default:
+        _, getErr := s.awards.GetClaim(ctx, c.ID)
+        if getErr == nil {
+            debit.Balance, err = s.ledger.Balance(ctx, c.UserID)
+            return err
+        }
+        if !errors.Is(getErr, repository.ErrNotFound) {
+            if c.VoucherCode != "" {
+                logging.Error(fmt.Sprintf("claim %s may have been stored despite %v; keeping its voucher", c.ID, err))
+            }
+            return err
+        }
+    }
+    if c.VoucherCode != "" {
+        if unassignErr := s.unassignVoucher(ctx, c); unassignErr != nil {
+            err = errors.Join(err, unassignErr)
+        }
+    }
+    return err
+}
+
+// credit refunds the points of a claim. Its entry ID is derived from the
+// claim, so however often a refund is retried it pays out once.
+func (s *rewardService) credit(ctx context.Context, c *models.Claim) error {
+    err := s.ledger.Append(ctx, s.entry(c, models.LedgerCredit, models.ReasonClaimRefund))
+    if errors.Is(err, repository.ErrEntryExists) {
+        return nil
+    }
+    return err
+}
+
+func (s *rewardService) ListMyClaims(ctx context.Context) ([]*models.Claim, error) {
+    p, err := caller(ctx)
+    if err != nil {
+        return nil, err
+    }
+    return s.awards.ListClaimsByUser(ctx, p.UserID)
+}
+
+func (s *rewardService) ListClaims(ctx context.Context, status models.ClaimStatus) ([]*models.Claim, error) {
+    if !status.Valid() {
+        return nil, ErrUnknownClaimStatus
+    }
+    return s.awards.ListClaimsByStatus(ctx, status)
+}
+
+func (s *rewardService) ApproveClaim(ctx context.Context, claimID, note string) (*models.Claim, error) {
+    return s.transition(ctx, claimID, models.ClaimApproved, note)
+}
+
+func (s *rewardService) FulfillClaim(ctx context.Context, claimID, note string) (*models.Claim, error) {
+    return s.transition(ctx, claimID, models.ClaimFulfilled, note)
+}
+
+func (s *rewardService) RejectClaim(ctx context.Context, claimID, note string) (*models.Claim, error) {
+    c, err := s.transition(ctx, claimID, models.ClaimRejected, note)
+    if err != nil {
+        return nil, err
+    }
+    return s.refund(ctx, c)
+}
+
+func (s *rewardService) RefundClaim(ctx context.Context, claimID string) (*models.Claim, error) {
+    c, err := s.getClaim(ctx, claimID)
+    if err != nil {
+        return nil, err
+    }
+    if c.Status != models.ClaimRejected {
+        return nil, ErrInvalidClaimTransition
+    }
+    return s.refund(ctx, c)
+}
+
+// refund moves a rejected claim to refunded before crediting the points,
+// so two concurrent refunds cannot both pay out. If the credit fails the
+// claim is put back to rejected for RefundClaim to retry.
+func (s *rewardService) refund(ctx context.Context, c *models.Claim) (*models.Claim, error) {
+    refunded, err := s.setStatus(ctx, c, models.ClaimRefunded, c.Note)
+    if err != nil {
+        return nil, err
+    }
+    if err := s.credit(ctx, c); err != nil {
+        if _, revertErr := s.awards.SetClaimStatus(ctx, c.ID, models.ClaimRefunded, models.ClaimRejected, c.Note, s.now()); revertErr != nil {
+            logging.Error(fmt.Sprintf("claim %s marked refunded but crediting %d points failed: %v", c.ID, c.Points, err))
+        }
+        return nil, err
+    }
+    s.release(ctx, c)
+    return refunded, nil
+}
+
+func (s *rewardService) transition(ctx context.Context, claimID string, to models.ClaimStatus, note string) (*models.Claim, error) {
+    c, err := s.getClaim(ctx, claimID)
+    if err != nil {
+        return nil, err
+    }
+    if !c.Status.CanMoveTo(to) {
+        return nil, ErrInvalidClaimTransition
+    }
+    return s.setStatus(ctx, c, to, note)
+}
+
+func (s *rewardService) setStatus(ctx context.Context, c *models.Claim, to models.ClaimStatus, note string) (*models.Claim, error) {
+    updated, err := s.awards.SetClaimStatus(ctx, c.ID, c.Status, to, note, s.now())
+    if err != nil {
+        if errors.Is(err, repository.ErrClaimStatusChanged) {
+            return nil, ErrClaimChanged
+        }
+        return nil, err
+    }
+    return updated, nil
+}
+
+func (s *rewardService) getClaim(ctx context.Context, id string) (*models.Claim, error) {
+    c, err := s.awards.GetClaim(ctx, id)
+    if errors.Is(err, repository.ErrNotFound) {
+        return nil, ErrClaimNotFound
+    }
+    if err != nil {
+        return nil, err
+    }
+    return c, nil
+}
+
+func (s *rewardService) release(ctx context.Context, c *models.Claim) error {
+    err := s.awards.Release(ctx, c.AwardID, c.UserID)
+    if err != nil {
+        logging.Error(fmt.Sprintf("releasing stock of award %s for user %s failed: %v", c.AwardID, c.UserID, err))
+    }
+    return err
+}
+
+func (s *rewardService) CreateAward(ctx context.Context, spec AwardSpec, stock int64) (*models.Award, error) {
+    if err := spec.validate(); err != nil {
+        return nil, err
+    }
+    if stock < 0 {
+        return nil, fmt.Errorf("%w: stock cannot be negative", ErrInvalidAward)
+    }
+    if spec.Digital && stock != 0 {
+        return nil, fmt.Errorf("%w: digital award stock comes from uploaded codes", ErrInvalidAward)
+    }
+    a := &models.Award{
+        ID:             uuid.NewString(),
+        TotalStock:     stock,
+        RemainingStock: stock,
+        Active:         true,
+        Digital:        spec.Digital,
+    }
+    spec.apply(a)
+    if err := s.awards.Create(ctx, a); err != nil {
+        return nil, err
+    }
+    return a, nil
+}
+
+func (s *rewardService) UpdateAward(ctx context.Context, awardID string, spec AwardSpec) (*models.Award, error) {
+    if err := spec.validate(); err != nil {
+        return nil, err
+    }
+    a, err := s.get(ctx, awardID)
+    if err != nil {
+        return nil, err
+    }
+    spec.apply(a)
+    if err := s.awards.Update(ctx, a); err != nil {
+        return nil, err
+    }
+    // Re-read so the stock reflects claims made in the meantime.
+    return s.get(ctx, awardID)
+}
+
+func (s *rewardService) RestockAward(ctx context.Context, awardID string, quantity int64) (*models.Award, error) {
+    if quantity <= 0 {
+        return nil, fmt.Errorf("%w: restock quantity must be positive", ErrInvalidAward)
+    }
+    a, err := s.get(ctx, awardID)
+    if err != nil {
+        return nil, err
+    }
+    if a.Digital {
+        return nil, fmt.Errorf("%w: digital awards are restocked by uploading codes", ErrInvalidAward)
+    }
+    return s.awards.Restock(ctx, awardID, quantity)
+}
+
+// RetireAward stops new claims. Existing claims are unaffected.
+func (s *rewardService) RetireAward(ctx context.Context, awardID string) (*models.Award, error) {
+    a, err := s.awards.Retire(ctx, awardID)
+    if errors.Is(err, repository.ErrNotFound) {
+        return nil, ErrAwardNotFound
+    }
+    if err != nil {
+        return nil, err
+    }
+    return a, nil
+}
+
+func (s *rewardService) get(ctx context.Context, id string) (*models.Award, error) {
+    a, err := s.awards.GetByID(ctx, id)
+    if errors.Is(err, repository.ErrNotFound) {
+        return nil, ErrAwardNotFound
+    }
+    if err != nil {
+        return nil, err
+    }
+    return a, nil
+}
+
+func (s *rewardService) entry(c *models.Claim, kind models.LedgerKind, reason models.LedgerReason) *models.LedgerEntry {
+    return &models.LedgerEntry{
+        ID:        entryID("claim", c.ID, string(reason)),
+        UserID:    c.UserID,
+        Kind:      kind,
+        Amount:    c.Points,
+        Reason:    reason,
+        RefType:   repository.RefClaim,
+        RefID:     c.ID,
+        CreatedAt: s.now(),
+    }
+}
@@ -0,0 +1,302 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/rprajapati0067/quiz-game-backend/internal/models"
+)
+
+// wantClaimed checks the player's points and ledger entries and the
+// award's stock.
+func (f *fixture) wantClaimed(t *testing.T, balance int64, entries int, stock int64) {
+	t.Helper()
+	f.wantBalance(t, f.user, balance, entries)
+	f.wantStock(t, f.award.ID, stock)
+}
+
+func TestClaimAwardCountsClaimStoredDespiteError(t *testing.T) {
+	f := newFixture(t)
+	f.give(t, f.user, startingPoints)
+	f.awards.failAfterPlace = true
+	res, err := f.rewards.ClaimAward(f.ctx, f.award.ID)
+	if err != nil {
+		t.Fatalf("claim: %v", err)
+	}
+	if res.RemainingPoints != startingPoints-awardCost {
+		t.Fatalf("claim left %d points, want %d", res.RemainingPoints, startingPoints-awardCost)
+	}
+	f.wantClaimed(t, startingPoints-awardCost, 2, awardStock-1)
+}
+
+func TestClaimAwardGivesVoucherBackWhenNotPlaced(t *testing.T) {
+	f := newFixture(t)
+	f.give(t, f.user, startingPoints)
+	award, err := f.rewards.CreateAward(f.ctx, AwardSpec{Digital: true, Product: "gift card", PointCost: awardCost}, 0)
+	if err != nil {
+		t.Fatalf("create award: %v", err)
+	}
+	if _, err := f.rewards.UploadVouchers(f.ctx, award.ID, strings.NewReader("A\nB\n")); err != nil {
+		t.Fatalf("upload: %v", err)
+	}
+
+	f.awards.failPlace = true
+	if _, err := f.rewards.ClaimAward(f.ctx, award.ID); !errors.Is(err, errInjected) {
+		t.Fatalf("claim: got %v, want the injected failure", err)
+	}
+	left, err := f.vouchers.Available(f.ctx, award.ID)
+	if err != nil {
+		t.Fatalf("available: %v", err)
+	}
+	if left != 2 {
+		t.Fatalf("%d codes available after the failed claim, want 2", left)
+	}
+
+	res, err := f.rewards.ClaimAward(f.ctx, award.ID)
+	if err != nil {
+		t.Fatalf("claim again: %v", err)
+	}
+	if res.Claim.VoucherCode == "" || res.Claim.Status != models.ClaimFulfilled {
+		t.Fatalf("claim %+v, want a fulfilled claim with a code", res.Claim)
+	}
+}
+
+func TestClaimRefundIsCreditedOnce(t *testing.T) {
+	f := newFixture(t)
+	f.give(t, f.user, startingPoints)
+	res, err := f.rewards.ClaimAward(f.ctx, f.award.ID)
+	if err != nil {
+		t.Fatalf("claim: %v", err)
+	}
+	c := res.Claim
+
+	// A refund that landed but reported failure leaves the claim rejected;
+	// retrying it must not pay a second time.
+	if err := f.rewards.credit(f.ctx, c); err != nil {
+		t.Fatalf("credit: %v", err)
+	}
+	if _, err := f.rewards.RejectClaim(f.ctx, c.ID, "out of mugs"); err != nil {
+		t.Fatalf("reject: %v", err)
+	}
+	f.wantClaimed(t, startingPoints, 3, awardStock)
+}
+
+// claimConcurrently runs ClaimAward once per context at the same time and
+// returns how many succeeded, failing on any error other than allowed.
+func (f *fixture) claimConcurrently(t *testing.T, awardIDs []string, ctxs []context.Context, allowed error) int {
+	t.Helper()
+	errs := make([]error, len(ctxs))
+	var wg sync.WaitGroup
+	for i, ctx := range ctxs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = f.rewards.ClaimAward(ctx, awardIDs[i%len(awardIDs)])
+		}()
+	}
+	wg.Wait()
+
+	placed := 0
+	for _, err := range errs {
+		switch {
+		case err == nil:
+			placed++
+		case !errors.Is(err, allowed):
+			t.Fatalf("claim: got %v, want success or %v", err, allowed)
+		}
+	}
+	return placed
+}
+
+func TestConcurrentClaimsDoNotOversell(t *testing.T) {
+	f := newFixture(t)
+	award, err := f.rewards.CreateAward(f.ctx, AwardSpec{Product: "last mug", PointCost: awardCost}, 1)
+	if err != nil {
+		t.Fatalf("create award: %v", err)
+	}
+
+	const players = 20
+	ctxs := make([]context.Context, players)
+	users := make([]string, players)
+	for i := range ctxs {
+		users[i] = uuid.NewString()
+		f.give(t, users[i], startingPoints)
+		ctxs[i] = f.as(users[i], models.RolePlayer)
+	}
+	if placed := f.claimConcurrently(t, []string{award.ID}, ctxs, ErrOutOfStock); placed != 1 {
+		t.Fatalf("%d of %d claims on the last unit were placed, want 1", placed, players)
+	}
+	f.wantStock(t, award.ID, 0)
+
+	spent := 0
+	for _, u := range users {
+		balance, err := f.ledger.Balance(f.ctx, u)
+		if err != nil {
+			t.Fatalf("balance: %v", err)
+		}
+		switch balance {
+		case startingPoints - awardCost:
+			spent++
+		case startingPoints:
+		default:
+			t.Fatalf("player has %d points, want %d or %d", balance, startingPoints, startingPoints-awardCost)
+		}
+	}
+	if spent != 1 {
+		t.Fatalf("%d players paid for the last unit, want 1", spent)
+	}
+}
+
+func TestConcurrentClaimsDoNotSpendPointsTwice(t *testing.T) {
+	f := newFixture(t)
+	f.give(t, f.user, awardCost)
+	var ids []string
+	for i := 0; i < 4; i++ {
+		award, err := f.rewards.CreateAward(f.ctx, AwardSpec{Product: "mug", PointCost: awardCost}, awardStock)
+		if err != nil {
+			t.Fatalf("create award: %v", err)
+		}
+		ids = append(ids, award.ID)
+	}
+
+	ctxs := make([]context.Context, 20)
+	for i := range ctxs {
+		ctxs[i] = f.ctx
+	}
+	if placed := f.claimConcurrently(t, ids, ctxs, ErrInsufficientPoints); placed != 1 {
+		t.Fatalf("%d concurrent claims were paid from one claim's points, want 1", placed)
+	}
+	f.wantBalance(t, f.user, 0, 2)
+	stock := int64(0)
+	for _, id := range ids {
+		award, err := f.awards.GetByID(f.ctx, id)
+		if err != nil {
+			t.Fatalf("get award: %v", err)
+		}
+		stock += award.RemainingStock
+	}
+	if stock != int64(len(ids))*awardStock-1 {
+		t.Fatalf("%d units left, want one taken", stock)
+	}
+}
+
+// claimIn places a claim by the fixture's player and moves it to status.
+// A rejected claim is one whose refund failed and awaits RefundClaim.
+func (f *fixture) claimIn(t *testing.T, status models.ClaimStatus) *models.Claim {
+	t.Helper()
+	res, err := f.rewards.ClaimAward(f.ctx, f.award.ID)
+	if err != nil {
+		t.Fatalf("claim: %v", err)
+	}
+	c := res.Claim
+	var steps []func() (*models.Claim, error)
+	approve := func() (*models.Claim, error) { return f.rewards.ApproveClaim(f.ctx, c.ID, "ok") }
+	switch status {
+	case models.ClaimApproved:
+		steps = append(steps, approve)
+	case models.ClaimFulfilled:
+		steps = append(steps, approve, func() (*models.Claim, error) { return f.rewards.FulfillClaim(f.ctx, c.ID, "shipped") })
+	case models.ClaimRefunded:
+		steps = append(steps, func() (*models.Claim, error) { return f.rewards.RejectClaim(f.ctx, c.ID, "no") })
+	case models.ClaimRejected:
+		f.ledger.failReason = models.ReasonClaimRefund
+		if _, err := f.rewards.RejectClaim(f.ctx, c.ID, "no"); !errors.Is(err, errInjected) {
+			t.Fatalf("reject: got %v, want the injected failure", err)
+		}
+	}
+	for _, step := range steps {
+		if c, err = step(); err != nil {
+			t.Fatalf("move claim to %s: %v", status, err)
+		}
+	}
+	if got, err := f.awards.GetClaim(f.ctx, c.ID); err != nil || got.Status != status {
+		t.Fatalf("claim is %+v, %v; want %s", got, err, status)
+	}
+	return c
+}
+
+func TestClaimTransitions(t *testing.T) {
+	type action struct {
+		name string
+		do   func(f *fixture, id string) (*models.Claim, error)
+	}
+	var (
+		approve = action{"approve", func(f *fixture, id string) (*models.Claim, error) { return f.rewards.ApproveClaim(f.ctx, id, "") }}
+		fulfill = action{"fulfill", func(f *fixture, id string) (*models.Claim, error) { return f.rewards.FulfillClaim(f.ctx, id, "") }}
+		reject  = action{"reject", func(f *fixture, id string) (*models.Claim, error) { return f.rewards.RejectClaim(f.ctx, id, "") }}
+		refund  = action{"refund", func(f *fixture, id string) (*models.Claim, error) { return f.rewards.RefundClaim(f.ctx, id) }}
+	)
+	for _, tc := range []struct {
+		from models.ClaimStatus
+		act  action
+		// want is the status the claim ends in, or "" when the action is
+		// not allowed.
+		want models.ClaimStatus
+	}{
+		{models.ClaimPending, approve, models.ClaimApproved},
+		{models.ClaimPending, fulfill, ""},
+		{models.ClaimPending, reject, models.ClaimRefunded},
+		{models.ClaimPending, refund, ""},
+		{models.ClaimApproved, approve, ""},
+		{models.ClaimApproved, fulfill, models.ClaimFulfilled},
+		{models.ClaimApproved, reject, models.ClaimRefunded},
+		{models.ClaimApproved, refund, ""},
+		{models.ClaimFulfilled, approve, ""},
+		{models.ClaimFulfilled, fulfill, ""},
+		{models.ClaimFulfilled, reject, ""},
+		{models.ClaimFulfilled, refund, ""},
+		{models.ClaimRejected, approve, ""},
+		{models.ClaimRejected, fulfill, ""},
+		{models.ClaimRejected, reject, ""},
+		{models.ClaimRejected, refund, models.ClaimRefunded},
+		{models.ClaimRefunded, approve, ""},
+		{models.ClaimRefunded, fulfill, ""},
+		{models.ClaimRefunded, reject, ""},
+		{models.ClaimRefunded, refund, ""},
+	} {
+		t.Run(string(tc.from)+" "+tc.act.name, func(t *testing.T) {
+			f := newFixture(t)
+			f.give(t, f.user, startingPoints)
+			c := f.claimIn(t, tc.from)
+			f.clock = f.clock.Add(time.Minute)
+
+			got, err := tc.act.do(f, c.ID)
+			want := tc.want
+			if want == "" {
+				if !errors.Is(err, ErrInvalidClaimTransition) {
+					t.Fatalf("got %+v, %v; want ErrInvalidClaimTransition", got, err)
+				}
+				want = tc.from
+			} else if err != nil || got.Status != want || !got.UpdatedAt.Equal(f.clock) {
+				t.Fatalf("got %+v, %v; want a claim %s at %v", got, err, want, f.clock)
+			}
+
+			stored, err := f.awards.GetClaim(f.ctx, c.ID)
+			if err != nil || stored.Status != want {
+				t.Fatalf("stored claim %+v, %v; want %s", stored, err, want)
+			}
+			// Only a refund returns the points and the unit of stock.
+			if want == models.ClaimRefunded {
+				f.wantClaimed(t, startingPoints, 3, awardStock)
+			} else {
+				f.wantClaimed(t, startingPoints-awardCost, 2, awardStock-1)
+			}
+		})
+	}
+}
+
+func TestClaimTransitionOfUnknownClaim(t *testing.T) {
+	f := newFixture(t)
+	if _, err := f.rewards.ApproveClaim(f.ctx, uuid.NewString(), ""); !errors.Is(err, ErrClaimNotFound) {
+		t.Fatalf("approve: got %v, want ErrClaimNotFound", err)
+	}
+	if _, err := f.rewards.RefundClaim(f.ctx, uuid.NewString()); !errors.Is(err, ErrClaimNotFound) {
+		t.Fatalf("refund: got %v, want ErrClaimNotFound", err)
+	}
+}
@@ -2,23 +2,186 @@ package service
 
 import (
     "context"
+    "errors"
+    "strconv"
 
+    "github.com/rprajapati0067/quiz-game-backend/internal/apperr"
     "github.com/rprajapati0067/quiz-game-backend/internal/models"
     "github.com/rprajapati0067/quiz-game-backend/internal/repository"
 )
 
+var (
+    ErrUnknownRole      = apperr.New(apperr.InvalidArgument, "UNKNOWN_ROLE", "unknown role")
+    ErrRevokeOwnAdmin   = apperr.New(apperr.InvalidArgument, "REVOKE_OWN_ADMIN", "admins cannot revoke their own admin role")
+    ErrInvalidPageToken = apperr.New(apperr.InvalidArgument, "INVALID_PAGE_TOKEN", "invalid page token")
+    ErrUserChanged      = apperr.New(apperr.Aborted, "USER_CHANGED", "user was changed concurrently, retry")
+)
+
+const (
+    defaultHistoryPageSize = 20
+    maxHistoryPageSize     = 100
+)
+
+// PointsHistoryPage is one page of the caller's ledger, newest first.
+// NextPageToken is empty on the last page.
+type PointsHistoryPage struct {
+    Entries       []*models.LedgerEntry
+    NextPageToken string
+}
+
 type UserService interface {
     GetByID(ctx context.Context, id string) (*models.User, error)
+    Me(ctx context.Context) (*models.User, error)
+    GrantRole(ctx context.Context, userID string, role models.Role) (*models.User, error)
+    RevokeRole(ctx context.Context, userID string, role models.Role) (*models.User, error)
+    PointsHistory(ctx context.Context, pageSize int, pageToken string) (*PointsHistoryPage, error)
 }
 
 type userService struct {
-    users repository.UserRepository
+    users  repository.UserRepository
+    ledger repository.LedgerRepository
 }
 
-func NewUserService(users repository.UserRepository) UserService {
-    return &userService{users: users}
+func NewUserService(users repository.UserRepository, ledger repository.LedgerRepository) UserService {
+    return &userService{users: users, ledger: ledger}
 }
 
 func (s *userService) GetByID(ctx context.Context, id string) (*models.User, error) {
-    return s.users.GetByID(ctx, id)
+    return s.get(ctx, id)
+}
+
+func (s *userService) Me(ctx context.Context) (*models.User, error) {
+    p, err := caller(ctx)
+    if err != nil {
+        return nil, err
+    }
+    return s.get(ctx, p.UserID)
+}
+
+func (s *userService) GrantRole(ctx context.Context, userID string, role models.Role) (*models.User, error) {
+    if !role.Valid() {
+        return nil, ErrUnknownRole
+    }
+    return modifyUser(ctx, s.users, userID, func(u *models.User) bool {
+        if u.HasRole(role) {
+            return false
+        }
+        u.Roles = append(u.Roles, role)
+        return true
+    })
+}
+
+func (s *userService) RevokeRole(ctx context.Context, userID string, role models.Role) (*models.User, error) {
+    if !role.Valid() {
+        return nil, ErrUnknownRole
+    }
+    p, err := caller(ctx)
+    if err != nil {
+        return nil, err
+    }
+    // Prevents an admin from locking everyone out by accident.
+    if role == models.RoleAdmin && p.UserID == userID {
+        return nil, ErrRevokeOwnAdmin
+    }
+    return modifyUser(ctx, s.users, userID, func(u *models.User) bool {
+        if !u.HasRole(role) {
+            return false
+        }
+        roles := make([]models.Role, 0, len(u.Roles))
+        for _, r := range u.Roles {
+            if r != role {
+                roles = append(roles, r)
+            }
+        }
+        u.Roles = roles
+        return true
+    })
+}
+
+func (s *userService) get(ctx context.Context, id string) (*models.User, error) {
+    u, err := s.users.GetByID(ctx, id)
+    if errors.Is(err, repository.ErrNotFound) {
+        return nil, ErrUserNotFound
+    }
+    if err != nil {
+        return nil, err
+    }
+    if err := s.fillPoints(ctx, u); err != nil {
+        return nil, err
+    }
+    return u, nil
+}
+
+func (s *userService) fillPoints(ctx context.Context, u *models.User) error {
+    balance, err := s.ledger.Balance(ctx, u.ID)
+    if err != nil {
+        return err
+    }
+    u.Points = balance
+    return nil
+}
+
+func (s *userService) PointsHistory(ctx context.Context, pageSize int, pageToken string) (*PointsHistoryPage, error) {
+    p, err := caller(ctx)
+    if err != nil {
+        return nil, err
+    }
+    if pageSize <= 0 {
+        pageSize = defaultHistoryPageSize
+    }
+    if pageSize > maxHistoryPageSize {
+        pageSize = maxHistoryPageSize
+    }
+    // The token is the sequence number of the last entry on the previous
+    // page; entries are immutable, so it stays valid indefinitely.
+    var before int64
+    if pageToken != "" {
+        before, err = strconv.ParseInt(pageToken, 10, 64)
+        if err != nil || before <= 0 {
+            return nil, ErrInvalidPageToken
+        }
+    }
+
+    entries, err := s.ledger.List(ctx, p.UserID, before, pageSize+1)
+    if err != nil {
+        return nil, err
+    }
+    page := &PointsHistoryPage{Entries: entries}
+    if len(entries) > pageSize {
+        page.Entries = entries[:pageSize]
+        page.NextPageToken = strconv.FormatInt(page.Entries[pageSize-1].Seq, 10)
+    }
+    return page, nil
+}
+
+// maxUpdateAttempts bounds how often modifyUser retries after losing a race
+// with another writer.
+const maxUpdateAttempts = 3
+
+// modifyUser applies fn to the latest stored copy of the user and saves it,
+// re-reading and retrying when a concurrent write bumped the version. fn
+// reports whether it changed anything; if not, nothing is written.
+func modifyUser(ctx context.Context, users repository.UserRepository, id string, fn func(u *models.User) bool) (*models.User, error) {
+    for attempt := 1; ; attempt++ {
+        u, err := users.GetByID(ctx, id)
+        if errors.Is(err, repository.ErrNotFound) {
+            return nil, ErrUserNotFound
+        }
+        if err != nil {
+            return nil, err
+        }
+        if !fn(u) {
+            return u, nil
+        }
+        err = users.Update(ctx, u)
+        if err == nil {
+            return u, nil
+        }
+        if !errors.Is(err, repository.ErrVersionConflict) {
+            return nil, err
+        }
+        if attempt == maxUpdateAttempts {
+            return nil, ErrUserChanged
+        }
+    }
 }
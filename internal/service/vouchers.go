@@ -0,0 +1,178 @@
+package service
+
+import (
+    "context"
+    "encoding/csv"
+    "errors"
+    "fmt"
+    "io"
+    "strings"
+    "sync"
+
+    "github.com/rprajapati0067/quiz-game-backend/internal/apperr"
+    "github.com/rprajapati0067/quiz-game-backend/internal/logging"
+    "github.com/rprajapati0067/quiz-game-backend/internal/models"
+    "github.com/rprajapati0067/quiz-game-backend/internal/repository"
+)
+
+var (
+    ErrAwardNotDigital   = apperr.New(apperr.InvalidArgument, "AWARD_NOT_DIGITAL", "award does not use voucher codes")
+    ErrInvalidVoucherCSV = apperr.New(apperr.InvalidArgument, "INVALID_VOUCHER_FILE", "invalid voucher file")
+    ErrVoucherBusy       = apperr.New(apperr.Aborted, "VOUCHER_BUSY", "too many concurrent claims for this award, retry")
+)
+
+// VoucherUpload reports what an upload added. Duplicates counts codes that
+// were already in the pool or repeated within the file.
+type VoucherUpload struct {
+    Award      *models.Award
+    Added      int
+    Duplicates int
+}
+
+func (s *rewardService) UploadVouchers(ctx context.Context, awardID string, r io.Reader) (*VoucherUpload, error) {
+    a, err := s.get(ctx, awardID)
+    if err != nil {
+        return nil, err
+    }
+    if !a.Digital {
+        return nil, ErrAwardNotDigital
+    }
+    codes, err := parseVoucherCSV(r)
+    if err != nil {
+        return nil, err
+    }
+
+    // Add restocks the award in the same transaction.
+    added, err := s.vouchers.Add(ctx, awardID, codes, s.now())
+    if err != nil {
+        return nil, err
+    }
+    if added > 0 {
+        if a, err = s.get(ctx, awardID); err != nil {
+            return nil, err
+        }
+        left, err := s.vouchers.Available(ctx, awardID)
+        if err != nil {
+            return nil, err
+        }
+        s.alerts.settle(awardID, s.voucherLevel(left))
+    }
+    return &VoucherUpload{Award: a, Added: added, Duplicates: len(codes) - added}, nil
+}
+
+// parseVoucherCSV reads one code per row from the first column. Blank rows
+// are skipped, as is a leading header row named "code".
+func parseVoucherCSV(r io.Reader) ([]string, error) {
+    cr := csv.NewReader(r)
+    cr.FieldsPerRecord = -1
+    cr.TrimLeadingSpace = true
+
+    var codes []string
+    for row := 0; ; row++ {
+        record, err := cr.Read()
+        if err == io.EOF {
+            break
+        }
+        if err != nil {
+            return nil, fmt.Errorf("%w: %v", ErrInvalidVoucherCSV, err)
+        }
+        code := strings.TrimSpace(record[0])
+        if code == "" || (row == 0 && strings.EqualFold(code, "code")) {
+            continue
+        }
+        codes = append(codes, code)
+    }
+    if len(codes) == 0 {
+        return nil, fmt.Errorf("%w: no codes found", ErrInvalidVoucherCSV)
+    }
+    return codes, nil
+}
+
+// assignVoucher gives the claim its code. A digital claim needs no further
+// handling, so it is fulfilled straight away.
+func (s *rewardService) assignVoucher(ctx context.Context, c *models.Claim) error {
+    v, left, err := s.vouchers.Assign(ctx, c.AwardID, c.ID, c.UserID, c.ClaimedAt)
+    if err != nil {
+        switch {
+        case errors.Is(err, repository.ErrNoVoucherAvailable):
+            return ErrOutOfStock
+        case errors.Is(err, repository.ErrVoucherContention):
+            return ErrVoucherBusy
+        }
+        return err
+    }
+    c.VoucherCode = v.Code
+    c.Status = models.ClaimFulfilled
+
+    // Alert when the pool crosses into a level rather than on every claim
+    // within it. Concurrent claims can skip counts, hence the ranges.
+    level := s.voucherLevel(left)
+    if s.alerts.raise(c.AwardID, level) {
+        switch level {
+        case voucherPoolExhausted:
+            logging.Error(fmt.Sprintf("voucher pool for award %s is exhausted", c.AwardID))
+        case voucherPoolLow:
+            logging.Error(fmt.Sprintf("voucher pool for award %s is low: %d codes left", c.AwardID, left))
+        }
+    }
+    return nil
+}
+
+// voucherPoolLevel grades how close a voucher pool is to running out.
+type voucherPoolLevel int
+
+const (
+    voucherPoolOK voucherPoolLevel = iota
+    voucherPoolLow
+    voucherPoolExhausted
+)
+
+func (s *rewardService) voucherLevel(left int) voucherPoolLevel {
+    switch {
+    case left <= 0:
+        return voucherPoolExhausted
+    case left <= s.cfg.LowVoucherThreshold:
+        return voucherPoolLow
+    }
+    return voucherPoolOK
+}
+
+// voucherAlerts remembers the level each award's pool was last reported
+// at, so an alert is logged once per crossing. It is kept per process.
+type voucherAlerts struct {
+    mu       sync.Mutex
+    reported map[string]voucherPoolLevel
+}
+
+// raise reports whether the pool has reached a worse level than the last
+// one reported, recording it if so.
+func (a *voucherAlerts) raise(awardID string, level voucherPoolLevel) bool {
+    a.mu.Lock()
+    defer a.mu.Unlock()
+    if level <= a.reported[awardID] {
+        return false
+    }
+    if a.reported == nil {
+        a.reported = make(map[string]voucherPoolLevel)
+    }
+    a.reported[awardID] = level
+    return true
+}
+
+// settle lowers the reported level after an upload refilled the pool, so
+// the next crossing is reported again.
+func (a *voucherAlerts) settle(awardID string, level voucherPoolLevel) {
+    a.mu.Lock()
+    defer a.mu.Unlock()
+    if level < a.reported[awardID] {
+        a.reported[awardID] = level
+    }
+}
+
+func (s *rewardService) unassignVoucher(ctx context.Context, c *models.Claim) error {
+    err := s.vouchers.Unassign(ctx, c.AwardID, c.ID)
+    if err != nil {
+        logging.Error(fmt.Sprintf("returning voucher of claim %s to award %s failed: %v", c.ID, c.AwardID, err))
+    }
+    return err
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUploadVouchersRestocksByNewCodes(t *testing.T) {
+	f := newFixture(t)
+	award, err := f.rewards.CreateAward(f.ctx, AwardSpec{Digital: true, Product: "gift card", PointCost: 10}, 0)
+	if err != nil {
+		t.Fatalf("create award: %v", err)
+	}
+
+	up, err := f.rewards.UploadVouchers(f.ctx, award.ID, strings.NewReader("code\nA\nB\nA\n"))
+	if err != nil {
+		t.Fatalf("upload: %v", err)
+	}
+	if up.Added != 2 || up.Duplicates != 1 || up.Award.RemainingStock != 2 || up.Award.TotalStock != 2 {
+		t.Fatalf("upload added %d with %d duplicates, stock %d of %d; want 2, 1 and 2 of 2",
+			up.Added, up.Duplicates, up.Award.RemainingStock, up.Award.TotalStock)
+	}
+
+	up, err = f.rewards.UploadVouchers(f.ctx, award.ID, strings.NewReader("B\nC\n"))
+	if err != nil {
+		t.Fatalf("upload again: %v", err)
+	}
+	if up.Added != 1 || up.Award.RemainingStock != 3 {
+		t.Fatalf("second upload added %d, stock %d; want 1 and 3", up.Added, up.Award.RemainingStock)
+	}
+}
+
+func TestVoucherAlertsFireOncePerCrossing(t *testing.T) {
+	s := &rewardService{cfg: RewardConfig{LowVoucherThreshold: 3}}
+	var fired []int
+	claim := func(left int) {
+		if s.alerts.raise("award", s.voucherLevel(left)) {
+			fired = append(fired, left)
+		}
+	}
+
+	// Concurrent claims can report counts out of order and skip the
+	// threshold itself.
+	for _, left := range []int{5, 4, 2, 3, 1, 0, 0} {
+		claim(left)
+	}
+	if len(fired) != 2 || fired[0] != 2 || fired[1] != 0 {
+		t.Fatalf("alerts fired at %v, want at 2 (low) and 0 (exhausted)", fired)
+	}
+
+	// Refilling to a low pool re-arms the exhausted alert only.
+	s.alerts.settle("award", s.voucherLevel(2))
+	fired = nil
+	for _, left := range []int{1, 0} {
+		claim(left)
+	}
+	if len(fired) != 1 || fired[0] != 0 {
+		t.Fatalf("after a small refill alerts fired at %v, want at 0", fired)
+	}
+
+	// Refilling above the threshold re-arms both.
+	s.alerts.settle("award", s.voucherLevel(10))
+	fired = nil
+	for _, left := range []int{9, 3, 0} {
+		claim(left)
+	}
+	if len(fired) != 2 || fired[0] != 3 || fired[1] != 0 {
+		t.Fatalf("after a full refill alerts fired at %v, want at 3 and 0", fired)
+	}
+}
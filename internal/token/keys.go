@@ -0,0 +1,79 @@
+package token
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Key is a signing or verification key identified by the "kid" header of
+// the tokens it produces. Sign is nil for verification-only keys, which is
+// how previous keys are kept around during rotation.
+type Key struct {
+	ID     string
+	Method jwt.SigningMethod
+	Sign   any
+	Verify any
+}
+
+func NewHMACKey(id string, secret []byte) Key {
+	return Key{ID: id, Method: jwt.SigningMethodHS256, Sign: secret, Verify: secret}
+}
+
+// ParsePrivateKeyPEM loads an RSA (RS256) or Ed25519 (EdDSA) private key.
+func ParsePrivateKeyPEM(id string, data []byte) (Key, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return Key{}, errors.New("token: no PEM block found")
+	}
+
+	var parsed any
+	var err error
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	default:
+		return Key{}, fmt.Errorf("token: unsupported PEM block %q", block.Type)
+	}
+	if err != nil {
+		return Key{}, err
+	}
+
+	switch k := parsed.(type) {
+	case *rsa.PrivateKey:
+		return Key{ID: id, Method: jwt.SigningMethodRS256, Sign: k, Verify: &k.PublicKey}, nil
+	case ed25519.PrivateKey:
+		return Key{ID: id, Method: jwt.SigningMethodEdDSA, Sign: k, Verify: k.Public()}, nil
+	default:
+		return Key{}, fmt.Errorf("token: unsupported private key type %T", parsed)
+	}
+}
+
+// ParsePublicKeyPEM loads a verification-only RSA or Ed25519 public key.
+func ParsePublicKeyPEM(id string, data []byte) (Key, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return Key{}, errors.New("token: no PEM block found")
+	}
+
+	parsed, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return Key{}, err
+	}
+
+	switch k := parsed.(type) {
+	case *rsa.PublicKey:
+		return Key{ID: id, Method: jwt.SigningMethodRS256, Verify: k}, nil
+	case ed25519.PublicKey:
+		return Key{ID: id, Method: jwt.SigningMethodEdDSA, Verify: k}, nil
+	default:
+		return Key{}, fmt.Errorf("token: unsupported public key type %T", parsed)
+	}
+}
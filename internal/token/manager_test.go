@@ -0,0 +1,230 @@
+package token
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var testConfig = Config{Issuer: "quiz", AccessTTL: 15 * time.Minute}
+
+func rsaKey(t *testing.T, id string) (Key, []byte) {
+	t.Helper()
+	priv, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate rsa key: %v", err)
+	}
+	k, err := ParsePrivateKeyPEM(id, pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(priv)}))
+	if err != nil {
+		t.Fatalf("parse rsa key: %v", err)
+	}
+	return k, publicPEM(t, &priv.PublicKey)
+}
+
+func ed25519Key(t *testing.T, id string) (Key, []byte) {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("generate ed25519 key: %v", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		t.Fatalf("marshal ed25519 key: %v", err)
+	}
+	k, err := ParsePrivateKeyPEM(id, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+	if err != nil {
+		t.Fatalf("parse ed25519 key: %v", err)
+	}
+	return k, publicPEM(t, pub)
+}
+
+func publicPEM(t *testing.T, pub any) []byte {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		t.Fatalf("marshal public key: %v", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
+
+func newManager(t *testing.T, cfg Config, signing Key, verifyOnly ...Key) *Manager {
+	t.Helper()
+	m, err := NewManager(cfg, signing, verifyOnly...)
+	if err != nil {
+		t.Fatalf("new manager: %v", err)
+	}
+	return m
+}
+
+func issue(t *testing.T, m *Manager) string {
+	t.Helper()
+	raw, _, err := m.Issue("u1", "s1", []string{"player"})
+	if err != nil {
+		t.Fatalf("issue: %v", err)
+	}
+	return raw
+}
+
+func wantInvalid(t *testing.T, m *Manager, raw string) {
+	t.Helper()
+	if claims, err := m.Validate(raw); !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("validate: got %+v, %v; want ErrInvalidToken", claims, err)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	rsaSigning, _ := rsaKey(t, "rsa")
+	edSigning, _ := ed25519Key(t, "ed")
+	for _, k := range []Key{NewHMACKey("hmac", []byte("test-secret")), rsaSigning, edSigning} {
+		t.Run(k.Method.Alg(), func(t *testing.T) {
+			m := newManager(t, testConfig, k)
+			raw, expiresAt, err := m.Issue("u1", "s1", []string{"player", "editor"})
+			if err != nil {
+				t.Fatalf("issue: %v", err)
+			}
+			claims, err := m.Validate(raw)
+			if err != nil {
+				t.Fatalf("validate: %v", err)
+			}
+			if claims.UserID() != "u1" || claims.SessionID != "s1" || !reflect.DeepEqual(claims.Roles, []string{"player", "editor"}) {
+				t.Fatalf("got claims %+v", claims)
+			}
+			if !claims.ExpiresAt.Time.Equal(expiresAt.Truncate(time.Second)) {
+				t.Fatalf("expires at %v, want %v", claims.ExpiresAt.Time, expiresAt)
+			}
+
+			parsed, _, err := jwt.NewParser().ParseUnverified(raw, &Claims{})
+			if err != nil {
+				t.Fatalf("parse: %v", err)
+			}
+			if parsed.Header["kid"] != k.ID || parsed.Header["alg"] != k.Method.Alg() {
+				t.Fatalf("header %v, want kid %s and alg %s", parsed.Header, k.ID, k.Method.Alg())
+			}
+		})
+	}
+}
+
+func TestValidateRejectsUnknownKeyID(t *testing.T) {
+	secret := []byte("test-secret")
+	raw := issue(t, newManager(t, testConfig, NewHMACKey("other", secret)))
+	wantInvalid(t, newManager(t, testConfig, NewHMACKey("current", secret)), raw)
+
+	noKid := jwt.NewWithClaims(jwt.SigningMethodHS256, &Claims{RegisteredClaims: jwt.RegisteredClaims{
+		Issuer:    testConfig.Issuer,
+		Subject:   "u1",
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+	}})
+	signed, err := noKid.SignedString(secret)
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+	wantInvalid(t, newManager(t, testConfig, NewHMACKey("current", secret)), signed)
+}
+
+// TestValidateRejectsAlgConfusion forges an HS256 token using the RSA
+// public key, which attackers can get, as the HMAC secret.
+func TestValidateRejectsAlgConfusion(t *testing.T) {
+	k, pubPEM := rsaKey(t, "rsa")
+	m := newManager(t, testConfig, k)
+	block, _ := pem.Decode(pubPEM)
+	for name, secret := range map[string][]byte{"PEM": pubPEM, "DER": block.Bytes} {
+		t.Run(name, func(t *testing.T) {
+			forged := jwt.NewWithClaims(jwt.SigningMethodHS256, &Claims{
+				RegisteredClaims: jwt.RegisteredClaims{
+					Issuer:    testConfig.Issuer,
+					Subject:   "admin",
+					ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+				},
+				SessionID: "s1",
+			})
+			forged.Header["kid"] = "rsa"
+			raw, err := forged.SignedString(secret)
+			if err != nil {
+				t.Fatalf("sign: %v", err)
+			}
+			wantInvalid(t, m, raw)
+		})
+	}
+}
+
+func TestValidateRejectsExpiredToken(t *testing.T) {
+	m := newManager(t, testConfig, NewHMACKey("k1", []byte("test-secret")))
+	now := time.Now()
+	m.now = func() time.Time { return now }
+	raw := issue(t, m)
+
+	m.now = func() time.Time { return now.Add(testConfig.AccessTTL - time.Second) }
+	if _, err := m.Validate(raw); err != nil {
+		t.Fatalf("validate before expiry: %v", err)
+	}
+	m.now = func() time.Time { return now.Add(testConfig.AccessTTL + time.Second) }
+	wantInvalid(t, m, raw)
+}
+
+func TestValidateRejectsWrongIssuer(t *testing.T) {
+	k := NewHMACKey("k1", []byte("test-secret"))
+	raw := issue(t, newManager(t, Config{Issuer: "someone-else", AccessTTL: time.Minute}, k))
+	wantInvalid(t, newManager(t, testConfig, k), raw)
+}
+
+func TestValidateRejectsMissingSubject(t *testing.T) {
+	m := newManager(t, testConfig, NewHMACKey("k1", []byte("test-secret")))
+	raw, _, err := m.Issue("", "s1", nil)
+	if err != nil {
+		t.Fatalf("issue: %v", err)
+	}
+	wantInvalid(t, m, raw)
+}
+
+func TestRotationKeyOnlyVerifies(t *testing.T) {
+	for _, gen := range []func(*testing.T, string) (Key, []byte){rsaKey, ed25519Key} {
+		old, oldPub := gen(t, "old")
+		t.Run(old.Method.Alg(), func(t *testing.T) {
+			raw := issue(t, newManager(t, testConfig, old))
+
+			verifyOnly, err := ParsePublicKeyPEM("old", oldPub)
+			if err != nil {
+				t.Fatalf("parse public key: %v", err)
+			}
+			if verifyOnly.Sign != nil {
+				t.Fatal("a public key can sign")
+			}
+			if _, err := NewManager(testConfig, verifyOnly); err == nil {
+				t.Fatal("NewManager accepted a verify-only signing key")
+			}
+
+			current, _ := ed25519Key(t, "current")
+			m := newManager(t, testConfig, current, verifyOnly)
+			claims, err := m.Validate(raw)
+			if err != nil {
+				t.Fatalf("validate a token of the rotated-out key: %v", err)
+			}
+			if claims.UserID() != "u1" {
+				t.Fatalf("got subject %q, want u1", claims.UserID())
+			}
+			fresh, err := m.Validate(issue(t, m))
+			if err != nil || fresh.UserID() != "u1" {
+				t.Fatalf("validate a token of the current key: %+v, %v", fresh, err)
+			}
+			parsed, _, _ := jwt.NewParser().ParseUnverified(issue(t, m), &Claims{})
+			if parsed.Header["kid"] != "current" {
+				t.Fatalf("new tokens signed by %v, want current", parsed.Header["kid"])
+			}
+		})
+	}
+}
+
+func TestNewManagerRejectsDuplicateKeyIDs(t *testing.T) {
+	k := NewHMACKey("k1", []byte("test-secret"))
+	if _, err := NewManager(testConfig, k, NewHMACKey("k1", []byte("other"))); err == nil {
+		t.Fatal("NewManager accepted two keys with one id")
+	}
+}
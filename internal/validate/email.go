@@ -0,0 +1,35 @@
+package validate
+
+import (
+	"net/mail"
+	"strings"
+)
+
+const maxEmailLength = 254
+
+// Email trims raw and reports whether it is a bare address such as
+// "ana@example.com". Display names ("Ana <ana@example.com>") and addresses
+// without a dot in the domain are rejected.
+func Email(raw string) (email string, ok bool) {
+	email = strings.TrimSpace(raw)
+	if len(email) > maxEmailLength {
+		return "", false
+	}
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Address != email || addr.Name != "" {
+		return "", false
+	}
+	at := strings.LastIndexByte(email, '@')
+	if domain := email[at+1:]; !strings.Contains(domain, ".") || strings.HasSuffix(domain, ".") {
+		return "", false
+	}
+	return email, true
+}
+
+// Email trims raw like the package-level Email, recording a violation of
+// field if it is not an email address.
+func (v *Violations) Email(field, raw string) string {
+	email, ok := Email(raw)
+	v.Check(ok, field, "must be an email address")
+	return email
+}
@@ -0,0 +1,54 @@
+package validate
+
+import "strings"
+
+// E.164 numbers have a country code and subscriber number of at most 15
+// digits in total. The lower bound rejects obvious typos while allowing the
+// shortest national numbers in use.
+const (
+	minPhoneDigits = 7
+	maxPhoneDigits = 15
+)
+
+// Phone normalizes raw to E.164 form, "+" followed by digits, so the same
+// number typed as "+1 (555) 010-0199", "1-555-010-0199" or
+// "0015550100199" is stored and looked up identically. Spaces, dashes, dots
+// and parentheses are dropped and a leading "00" international prefix
+// becomes "+". Numbers without either prefix are taken to already include
+// their country code. ok is false if raw is not a phone number.
+func Phone(raw string) (phone string, ok bool) {
+	s := strings.TrimSpace(raw)
+	switch {
+	case strings.HasPrefix(s, "+"):
+		s = s[1:]
+	case strings.HasPrefix(s, "00"):
+		s = s[2:]
+	}
+
+	var b strings.Builder
+	b.WriteByte('+')
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case r == ' ' || r == '-' || r == '.' || r == '(' || r == ')':
+		default:
+			return "", false
+		}
+	}
+
+	phone = b.String()
+	digits := len(phone) - 1
+	if digits < minPhoneDigits || digits > maxPhoneDigits || phone[1] == '0' {
+		return "", false
+	}
+	return phone, true
+}
+
+// Phone normalizes raw like the package-level Phone, recording a violation
+// of field if it is not a phone number.
+func (v *Violations) Phone(field, raw string) string {
+	phone, ok := Phone(raw)
+	v.Check(ok, field, "must be a phone number in international format, like +15550100199")
+	return phone
+}
@@ -0,0 +1,37 @@
+// Package validate checks and normalizes request fields before the services
+// act on them. Checks are collected in a Violations so one response can
+// report every bad field at once.
+package validate
+
+import (
+	"fmt"
+
+	"github.com/rprajapati0067/quiz-game-backend/internal/apperr"
+)
+
+// ErrInvalid matches every error returned by Violations.Err.
+var ErrInvalid = apperr.New(apperr.InvalidArgument, "INVALID_ARGUMENT", "invalid request")
+
+// Violations collects the invalid fields of one request.
+type Violations []apperr.FieldViolation
+
+// Add records that field is invalid.
+func (v *Violations) Add(field, format string, args ...interface{}) {
+	*v = append(*v, apperr.FieldViolation{Field: field, Description: fmt.Sprintf(format, args...)})
+}
+
+// Check records a violation of field unless ok.
+func (v *Violations) Check(ok bool, field, format string, args ...interface{}) {
+	if !ok {
+		v.Add(field, format, args...)
+	}
+}
+
+// Err returns an ErrInvalid listing the violations, or nil if there are
+// none.
+func (v Violations) Err() error {
+	if len(v) == 0 {
+		return nil
+	}
+	return ErrInvalid.WithViolations(v...)
+}
@@ -0,0 +1,101 @@
+package validate
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/rprajapati0067/quiz-game-backend/internal/apperr"
+)
+
+func TestPhone(t *testing.T) {
+	for _, tc := range []struct {
+		raw  string
+		want string // empty when raw is rejected
+	}{
+		{raw: "+15550100199", want: "+15550100199"},
+		{raw: "+1 (555) 010-0199", want: "+15550100199"},
+		{raw: "+1.555.010.0199", want: "+15550100199"},
+		{raw: "1-555-010-0199", want: "+15550100199"},
+		{raw: "0015550100199", want: "+15550100199"},
+		{raw: "00 44 20 7946 0958", want: "+442079460958"},
+		{raw: "  +44 20 7946 0958\t", want: "+442079460958"},
+		{raw: "+1234567", want: "+1234567"},
+		{raw: "+123456789012345", want: "+123456789012345"},
+
+		{raw: ""},
+		{raw: "+"},
+		{raw: "+123456"},
+		{raw: "+1234567890123456"},
+		{raw: "+0155501001"},
+		{raw: "0155501001"},
+		{raw: "000155501001"},
+		{raw: "++15550100199"},
+		{raw: "+1 555 CALL NOW"},
+		{raw: "+1/555/010/0199"},
+		{raw: "tel:+15550100199"},
+	} {
+		got, ok := Phone(tc.raw)
+		if ok != (tc.want != "") || got != tc.want {
+			t.Errorf("Phone(%q) = %q, %v; want %q", tc.raw, got, ok, tc.want)
+		}
+	}
+}
+
+func TestEmail(t *testing.T) {
+	long := strings.Repeat("a", maxEmailLength-len("@example.com")) + "@example.com"
+	for _, tc := range []struct {
+		raw  string
+		want string // empty when raw is rejected
+	}{
+		{raw: "ana@example.com", want: "ana@example.com"},
+		{raw: "  ana.maria+quiz@mail.example.co.uk ", want: "ana.maria+quiz@mail.example.co.uk"},
+		{raw: long, want: long},
+
+		{raw: ""},
+		{raw: "ana"},
+		{raw: "ana@"},
+		{raw: "@example.com"},
+		{raw: "Ana <ana@example.com>"},
+		{raw: "<ana@example.com>"},
+		{raw: `"Ana" <ana@example.com>`},
+		{raw: "ana@localhost"},
+		{raw: "ana@example."},
+		{raw: "ana@example.com, bob@example.com"},
+		{raw: "x" + long},
+	} {
+		got, ok := Email(tc.raw)
+		if ok != (tc.want != "") || got != tc.want {
+			t.Errorf("Email(%q) = %q, %v; want %q", tc.raw, got, ok, tc.want)
+		}
+	}
+}
+
+func TestViolations(t *testing.T) {
+	var v Violations
+	if err := v.Err(); err != nil {
+		t.Fatalf("no violations: got %v", err)
+	}
+
+	phone := v.Phone("phone", "+1 (555) 010-0199")
+	email := v.Email("email", "Ana <ana@example.com>")
+	v.Check(true, "name", "is required")
+	v.Check(false, "options", "must have at least %d entries", 2)
+	if phone != "+15550100199" || email != "" {
+		t.Fatalf("got phone %q and email %q", phone, email)
+	}
+
+	err := v.Err()
+	if !errors.Is(err, ErrInvalid) || apperr.KindOf(err) != apperr.InvalidArgument {
+		t.Fatalf("got %v, want ErrInvalid", err)
+	}
+	e, _ := apperr.From(err)
+	want := []apperr.FieldViolation{
+		{Field: "email", Description: "must be an email address"},
+		{Field: "options", Description: "must have at least 2 entries"},
+	}
+	if !reflect.DeepEqual(e.Violations, want) {
+		t.Fatalf("violations %+v, want %+v", e.Violations, want)
+	}
+}
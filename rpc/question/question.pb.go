@@ -7,6 +7,7 @@
 package question
 
 import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	reflect "reflect"
@@ -21,13 +22,16 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Question is returned in full to editors and admins. Players never
+// receive correct_index or created_by, and may see options reordered.
 type Question struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	Text          string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
 	Options       []string               `protobuf:"bytes,3,rep,name=options,proto3" json:"options,omitempty"`
-	CorrectIndex  int32                  `protobuf:"varint,4,opt,name=correct_index,json=correctIndex,proto3" json:"correct_index,omitempty"`
+	CorrectIndex  *int32                 `protobuf:"varint,4,opt,name=correct_index,json=correctIndex,proto3,oneof" json:"correct_index,omitempty"`
 	Slot          int32                  `protobuf:"varint,5,opt,name=slot,proto3" json:"slot,omitempty"`
+	CreatedBy     string                 `protobuf:"bytes,6,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -84,8 +88,8 @@ func (x *Question) GetOptions() []string {
 }
 
 func (x *Question) GetCorrectIndex() int32 {
-	if x != nil {
-		return x.CorrectIndex
+	if x != nil && x.CorrectIndex != nil {
+		return *x.CorrectIndex
 	}
 	return 0
 }
@@ -97,6 +101,13 @@ func (x *Question) GetSlot() int32 {
 	return 0
 }
 
+func (x *Question) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
 type CreateQuestionRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Text          string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
@@ -301,8 +312,11 @@ type SubmitAnswerRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	QuestionId    string                 `protobuf:"bytes,1,opt,name=question_id,json=questionId,proto3" json:"question_id,omitempty"`
 	SelectedIndex int32                  `protobuf:"varint,2,opt,name=selected_index,json=selectedIndex,proto3" json:"selected_index,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	// Retries with the same key return the original response instead of
+	// failing with ALREADY_ANSWERED.
+	IdempotencyKey string `protobuf:"bytes,3,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *SubmitAnswerRequest) Reset() {
@@ -349,10 +363,18 @@ func (x *SubmitAnswerRequest) GetSelectedIndex() int32 {
 	return 0
 }
 
+func (x *SubmitAnswerRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
 type SubmitAnswerResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Correct       bool                   `protobuf:"varint,1,opt,name=correct,proto3" json:"correct,omitempty"`
 	UpdatedPoints int64                  `protobuf:"varint,2,opt,name=updated_points,json=updatedPoints,proto3" json:"updated_points,omitempty"`
+	Replayed      bool                   `protobuf:"varint,3,opt,name=replayed,proto3" json:"replayed,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -401,17 +423,27 @@ func (x *SubmitAnswerResponse) GetUpdatedPoints() int64 {
 	return 0
 }
 
+func (x *SubmitAnswerResponse) GetReplayed() bool {
+	if x != nil {
+		return x.Replayed
+	}
+	return false
+}
+
 var File_question_proto protoreflect.FileDescriptor
 
 const file_question_proto_rawDesc = "" +
 	"\n" +
-	"\x0equestion.proto\x12\rquiz.question\"\x81\x01\n" +
+	"\x0equestion.proto\x12\rquiz.question\x1a\x1cgoogle/api/annotations.proto\"\xb7\x01\n" +
 	"\bQuestion\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04text\x18\x02 \x01(\tR\x04text\x12\x18\n" +
-	"\aoptions\x18\x03 \x03(\tR\aoptions\x12#\n" +
-	"\rcorrect_index\x18\x04 \x01(\x05R\fcorrectIndex\x12\x12\n" +
-	"\x04slot\x18\x05 \x01(\x05R\x04slot\"~\n" +
+	"\aoptions\x18\x03 \x03(\tR\aoptions\x12(\n" +
+	"\rcorrect_index\x18\x04 \x01(\x05H\x00R\fcorrectIndex\x88\x01\x01\x12\x12\n" +
+	"\x04slot\x18\x05 \x01(\x05R\x04slot\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x06 \x01(\tR\tcreatedByB\x10\n" +
+	"\x0e_correct_index\"~\n" +
 	"\x15CreateQuestionRequest\x12\x12\n" +
 	"\x04text\x18\x01 \x01(\tR\x04text\x12\x18\n" +
 	"\aoptions\x18\x02 \x03(\tR\aoptions\x12#\n" +
@@ -422,18 +454,20 @@ const file_question_proto_rawDesc = "" +
 	"\x14ListQuestionsRequest\x12\x12\n" +
 	"\x04slot\x18\x01 \x01(\x05R\x04slot\"N\n" +
 	"\x15ListQuestionsResponse\x125\n" +
-	"\tquestions\x18\x01 \x03(\v2\x17.quiz.question.QuestionR\tquestions\"]\n" +
+	"\tquestions\x18\x01 \x03(\v2\x17.quiz.question.QuestionR\tquestions\"\x86\x01\n" +
 	"\x13SubmitAnswerRequest\x12\x1f\n" +
 	"\vquestion_id\x18\x01 \x01(\tR\n" +
 	"questionId\x12%\n" +
-	"\x0eselected_index\x18\x02 \x01(\x05R\rselectedIndex\"W\n" +
+	"\x0eselected_index\x18\x02 \x01(\x05R\rselectedIndex\x12'\n" +
+	"\x0fidempotency_key\x18\x03 \x01(\tR\x0eidempotencyKey\"s\n" +
 	"\x14SubmitAnswerResponse\x12\x18\n" +
 	"\acorrect\x18\x01 \x01(\bR\acorrect\x12%\n" +
-	"\x0eupdated_points\x18\x02 \x01(\x03R\rupdatedPoints2\xa5\x02\n" +
-	"\x0fQuestionService\x12]\n" +
-	"\x0eCreateQuestion\x12$.quiz.question.CreateQuestionRequest\x1a%.quiz.question.CreateQuestionResponse\x12Z\n" +
-	"\rListQuestions\x12#.quiz.question.ListQuestionsRequest\x1a$.quiz.question.ListQuestionsResponse\x12W\n" +
-	"\fSubmitAnswer\x12\".quiz.question.SubmitAnswerRequest\x1a#.quiz.question.SubmitAnswerResponseBCZAgithub.com/rprajapati0067/quiz-game-backend/rpc/question;questionb\x06proto3"
+	"\x0eupdated_points\x18\x02 \x01(\x03R\rupdatedPoints\x12\x1a\n" +
+	"\breplayed\x18\x03 \x01(\bR\breplayed2\x8b\x03\n" +
+	"\x0fQuestionService\x12\x82\x01\n" +
+	"\x0eCreateQuestion\x12$.quiz.question.CreateQuestionRequest\x1a%.quiz.question.CreateQuestionResponse\"#\x82\xd3\xe4\x93\x02\x1d:\x01*\"\x18/api/v1/questions/create\x12u\n" +
+	"\rListQuestions\x12#.quiz.question.ListQuestionsRequest\x1a$.quiz.question.ListQuestionsResponse\"\x19\x82\xd3\xe4\x93\x02\x13\x12\x11/api/v1/questions\x12|\n" +
+	"\fSubmitAnswer\x12\".quiz.question.SubmitAnswerRequest\x1a#.quiz.question.SubmitAnswerResponse\"#\x82\xd3\xe4\x93\x02\x1d:\x01*\"\x18/api/v1/questions/submitBCZAgithub.com/rprajapati0067/quiz-game-backend/rpc/question;questionb\x06proto3"
 
 var (
 	file_question_proto_rawDescOnce sync.Once
@@ -478,6 +512,7 @@ func file_question_proto_init() {
 	if File_question_proto != nil {
 		return
 	}
+	file_question_proto_msgTypes[0].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
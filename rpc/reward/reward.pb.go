@@ -7,6 +7,7 @@
 package reward
 
 import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	reflect "reflect"
@@ -22,10 +23,20 @@ const (
 )
 
 type Award struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Product       string                 `protobuf:"bytes,2,opt,name=product,proto3" json:"product,omitempty"`
-	PointCost     int64                  `protobuf:"varint,3,opt,name=point_cost,json=pointCost,proto3" json:"point_cost,omitempty"`
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Product        string                 `protobuf:"bytes,2,opt,name=product,proto3" json:"product,omitempty"`
+	PointCost      int64                  `protobuf:"varint,3,opt,name=point_cost,json=pointCost,proto3" json:"point_cost,omitempty"`
+	TotalStock     int64                  `protobuf:"varint,4,opt,name=total_stock,json=totalStock,proto3" json:"total_stock,omitempty"`
+	RemainingStock int64                  `protobuf:"varint,5,opt,name=remaining_stock,json=remainingStock,proto3" json:"remaining_stock,omitempty"`
+	// 0 means no per-user limit.
+	PerUserLimit int32 `protobuf:"varint,6,opt,name=per_user_limit,json=perUserLimit,proto3" json:"per_user_limit,omitempty"`
+	// Unix seconds; 0 leaves that end of the window open.
+	AvailableFrom  int64 `protobuf:"varint,7,opt,name=available_from,json=availableFrom,proto3" json:"available_from,omitempty"`
+	AvailableUntil int64 `protobuf:"varint,8,opt,name=available_until,json=availableUntil,proto3" json:"available_until,omitempty"`
+	Active         bool  `protobuf:"varint,9,opt,name=active,proto3" json:"active,omitempty"`
+	// Digital awards hand out a voucher code and are fulfilled immediately.
+	Digital       bool `protobuf:"varint,10,opt,name=digital,proto3" json:"digital,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -81,6 +92,56 @@ func (x *Award) GetPointCost() int64 {
 	return 0
 }
 
+func (x *Award) GetTotalStock() int64 {
+	if x != nil {
+		return x.TotalStock
+	}
+	return 0
+}
+
+func (x *Award) GetRemainingStock() int64 {
+	if x != nil {
+		return x.RemainingStock
+	}
+	return 0
+}
+
+func (x *Award) GetPerUserLimit() int32 {
+	if x != nil {
+		return x.PerUserLimit
+	}
+	return 0
+}
+
+func (x *Award) GetAvailableFrom() int64 {
+	if x != nil {
+		return x.AvailableFrom
+	}
+	return 0
+}
+
+func (x *Award) GetAvailableUntil() int64 {
+	if x != nil {
+		return x.AvailableUntil
+	}
+	return 0
+}
+
+func (x *Award) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+func (x *Award) GetDigital() bool {
+	if x != nil {
+		return x.Digital
+	}
+	return false
+}
+
+// Players only see awards they can claim right now; admins see all.
 type ListAwardsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
@@ -209,8 +270,11 @@ type ClaimAwardResponse struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
 	Success         bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
 	RemainingPoints int64                  `protobuf:"varint,2,opt,name=remaining_points,json=remainingPoints,proto3" json:"remaining_points,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+	Claim           *Claim                 `protobuf:"bytes,3,opt,name=claim,proto3" json:"claim,omitempty"`
+	// Set for digital awards.
+	VoucherCode   string `protobuf:"bytes,4,opt,name=voucher_code,json=voucherCode,proto3" json:"voucher_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ClaimAwardResponse) Reset() {
@@ -257,29 +321,891 @@ func (x *ClaimAwardResponse) GetRemainingPoints() int64 {
 	return 0
 }
 
-var File_reward_proto protoreflect.FileDescriptor
+func (x *ClaimAwardResponse) GetClaim() *Claim {
+	if x != nil {
+		return x.Claim
+	}
+	return nil
+}
 
-const file_reward_proto_rawDesc = "" +
-	"\n" +
-	"\freward.proto\x12\vquiz.reward\"P\n" +
-	"\x05Award\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
-	"\aproduct\x18\x02 \x01(\tR\aproduct\x12\x1d\n" +
-	"\n" +
-	"point_cost\x18\x03 \x01(\x03R\tpointCost\"\x13\n" +
-	"\x11ListAwardsRequest\"@\n" +
-	"\x12ListAwardsResponse\x12*\n" +
-	"\x06awards\x18\x01 \x03(\v2\x12.quiz.reward.AwardR\x06awards\".\n" +
-	"\x11ClaimAwardRequest\x12\x19\n" +
-	"\baward_id\x18\x01 \x01(\tR\aawardId\"Y\n" +
-	"\x12ClaimAwardResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\x12)\n" +
-	"\x10remaining_points\x18\x02 \x01(\x03R\x0fremainingPoints2\xad\x01\n" +
-	"\rRewardService\x12M\n" +
+func (x *ClaimAwardResponse) GetVoucherCode() string {
+	if x != nil {
+		return x.VoucherCode
+	}
+	return ""
+}
+
+type Claim struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Id      string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId  string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	AwardId string                 `protobuf:"bytes,3,opt,name=award_id,json=awardId,proto3" json:"award_id,omitempty"`
+	Points  int64                  `protobuf:"varint,4,opt,name=points,proto3" json:"points,omitempty"`
+	// pending, approved, fulfilled, rejected or refunded.
+	Status        string `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	Note          string `protobuf:"bytes,6,opt,name=note,proto3" json:"note,omitempty"`
+	ClaimedAt     int64  `protobuf:"varint,7,opt,name=claimed_at,json=claimedAt,proto3" json:"claimed_at,omitempty"`
+	UpdatedAt     int64  `protobuf:"varint,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	VoucherCode   string `protobuf:"bytes,9,opt,name=voucher_code,json=voucherCode,proto3" json:"voucher_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Claim) Reset() {
+	*x = Claim{}
+	mi := &file_reward_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Claim) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Claim) ProtoMessage() {}
+
+func (x *Claim) ProtoReflect() protoreflect.Message {
+	mi := &file_reward_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Claim.ProtoReflect.Descriptor instead.
+func (*Claim) Descriptor() ([]byte, []int) {
+	return file_reward_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Claim) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Claim) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Claim) GetAwardId() string {
+	if x != nil {
+		return x.AwardId
+	}
+	return ""
+}
+
+func (x *Claim) GetPoints() int64 {
+	if x != nil {
+		return x.Points
+	}
+	return 0
+}
+
+func (x *Claim) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Claim) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+func (x *Claim) GetClaimedAt() int64 {
+	if x != nil {
+		return x.ClaimedAt
+	}
+	return 0
+}
+
+func (x *Claim) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+func (x *Claim) GetVoucherCode() string {
+	if x != nil {
+		return x.VoucherCode
+	}
+	return ""
+}
+
+type AwardSpec struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Product        string                 `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	PointCost      int64                  `protobuf:"varint,2,opt,name=point_cost,json=pointCost,proto3" json:"point_cost,omitempty"`
+	PerUserLimit   int32                  `protobuf:"varint,3,opt,name=per_user_limit,json=perUserLimit,proto3" json:"per_user_limit,omitempty"`
+	AvailableFrom  int64                  `protobuf:"varint,4,opt,name=available_from,json=availableFrom,proto3" json:"available_from,omitempty"`
+	AvailableUntil int64                  `protobuf:"varint,5,opt,name=available_until,json=availableUntil,proto3" json:"available_until,omitempty"`
+	// Only honoured by CreateAward.
+	Digital       bool `protobuf:"varint,6,opt,name=digital,proto3" json:"digital,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AwardSpec) Reset() {
+	*x = AwardSpec{}
+	mi := &file_reward_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AwardSpec) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AwardSpec) ProtoMessage() {}
+
+func (x *AwardSpec) ProtoReflect() protoreflect.Message {
+	mi := &file_reward_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AwardSpec.ProtoReflect.Descriptor instead.
+func (*AwardSpec) Descriptor() ([]byte, []int) {
+	return file_reward_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *AwardSpec) GetProduct() string {
+	if x != nil {
+		return x.Product
+	}
+	return ""
+}
+
+func (x *AwardSpec) GetPointCost() int64 {
+	if x != nil {
+		return x.PointCost
+	}
+	return 0
+}
+
+func (x *AwardSpec) GetPerUserLimit() int32 {
+	if x != nil {
+		return x.PerUserLimit
+	}
+	return 0
+}
+
+func (x *AwardSpec) GetAvailableFrom() int64 {
+	if x != nil {
+		return x.AvailableFrom
+	}
+	return 0
+}
+
+func (x *AwardSpec) GetAvailableUntil() int64 {
+	if x != nil {
+		return x.AvailableUntil
+	}
+	return 0
+}
+
+func (x *AwardSpec) GetDigital() bool {
+	if x != nil {
+		return x.Digital
+	}
+	return false
+}
+
+type CreateAwardRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Spec          *AwardSpec             `protobuf:"bytes,1,opt,name=spec,proto3" json:"spec,omitempty"`
+	Stock         int64                  `protobuf:"varint,2,opt,name=stock,proto3" json:"stock,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAwardRequest) Reset() {
+	*x = CreateAwardRequest{}
+	mi := &file_reward_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAwardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAwardRequest) ProtoMessage() {}
+
+func (x *CreateAwardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reward_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAwardRequest.ProtoReflect.Descriptor instead.
+func (*CreateAwardRequest) Descriptor() ([]byte, []int) {
+	return file_reward_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CreateAwardRequest) GetSpec() *AwardSpec {
+	if x != nil {
+		return x.Spec
+	}
+	return nil
+}
+
+func (x *CreateAwardRequest) GetStock() int64 {
+	if x != nil {
+		return x.Stock
+	}
+	return 0
+}
+
+type UpdateAwardRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AwardId       string                 `protobuf:"bytes,1,opt,name=award_id,json=awardId,proto3" json:"award_id,omitempty"`
+	Spec          *AwardSpec             `protobuf:"bytes,2,opt,name=spec,proto3" json:"spec,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateAwardRequest) Reset() {
+	*x = UpdateAwardRequest{}
+	mi := &file_reward_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateAwardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateAwardRequest) ProtoMessage() {}
+
+func (x *UpdateAwardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reward_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateAwardRequest.ProtoReflect.Descriptor instead.
+func (*UpdateAwardRequest) Descriptor() ([]byte, []int) {
+	return file_reward_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *UpdateAwardRequest) GetAwardId() string {
+	if x != nil {
+		return x.AwardId
+	}
+	return ""
+}
+
+func (x *UpdateAwardRequest) GetSpec() *AwardSpec {
+	if x != nil {
+		return x.Spec
+	}
+	return nil
+}
+
+type RestockAwardRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AwardId       string                 `protobuf:"bytes,1,opt,name=award_id,json=awardId,proto3" json:"award_id,omitempty"`
+	Quantity      int64                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestockAwardRequest) Reset() {
+	*x = RestockAwardRequest{}
+	mi := &file_reward_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestockAwardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestockAwardRequest) ProtoMessage() {}
+
+func (x *RestockAwardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reward_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestockAwardRequest.ProtoReflect.Descriptor instead.
+func (*RestockAwardRequest) Descriptor() ([]byte, []int) {
+	return file_reward_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *RestockAwardRequest) GetAwardId() string {
+	if x != nil {
+		return x.AwardId
+	}
+	return ""
+}
+
+func (x *RestockAwardRequest) GetQuantity() int64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+type RetireAwardRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AwardId       string                 `protobuf:"bytes,1,opt,name=award_id,json=awardId,proto3" json:"award_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RetireAwardRequest) Reset() {
+	*x = RetireAwardRequest{}
+	mi := &file_reward_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RetireAwardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetireAwardRequest) ProtoMessage() {}
+
+func (x *RetireAwardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reward_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetireAwardRequest.ProtoReflect.Descriptor instead.
+func (*RetireAwardRequest) Descriptor() ([]byte, []int) {
+	return file_reward_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RetireAwardRequest) GetAwardId() string {
+	if x != nil {
+		return x.AwardId
+	}
+	return ""
+}
+
+type AwardResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Award         *Award                 `protobuf:"bytes,1,opt,name=award,proto3" json:"award,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AwardResponse) Reset() {
+	*x = AwardResponse{}
+	mi := &file_reward_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AwardResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AwardResponse) ProtoMessage() {}
+
+func (x *AwardResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_reward_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AwardResponse.ProtoReflect.Descriptor instead.
+func (*AwardResponse) Descriptor() ([]byte, []int) {
+	return file_reward_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *AwardResponse) GetAward() *Award {
+	if x != nil {
+		return x.Award
+	}
+	return nil
+}
+
+type ListMyClaimsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMyClaimsRequest) Reset() {
+	*x = ListMyClaimsRequest{}
+	mi := &file_reward_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMyClaimsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMyClaimsRequest) ProtoMessage() {}
+
+func (x *ListMyClaimsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reward_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMyClaimsRequest.ProtoReflect.Descriptor instead.
+func (*ListMyClaimsRequest) Descriptor() ([]byte, []int) {
+	return file_reward_proto_rawDescGZIP(), []int{12}
+}
+
+type ListClaimsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListClaimsRequest) Reset() {
+	*x = ListClaimsRequest{}
+	mi := &file_reward_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListClaimsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListClaimsRequest) ProtoMessage() {}
+
+func (x *ListClaimsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reward_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListClaimsRequest.ProtoReflect.Descriptor instead.
+func (*ListClaimsRequest) Descriptor() ([]byte, []int) {
+	return file_reward_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListClaimsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type ListClaimsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Claims        []*Claim               `protobuf:"bytes,1,rep,name=claims,proto3" json:"claims,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListClaimsResponse) Reset() {
+	*x = ListClaimsResponse{}
+	mi := &file_reward_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListClaimsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListClaimsResponse) ProtoMessage() {}
+
+func (x *ListClaimsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_reward_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListClaimsResponse.ProtoReflect.Descriptor instead.
+func (*ListClaimsResponse) Descriptor() ([]byte, []int) {
+	return file_reward_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListClaimsResponse) GetClaims() []*Claim {
+	if x != nil {
+		return x.Claims
+	}
+	return nil
+}
+
+type ClaimTransitionRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	ClaimId string                 `protobuf:"bytes,1,opt,name=claim_id,json=claimId,proto3" json:"claim_id,omitempty"`
+	// Shown to the player, e.g. a rejection reason or tracking number.
+	Note          string `protobuf:"bytes,2,opt,name=note,proto3" json:"note,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClaimTransitionRequest) Reset() {
+	*x = ClaimTransitionRequest{}
+	mi := &file_reward_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClaimTransitionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClaimTransitionRequest) ProtoMessage() {}
+
+func (x *ClaimTransitionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reward_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClaimTransitionRequest.ProtoReflect.Descriptor instead.
+func (*ClaimTransitionRequest) Descriptor() ([]byte, []int) {
+	return file_reward_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ClaimTransitionRequest) GetClaimId() string {
+	if x != nil {
+		return x.ClaimId
+	}
+	return ""
+}
+
+func (x *ClaimTransitionRequest) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+type ClaimResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Claim         *Claim                 `protobuf:"bytes,1,opt,name=claim,proto3" json:"claim,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClaimResponse) Reset() {
+	*x = ClaimResponse{}
+	mi := &file_reward_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClaimResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClaimResponse) ProtoMessage() {}
+
+func (x *ClaimResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_reward_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClaimResponse.ProtoReflect.Descriptor instead.
+func (*ClaimResponse) Descriptor() ([]byte, []int) {
+	return file_reward_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ClaimResponse) GetClaim() *Claim {
+	if x != nil {
+		return x.Claim
+	}
+	return nil
+}
+
+type UploadVouchersRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	AwardId string                 `protobuf:"bytes,1,opt,name=award_id,json=awardId,proto3" json:"award_id,omitempty"`
+	// CSV with one code per row in the first column; an optional header row
+	// named "code" is skipped.
+	Csv           []byte `protobuf:"bytes,2,opt,name=csv,proto3" json:"csv,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadVouchersRequest) Reset() {
+	*x = UploadVouchersRequest{}
+	mi := &file_reward_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadVouchersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadVouchersRequest) ProtoMessage() {}
+
+func (x *UploadVouchersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reward_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadVouchersRequest.ProtoReflect.Descriptor instead.
+func (*UploadVouchersRequest) Descriptor() ([]byte, []int) {
+	return file_reward_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *UploadVouchersRequest) GetAwardId() string {
+	if x != nil {
+		return x.AwardId
+	}
+	return ""
+}
+
+func (x *UploadVouchersRequest) GetCsv() []byte {
+	if x != nil {
+		return x.Csv
+	}
+	return nil
+}
+
+type UploadVouchersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Award         *Award                 `protobuf:"bytes,1,opt,name=award,proto3" json:"award,omitempty"`
+	Added         int32                  `protobuf:"varint,2,opt,name=added,proto3" json:"added,omitempty"`
+	Duplicates    int32                  `protobuf:"varint,3,opt,name=duplicates,proto3" json:"duplicates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadVouchersResponse) Reset() {
+	*x = UploadVouchersResponse{}
+	mi := &file_reward_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadVouchersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadVouchersResponse) ProtoMessage() {}
+
+func (x *UploadVouchersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_reward_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadVouchersResponse.ProtoReflect.Descriptor instead.
+func (*UploadVouchersResponse) Descriptor() ([]byte, []int) {
+	return file_reward_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *UploadVouchersResponse) GetAward() *Award {
+	if x != nil {
+		return x.Award
+	}
+	return nil
+}
+
+func (x *UploadVouchersResponse) GetAdded() int32 {
+	if x != nil {
+		return x.Added
+	}
+	return 0
+}
+
+func (x *UploadVouchersResponse) GetDuplicates() int32 {
+	if x != nil {
+		return x.Duplicates
+	}
+	return 0
+}
+
+var File_reward_proto protoreflect.FileDescriptor
+
+const file_reward_proto_rawDesc = "" +
+	"\n" +
+	"\freward.proto\x12\vquiz.reward\x1a\x1cgoogle/api/annotations.proto\"\xc2\x02\n" +
+	"\x05Award\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\aproduct\x18\x02 \x01(\tR\aproduct\x12\x1d\n" +
+	"\n" +
+	"point_cost\x18\x03 \x01(\x03R\tpointCost\x12\x1f\n" +
+	"\vtotal_stock\x18\x04 \x01(\x03R\n" +
+	"totalStock\x12'\n" +
+	"\x0fremaining_stock\x18\x05 \x01(\x03R\x0eremainingStock\x12$\n" +
+	"\x0eper_user_limit\x18\x06 \x01(\x05R\fperUserLimit\x12%\n" +
+	"\x0eavailable_from\x18\a \x01(\x03R\ravailableFrom\x12'\n" +
+	"\x0favailable_until\x18\b \x01(\x03R\x0eavailableUntil\x12\x16\n" +
+	"\x06active\x18\t \x01(\bR\x06active\x12\x18\n" +
+	"\adigital\x18\n" +
+	" \x01(\bR\adigital\"\x13\n" +
+	"\x11ListAwardsRequest\"@\n" +
+	"\x12ListAwardsResponse\x12*\n" +
+	"\x06awards\x18\x01 \x03(\v2\x12.quiz.reward.AwardR\x06awards\".\n" +
+	"\x11ClaimAwardRequest\x12\x19\n" +
+	"\baward_id\x18\x01 \x01(\tR\aawardId\"\xa6\x01\n" +
+	"\x12ClaimAwardResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12)\n" +
+	"\x10remaining_points\x18\x02 \x01(\x03R\x0fremainingPoints\x12(\n" +
+	"\x05claim\x18\x03 \x01(\v2\x12.quiz.reward.ClaimR\x05claim\x12!\n" +
+	"\fvoucher_code\x18\x04 \x01(\tR\vvoucherCode\"\xf0\x01\n" +
+	"\x05Claim\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x19\n" +
+	"\baward_id\x18\x03 \x01(\tR\aawardId\x12\x16\n" +
+	"\x06points\x18\x04 \x01(\x03R\x06points\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12\x12\n" +
+	"\x04note\x18\x06 \x01(\tR\x04note\x12\x1d\n" +
+	"\n" +
+	"claimed_at\x18\a \x01(\x03R\tclaimedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\x03R\tupdatedAt\x12!\n" +
+	"\fvoucher_code\x18\t \x01(\tR\vvoucherCode\"\xd4\x01\n" +
+	"\tAwardSpec\x12\x18\n" +
+	"\aproduct\x18\x01 \x01(\tR\aproduct\x12\x1d\n" +
+	"\n" +
+	"point_cost\x18\x02 \x01(\x03R\tpointCost\x12$\n" +
+	"\x0eper_user_limit\x18\x03 \x01(\x05R\fperUserLimit\x12%\n" +
+	"\x0eavailable_from\x18\x04 \x01(\x03R\ravailableFrom\x12'\n" +
+	"\x0favailable_until\x18\x05 \x01(\x03R\x0eavailableUntil\x12\x18\n" +
+	"\adigital\x18\x06 \x01(\bR\adigital\"V\n" +
+	"\x12CreateAwardRequest\x12*\n" +
+	"\x04spec\x18\x01 \x01(\v2\x16.quiz.reward.AwardSpecR\x04spec\x12\x14\n" +
+	"\x05stock\x18\x02 \x01(\x03R\x05stock\"[\n" +
+	"\x12UpdateAwardRequest\x12\x19\n" +
+	"\baward_id\x18\x01 \x01(\tR\aawardId\x12*\n" +
+	"\x04spec\x18\x02 \x01(\v2\x16.quiz.reward.AwardSpecR\x04spec\"L\n" +
+	"\x13RestockAwardRequest\x12\x19\n" +
+	"\baward_id\x18\x01 \x01(\tR\aawardId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x03R\bquantity\"/\n" +
+	"\x12RetireAwardRequest\x12\x19\n" +
+	"\baward_id\x18\x01 \x01(\tR\aawardId\"9\n" +
+	"\rAwardResponse\x12(\n" +
+	"\x05award\x18\x01 \x01(\v2\x12.quiz.reward.AwardR\x05award\"\x15\n" +
+	"\x13ListMyClaimsRequest\"+\n" +
+	"\x11ListClaimsRequest\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\"@\n" +
+	"\x12ListClaimsResponse\x12*\n" +
+	"\x06claims\x18\x01 \x03(\v2\x12.quiz.reward.ClaimR\x06claims\"G\n" +
+	"\x16ClaimTransitionRequest\x12\x19\n" +
+	"\bclaim_id\x18\x01 \x01(\tR\aclaimId\x12\x12\n" +
+	"\x04note\x18\x02 \x01(\tR\x04note\"9\n" +
+	"\rClaimResponse\x12(\n" +
+	"\x05claim\x18\x01 \x01(\v2\x12.quiz.reward.ClaimR\x05claim\"D\n" +
+	"\x15UploadVouchersRequest\x12\x19\n" +
+	"\baward_id\x18\x01 \x01(\tR\aawardId\x12\x10\n" +
+	"\x03csv\x18\x02 \x01(\fR\x03csv\"x\n" +
+	"\x16UploadVouchersResponse\x12(\n" +
+	"\x05award\x18\x01 \x01(\v2\x12.quiz.reward.AwardR\x05award\x12\x14\n" +
+	"\x05added\x18\x02 \x01(\x05R\x05added\x12\x1e\n" +
+	"\n" +
+	"duplicates\x18\x03 \x01(\x05R\n" +
+	"duplicates2\x87\f\n" +
+	"\rRewardService\x12f\n" +
+	"\n" +
+	"ListAwards\x12\x1e.quiz.reward.ListAwardsRequest\x1a\x1f.quiz.reward.ListAwardsResponse\"\x17\x82\xd3\xe4\x93\x02\x11\x12\x0f/api/v1/rewards\x12o\n" +
 	"\n" +
-	"ListAwards\x12\x1e.quiz.reward.ListAwardsRequest\x1a\x1f.quiz.reward.ListAwardsResponse\x12M\n" +
+	"ClaimAward\x12\x1e.quiz.reward.ClaimAwardRequest\x1a\x1f.quiz.reward.ClaimAwardResponse\" \x82\xd3\xe4\x93\x02\x1a:\x01*\"\x15/api/v1/rewards/claim\x12r\n" +
+	"\vCreateAward\x12\x1f.quiz.reward.CreateAwardRequest\x1a\x1a.quiz.reward.AwardResponse\"&\x82\xd3\xe4\x93\x02 :\x01*\"\x1b/api/v1/admin/awards/create\x12r\n" +
+	"\vUpdateAward\x12\x1f.quiz.reward.UpdateAwardRequest\x1a\x1a.quiz.reward.AwardResponse\"&\x82\xd3\xe4\x93\x02 :\x01*\"\x1b/api/v1/admin/awards/update\x12u\n" +
+	"\fRestockAward\x12 .quiz.reward.RestockAwardRequest\x1a\x1a.quiz.reward.AwardResponse\"'\x82\xd3\xe4\x93\x02!:\x01*\"\x1c/api/v1/admin/awards/restock\x12r\n" +
+	"\vRetireAward\x12\x1f.quiz.reward.RetireAwardRequest\x1a\x1a.quiz.reward.AwardResponse\"&\x82\xd3\xe4\x93\x02 :\x01*\"\x1b/api/v1/admin/awards/retire\x12\x85\x01\n" +
+	"\x0eUploadVouchers\x12\".quiz.reward.UploadVouchersRequest\x1a#.quiz.reward.UploadVouchersResponse\"*\x82\xd3\xe4\x93\x02$:\x03csv\"\x1d/api/v1/admin/awards/vouchers\x12q\n" +
+	"\fListMyClaims\x12 .quiz.reward.ListMyClaimsRequest\x1a\x1f.quiz.reward.ListClaimsResponse\"\x1e\x82\xd3\xe4\x93\x02\x18\x12\x16/api/v1/rewards/claims\x12k\n" +
 	"\n" +
-	"ClaimAward\x12\x1e.quiz.reward.ClaimAwardRequest\x1a\x1f.quiz.reward.ClaimAwardResponseB?Z=github.com/rprajapati0067/quiz-game-backend/rpc/reward;rewardb\x06proto3"
+	"ListClaims\x12\x1e.quiz.reward.ListClaimsRequest\x1a\x1f.quiz.reward.ListClaimsResponse\"\x1c\x82\xd3\xe4\x93\x02\x16\x12\x14/api/v1/admin/claims\x12x\n" +
+	"\fApproveClaim\x12#.quiz.reward.ClaimTransitionRequest\x1a\x1a.quiz.reward.ClaimResponse\"'\x82\xd3\xe4\x93\x02!:\x01*\"\x1c/api/v1/admin/claims/approve\x12x\n" +
+	"\fFulfillClaim\x12#.quiz.reward.ClaimTransitionRequest\x1a\x1a.quiz.reward.ClaimResponse\"'\x82\xd3\xe4\x93\x02!:\x01*\"\x1c/api/v1/admin/claims/fulfill\x12v\n" +
+	"\vRejectClaim\x12#.quiz.reward.ClaimTransitionRequest\x1a\x1a.quiz.reward.ClaimResponse\"&\x82\xd3\xe4\x93\x02 :\x01*\"\x1b/api/v1/admin/claims/reject\x12v\n" +
+	"\vRefundClaim\x12#.quiz.reward.ClaimTransitionRequest\x1a\x1a.quiz.reward.ClaimResponse\"&\x82\xd3\xe4\x93\x02 :\x01*\"\x1b/api/v1/admin/claims/refundB?Z=github.com/rprajapati0067/quiz-game-backend/rpc/reward;rewardb\x06proto3"
 
 var (
 	file_reward_proto_rawDescOnce sync.Once
@@ -293,25 +1219,68 @@ func file_reward_proto_rawDescGZIP() []byte {
 	return file_reward_proto_rawDescData
 }
 
-var file_reward_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_reward_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
 var file_reward_proto_goTypes = []any{
-	(*Award)(nil),              // 0: quiz.reward.Award
-	(*ListAwardsRequest)(nil),  // 1: quiz.reward.ListAwardsRequest
-	(*ListAwardsResponse)(nil), // 2: quiz.reward.ListAwardsResponse
-	(*ClaimAwardRequest)(nil),  // 3: quiz.reward.ClaimAwardRequest
-	(*ClaimAwardResponse)(nil), // 4: quiz.reward.ClaimAwardResponse
+	(*Award)(nil),                  // 0: quiz.reward.Award
+	(*ListAwardsRequest)(nil),      // 1: quiz.reward.ListAwardsRequest
+	(*ListAwardsResponse)(nil),     // 2: quiz.reward.ListAwardsResponse
+	(*ClaimAwardRequest)(nil),      // 3: quiz.reward.ClaimAwardRequest
+	(*ClaimAwardResponse)(nil),     // 4: quiz.reward.ClaimAwardResponse
+	(*Claim)(nil),                  // 5: quiz.reward.Claim
+	(*AwardSpec)(nil),              // 6: quiz.reward.AwardSpec
+	(*CreateAwardRequest)(nil),     // 7: quiz.reward.CreateAwardRequest
+	(*UpdateAwardRequest)(nil),     // 8: quiz.reward.UpdateAwardRequest
+	(*RestockAwardRequest)(nil),    // 9: quiz.reward.RestockAwardRequest
+	(*RetireAwardRequest)(nil),     // 10: quiz.reward.RetireAwardRequest
+	(*AwardResponse)(nil),          // 11: quiz.reward.AwardResponse
+	(*ListMyClaimsRequest)(nil),    // 12: quiz.reward.ListMyClaimsRequest
+	(*ListClaimsRequest)(nil),      // 13: quiz.reward.ListClaimsRequest
+	(*ListClaimsResponse)(nil),     // 14: quiz.reward.ListClaimsResponse
+	(*ClaimTransitionRequest)(nil), // 15: quiz.reward.ClaimTransitionRequest
+	(*ClaimResponse)(nil),          // 16: quiz.reward.ClaimResponse
+	(*UploadVouchersRequest)(nil),  // 17: quiz.reward.UploadVouchersRequest
+	(*UploadVouchersResponse)(nil), // 18: quiz.reward.UploadVouchersResponse
 }
 var file_reward_proto_depIdxs = []int32{
-	0, // 0: quiz.reward.ListAwardsResponse.awards:type_name -> quiz.reward.Award
-	1, // 1: quiz.reward.RewardService.ListAwards:input_type -> quiz.reward.ListAwardsRequest
-	3, // 2: quiz.reward.RewardService.ClaimAward:input_type -> quiz.reward.ClaimAwardRequest
-	2, // 3: quiz.reward.RewardService.ListAwards:output_type -> quiz.reward.ListAwardsResponse
-	4, // 4: quiz.reward.RewardService.ClaimAward:output_type -> quiz.reward.ClaimAwardResponse
-	3, // [3:5] is the sub-list for method output_type
-	1, // [1:3] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	0,  // 0: quiz.reward.ListAwardsResponse.awards:type_name -> quiz.reward.Award
+	5,  // 1: quiz.reward.ClaimAwardResponse.claim:type_name -> quiz.reward.Claim
+	6,  // 2: quiz.reward.CreateAwardRequest.spec:type_name -> quiz.reward.AwardSpec
+	6,  // 3: quiz.reward.UpdateAwardRequest.spec:type_name -> quiz.reward.AwardSpec
+	0,  // 4: quiz.reward.AwardResponse.award:type_name -> quiz.reward.Award
+	5,  // 5: quiz.reward.ListClaimsResponse.claims:type_name -> quiz.reward.Claim
+	5,  // 6: quiz.reward.ClaimResponse.claim:type_name -> quiz.reward.Claim
+	0,  // 7: quiz.reward.UploadVouchersResponse.award:type_name -> quiz.reward.Award
+	1,  // 8: quiz.reward.RewardService.ListAwards:input_type -> quiz.reward.ListAwardsRequest
+	3,  // 9: quiz.reward.RewardService.ClaimAward:input_type -> quiz.reward.ClaimAwardRequest
+	7,  // 10: quiz.reward.RewardService.CreateAward:input_type -> quiz.reward.CreateAwardRequest
+	8,  // 11: quiz.reward.RewardService.UpdateAward:input_type -> quiz.reward.UpdateAwardRequest
+	9,  // 12: quiz.reward.RewardService.RestockAward:input_type -> quiz.reward.RestockAwardRequest
+	10, // 13: quiz.reward.RewardService.RetireAward:input_type -> quiz.reward.RetireAwardRequest
+	17, // 14: quiz.reward.RewardService.UploadVouchers:input_type -> quiz.reward.UploadVouchersRequest
+	12, // 15: quiz.reward.RewardService.ListMyClaims:input_type -> quiz.reward.ListMyClaimsRequest
+	13, // 16: quiz.reward.RewardService.ListClaims:input_type -> quiz.reward.ListClaimsRequest
+	15, // 17: quiz.reward.RewardService.ApproveClaim:input_type -> quiz.reward.ClaimTransitionRequest
+	15, // 18: quiz.reward.RewardService.FulfillClaim:input_type -> quiz.reward.ClaimTransitionRequest
+	15, // 19: quiz.reward.RewardService.RejectClaim:input_type -> quiz.reward.ClaimTransitionRequest
+	15, // 20: quiz.reward.RewardService.RefundClaim:input_type -> quiz.reward.ClaimTransitionRequest
+	2,  // 21: quiz.reward.RewardService.ListAwards:output_type -> quiz.reward.ListAwardsResponse
+	4,  // 22: quiz.reward.RewardService.ClaimAward:output_type -> quiz.reward.ClaimAwardResponse
+	11, // 23: quiz.reward.RewardService.CreateAward:output_type -> quiz.reward.AwardResponse
+	11, // 24: quiz.reward.RewardService.UpdateAward:output_type -> quiz.reward.AwardResponse
+	11, // 25: quiz.reward.RewardService.RestockAward:output_type -> quiz.reward.AwardResponse
+	11, // 26: quiz.reward.RewardService.RetireAward:output_type -> quiz.reward.AwardResponse
+	18, // 27: quiz.reward.RewardService.UploadVouchers:output_type -> quiz.reward.UploadVouchersResponse
+	14, // 28: quiz.reward.RewardService.ListMyClaims:output_type -> quiz.reward.ListClaimsResponse
+	14, // 29: quiz.reward.RewardService.ListClaims:output_type -> quiz.reward.ListClaimsResponse
+	16, // 30: quiz.reward.RewardService.ApproveClaim:output_type -> quiz.reward.ClaimResponse
+	16, // 31: quiz.reward.RewardService.FulfillClaim:output_type -> quiz.reward.ClaimResponse
+	16, // 32: quiz.reward.RewardService.RejectClaim:output_type -> quiz.reward.ClaimResponse
+	16, // 33: quiz.reward.RewardService.RefundClaim:output_type -> quiz.reward.ClaimResponse
+	21, // [21:34] is the sub-list for method output_type
+	8,  // [8:21] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
 }
 
 func init() { file_reward_proto_init() }
@@ -325,7 +1294,7 @@ func file_reward_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_reward_proto_rawDesc), len(file_reward_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   5,
+			NumMessages:   19,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,8 +19,19 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	RewardService_ListAwards_FullMethodName = "/quiz.reward.RewardService/ListAwards"
-	RewardService_ClaimAward_FullMethodName = "/quiz.reward.RewardService/ClaimAward"
+	RewardService_ListAwards_FullMethodName     = "/quiz.reward.RewardService/ListAwards"
+	RewardService_ClaimAward_FullMethodName     = "/quiz.reward.RewardService/ClaimAward"
+	RewardService_CreateAward_FullMethodName    = "/quiz.reward.RewardService/CreateAward"
+	RewardService_UpdateAward_FullMethodName    = "/quiz.reward.RewardService/UpdateAward"
+	RewardService_RestockAward_FullMethodName   = "/quiz.reward.RewardService/RestockAward"
+	RewardService_RetireAward_FullMethodName    = "/quiz.reward.RewardService/RetireAward"
+	RewardService_UploadVouchers_FullMethodName = "/quiz.reward.RewardService/UploadVouchers"
+	RewardService_ListMyClaims_FullMethodName   = "/quiz.reward.RewardService/ListMyClaims"
+	RewardService_ListClaims_FullMethodName     = "/quiz.reward.RewardService/ListClaims"
+	RewardService_ApproveClaim_FullMethodName   = "/quiz.reward.RewardService/ApproveClaim"
+	RewardService_FulfillClaim_FullMethodName   = "/quiz.reward.RewardService/FulfillClaim"
+	RewardService_RejectClaim_FullMethodName    = "/quiz.reward.RewardService/RejectClaim"
+	RewardService_RefundClaim_FullMethodName    = "/quiz.reward.RewardService/RefundClaim"
 )
 
 // RewardServiceClient is the client API for RewardService service.
@@ -29,6 +40,29 @@ const (
 type RewardServiceClient interface {
 	ListAwards(ctx context.Context, in *ListAwardsRequest, opts ...grpc.CallOption) (*ListAwardsResponse, error)
 	ClaimAward(ctx context.Context, in *ClaimAwardRequest, opts ...grpc.CallOption) (*ClaimAwardResponse, error)
+	// Admin only.
+	CreateAward(ctx context.Context, in *CreateAwardRequest, opts ...grpc.CallOption) (*AwardResponse, error)
+	// Admin only. Stock is changed with RestockAward.
+	UpdateAward(ctx context.Context, in *UpdateAwardRequest, opts ...grpc.CallOption) (*AwardResponse, error)
+	// Admin only.
+	RestockAward(ctx context.Context, in *RestockAwardRequest, opts ...grpc.CallOption) (*AwardResponse, error)
+	// Admin only. Retired awards can no longer be claimed.
+	RetireAward(ctx context.Context, in *RetireAwardRequest, opts ...grpc.CallOption) (*AwardResponse, error)
+	// Admin only. Adds codes to a digital award's voucher pool.
+	UploadVouchers(ctx context.Context, in *UploadVouchersRequest, opts ...grpc.CallOption) (*UploadVouchersResponse, error)
+	// The caller's claims, newest first.
+	ListMyClaims(ctx context.Context, in *ListMyClaimsRequest, opts ...grpc.CallOption) (*ListClaimsResponse, error)
+	// Admin and support: claims in one status, oldest first.
+	ListClaims(ctx context.Context, in *ListClaimsRequest, opts ...grpc.CallOption) (*ListClaimsResponse, error)
+	// Admin and support. pending -> approved.
+	ApproveClaim(ctx context.Context, in *ClaimTransitionRequest, opts ...grpc.CallOption) (*ClaimResponse, error)
+	// Admin and support. approved -> fulfilled.
+	FulfillClaim(ctx context.Context, in *ClaimTransitionRequest, opts ...grpc.CallOption) (*ClaimResponse, error)
+	// Admin and support. pending or approved -> rejected -> refunded; the
+	// points go back to the player.
+	RejectClaim(ctx context.Context, in *ClaimTransitionRequest, opts ...grpc.CallOption) (*ClaimResponse, error)
+	// Admin and support. Retries the refund of a rejected claim.
+	RefundClaim(ctx context.Context, in *ClaimTransitionRequest, opts ...grpc.CallOption) (*ClaimResponse, error)
 }
 
 type rewardServiceClient struct {
@@ -59,12 +93,145 @@ func (c *rewardServiceClient) ClaimAward(ctx context.Context, in *ClaimAwardRequ
 	return out, nil
 }
 
+func (c *rewardServiceClient) CreateAward(ctx context.Context, in *CreateAwardRequest, opts ...grpc.CallOption) (*AwardResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AwardResponse)
+	err := c.cc.Invoke(ctx, RewardService_CreateAward_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rewardServiceClient) UpdateAward(ctx context.Context, in *UpdateAwardRequest, opts ...grpc.CallOption) (*AwardResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AwardResponse)
+	err := c.cc.Invoke(ctx, RewardService_UpdateAward_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rewardServiceClient) RestockAward(ctx context.Context, in *RestockAwardRequest, opts ...grpc.CallOption) (*AwardResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AwardResponse)
+	err := c.cc.Invoke(ctx, RewardService_RestockAward_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rewardServiceClient) RetireAward(ctx context.Context, in *RetireAwardRequest, opts ...grpc.CallOption) (*AwardResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AwardResponse)
+	err := c.cc.Invoke(ctx, RewardService_RetireAward_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rewardServiceClient) UploadVouchers(ctx context.Context, in *UploadVouchersRequest, opts ...grpc.CallOption) (*UploadVouchersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UploadVouchersResponse)
+	err := c.cc.Invoke(ctx, RewardService_UploadVouchers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rewardServiceClient) ListMyClaims(ctx context.Context, in *ListMyClaimsRequest, opts ...grpc.CallOption) (*ListClaimsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListClaimsResponse)
+	err := c.cc.Invoke(ctx, RewardService_ListMyClaims_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rewardServiceClient) ListClaims(ctx context.Context, in *ListClaimsRequest, opts ...grpc.CallOption) (*ListClaimsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListClaimsResponse)
+	err := c.cc.Invoke(ctx, RewardService_ListClaims_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rewardServiceClient) ApproveClaim(ctx context.Context, in *ClaimTransitionRequest, opts ...grpc.CallOption) (*ClaimResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ClaimResponse)
+	err := c.cc.Invoke(ctx, RewardService_ApproveClaim_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rewardServiceClient) FulfillClaim(ctx context.Context, in *ClaimTransitionRequest, opts ...grpc.CallOption) (*ClaimResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ClaimResponse)
+	err := c.cc.Invoke(ctx, RewardService_FulfillClaim_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rewardServiceClient) RejectClaim(ctx context.Context, in *ClaimTransitionRequest, opts ...grpc.CallOption) (*ClaimResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ClaimResponse)
+	err := c.cc.Invoke(ctx, RewardService_RejectClaim_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rewardServiceClient) RefundClaim(ctx context.Context, in *ClaimTransitionRequest, opts ...grpc.CallOption) (*ClaimResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ClaimResponse)
+	err := c.cc.Invoke(ctx, RewardService_RefundClaim_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // RewardServiceServer is the server API for RewardService service.
 // All implementations must embed UnimplementedRewardServiceServer
 // for forward compatibility.
 type RewardServiceServer interface {
 	ListAwards(context.Context, *ListAwardsRequest) (*ListAwardsResponse, error)
 	ClaimAward(context.Context, *ClaimAwardRequest) (*ClaimAwardResponse, error)
+	// Admin only.
+	CreateAward(context.Context, *CreateAwardRequest) (*AwardResponse, error)
+	// Admin only. Stock is changed with RestockAward.
+	UpdateAward(context.Context, *UpdateAwardRequest) (*AwardResponse, error)
+	// Admin only.
+	RestockAward(context.Context, *RestockAwardRequest) (*AwardResponse, error)
+	// Admin only. Retired awards can no longer be claimed.
+	RetireAward(context.Context, *RetireAwardRequest) (*AwardResponse, error)
+	// Admin only. Adds codes to a digital award's voucher pool.
+	UploadVouchers(context.Context, *UploadVouchersRequest) (*UploadVouchersResponse, error)
+	// The caller's claims, newest first.
+	ListMyClaims(context.Context, *ListMyClaimsRequest) (*ListClaimsResponse, error)
+	// Admin and support: claims in one status, oldest first.
+	ListClaims(context.Context, *ListClaimsRequest) (*ListClaimsResponse, error)
+	// Admin and support. pending -> approved.
+	ApproveClaim(context.Context, *ClaimTransitionRequest) (*ClaimResponse, error)
+	// Admin and support. approved -> fulfilled.
+	FulfillClaim(context.Context, *ClaimTransitionRequest) (*ClaimResponse, error)
+	// Admin and support. pending or approved -> rejected -> refunded; the
+	// points go back to the player.
+	RejectClaim(context.Context, *ClaimTransitionRequest) (*ClaimResponse, error)
+	// Admin and support. Retries the refund of a rejected claim.
+	RefundClaim(context.Context, *ClaimTransitionRequest) (*ClaimResponse, error)
 	mustEmbedUnimplementedRewardServiceServer()
 }
 
@@ -81,6 +248,39 @@ func (UnimplementedRewardServiceServer) ListAwards(context.Context, *ListAwardsR
 func (UnimplementedRewardServiceServer) ClaimAward(context.Context, *ClaimAwardRequest) (*ClaimAwardResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ClaimAward not implemented")
 }
+func (UnimplementedRewardServiceServer) CreateAward(context.Context, *CreateAwardRequest) (*AwardResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAward not implemented")
+}
+func (UnimplementedRewardServiceServer) UpdateAward(context.Context, *UpdateAwardRequest) (*AwardResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateAward not implemented")
+}
+func (UnimplementedRewardServiceServer) RestockAward(context.Context, *RestockAwardRequest) (*AwardResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestockAward not implemented")
+}
+func (UnimplementedRewardServiceServer) RetireAward(context.Context, *RetireAwardRequest) (*AwardResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RetireAward not implemented")
+}
+func (UnimplementedRewardServiceServer) UploadVouchers(context.Context, *UploadVouchersRequest) (*UploadVouchersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UploadVouchers not implemented")
+}
+func (UnimplementedRewardServiceServer) ListMyClaims(context.Context, *ListMyClaimsRequest) (*ListClaimsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMyClaims not implemented")
+}
+func (UnimplementedRewardServiceServer) ListClaims(context.Context, *ListClaimsRequest) (*ListClaimsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListClaims not implemented")
+}
+func (UnimplementedRewardServiceServer) ApproveClaim(context.Context, *ClaimTransitionRequest) (*ClaimResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApproveClaim not implemented")
+}
+func (UnimplementedRewardServiceServer) FulfillClaim(context.Context, *ClaimTransitionRequest) (*ClaimResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FulfillClaim not implemented")
+}
+func (UnimplementedRewardServiceServer) RejectClaim(context.Context, *ClaimTransitionRequest) (*ClaimResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RejectClaim not implemented")
+}
+func (UnimplementedRewardServiceServer) RefundClaim(context.Context, *ClaimTransitionRequest) (*ClaimResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefundClaim not implemented")
+}
 func (UnimplementedRewardServiceServer) mustEmbedUnimplementedRewardServiceServer() {}
 func (UnimplementedRewardServiceServer) testEmbeddedByValue()                       {}
 
@@ -138,6 +338,204 @@ func _RewardService_ClaimAward_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RewardService_CreateAward_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAwardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RewardServiceServer).CreateAward(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RewardService_CreateAward_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RewardServiceServer).CreateAward(ctx, req.(*CreateAwardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RewardService_UpdateAward_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateAwardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RewardServiceServer).UpdateAward(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RewardService_UpdateAward_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RewardServiceServer).UpdateAward(ctx, req.(*UpdateAwardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RewardService_RestockAward_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestockAwardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RewardServiceServer).RestockAward(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RewardService_RestockAward_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RewardServiceServer).RestockAward(ctx, req.(*RestockAwardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RewardService_RetireAward_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RetireAwardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RewardServiceServer).RetireAward(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RewardService_RetireAward_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RewardServiceServer).RetireAward(ctx, req.(*RetireAwardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RewardService_UploadVouchers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UploadVouchersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RewardServiceServer).UploadVouchers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RewardService_UploadVouchers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RewardServiceServer).UploadVouchers(ctx, req.(*UploadVouchersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RewardService_ListMyClaims_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMyClaimsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RewardServiceServer).ListMyClaims(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RewardService_ListMyClaims_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RewardServiceServer).ListMyClaims(ctx, req.(*ListMyClaimsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RewardService_ListClaims_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListClaimsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RewardServiceServer).ListClaims(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RewardService_ListClaims_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RewardServiceServer).ListClaims(ctx, req.(*ListClaimsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RewardService_ApproveClaim_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClaimTransitionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RewardServiceServer).ApproveClaim(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RewardService_ApproveClaim_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RewardServiceServer).ApproveClaim(ctx, req.(*ClaimTransitionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RewardService_FulfillClaim_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClaimTransitionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RewardServiceServer).FulfillClaim(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RewardService_FulfillClaim_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RewardServiceServer).FulfillClaim(ctx, req.(*ClaimTransitionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RewardService_RejectClaim_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClaimTransitionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RewardServiceServer).RejectClaim(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RewardService_RejectClaim_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RewardServiceServer).RejectClaim(ctx, req.(*ClaimTransitionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RewardService_RefundClaim_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClaimTransitionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RewardServiceServer).RefundClaim(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RewardService_RefundClaim_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RewardServiceServer).RefundClaim(ctx, req.(*ClaimTransitionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // RewardService_ServiceDesc is the grpc.ServiceDesc for RewardService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -153,6 +551,50 @@ var RewardService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ClaimAward",
 			Handler:    _RewardService_ClaimAward_Handler,
 		},
+		{
+			MethodName: "CreateAward",
+			Handler:    _RewardService_CreateAward_Handler,
+		},
+		{
+			MethodName: "UpdateAward",
+			Handler:    _RewardService_UpdateAward_Handler,
+		},
+		{
+			MethodName: "RestockAward",
+			Handler:    _RewardService_RestockAward_Handler,
+		},
+		{
+			MethodName: "RetireAward",
+			Handler:    _RewardService_RetireAward_Handler,
+		},
+		{
+			MethodName: "UploadVouchers",
+			Handler:    _RewardService_UploadVouchers_Handler,
+		},
+		{
+			MethodName: "ListMyClaims",
+			Handler:    _RewardService_ListMyClaims_Handler,
+		},
+		{
+			MethodName: "ListClaims",
+			Handler:    _RewardService_ListClaims_Handler,
+		},
+		{
+			MethodName: "ApproveClaim",
+			Handler:    _RewardService_ApproveClaim_Handler,
+		},
+		{
+			MethodName: "FulfillClaim",
+			Handler:    _RewardService_FulfillClaim_Handler,
+		},
+		{
+			MethodName: "RejectClaim",
+			Handler:    _RewardService_RejectClaim_Handler,
+		},
+		{
+			MethodName: "RefundClaim",
+			Handler:    _RewardService_RefundClaim_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "reward.proto",